@@ -14,6 +14,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/dEnchanter/OddsIQ/backend/config"
 	"github.com/dEnchanter/OddsIQ/backend/internal/api"
+	"github.com/dEnchanter/OddsIQ/backend/internal/services"
 	"github.com/dEnchanter/OddsIQ/backend/pkg/database"
 )
 
@@ -29,36 +30,79 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
-	defer db.Close()
 
 	log.Println("✓ Connected to database successfully")
 
+	// Bring the schema up to date before anything queries it
+	if cfg.AutoMigrate {
+		if cfg.MigrationsBaseline > 0 {
+			if err := database.Baseline(context.Background(), db.Pool, cfg.MigrationsBaseline); err != nil {
+				log.Fatalf("Failed to record migration baseline: %v", err)
+			}
+		}
+		if err := database.ApplyMigrations(context.Background(), db.Pool); err != nil {
+			log.Fatalf("Failed to apply migrations: %v", err)
+		}
+		log.Println("✓ Database schema is up to date")
+	}
+
 	// Set Gin mode
 	if cfg.Env == "production" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
-	// Create router
-	router := gin.Default()
+	// Create router, logging each request with its request ID
+	router := gin.New()
+	router.Use(gin.Recovery(), api.RequestLogger())
 
 	// Configure CORS
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:3000", "http://127.0.0.1:3000"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-API-Key", "X-Request-ID"},
+		ExposeHeaders:    []string{"Content-Length", "Retry-After", "X-Request-ID"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
 
+	if len(cfg.APIKeys) == 0 {
+		if cfg.Env == "production" {
+			log.Fatal("API_KEYS must be set when ENV=production")
+		}
+		log.Println("Warning: API_KEYS is not set, write endpoints are unprotected")
+	}
+
 	// Setup routes
-	api.SetupRoutes(router, db.Pool, cfg)
+	server := api.SetupRoutes(router, db.Pool, cfg)
+
+	// Apply settings changed through the API, keeping defaults if they can't be loaded
+	if err := server.LoadSettings(context.Background()); err != nil {
+		log.Printf("Warning: failed to load stored settings: %v", err)
+	}
+
+	// Start the sync scheduler if enabled
+	var scheduler *services.Scheduler
+	if cfg.EnableScheduler {
+		scheduler, err = server.NewScheduler()
+		if err != nil {
+			log.Fatalf("Failed to create scheduler: %v", err)
+		}
+
+		start := scheduler.Start
+		if cfg.Env != "production" {
+			start = scheduler.StartDevelopmentSchedule
+		}
+		if err := start(); err != nil {
+			log.Fatalf("Failed to start scheduler: %v", err)
+		}
+	}
 
 	// Create server
 	srv := &http.Server{
 		Addr:    fmt.Sprintf(":%s", cfg.Port),
 		Handler: router,
 	}
+	srv.RegisterOnShutdown(server.CloseStreams)
 
 	// Start server in goroutine
 	go func() {
@@ -76,12 +120,24 @@ func main() {
 	log.Println("Shutting down server...")
 
 	// Graceful shutdown with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
 	defer cancel()
 
+	// Stop scheduled jobs first so no new syncs start while draining
+	if scheduler != nil {
+		scheduler.Stop()
+	}
+
+	// Stop accepting connections and let in-flight requests complete
 	if err := srv.Shutdown(ctx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+		log.Printf("Server forced to shutdown: %v", err)
+	}
+
+	// Cancel on-demand sync jobs and wait for them before closing the pool
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("Background jobs did not finish: %v", err)
 	}
 
+	db.Close()
 	log.Println("Server exited")
 }
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/dEnchanter/OddsIQ/backend/config"
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
 	"github.com/dEnchanter/OddsIQ/backend/internal/repository"
 	"github.com/dEnchanter/OddsIQ/backend/internal/services"
 	"github.com/dEnchanter/OddsIQ/backend/pkg/apifootball"
@@ -18,8 +19,13 @@ import (
 func main() {
 	// Command-line flags
 	seasonsFlag := flag.String("seasons", "2022,2023,2024,2025", "Comma-separated list of seasons to backfill")
+	leaguesFlag := flag.String("leagues", "", "Comma-separated API-Football league IDs (default: LEAGUES env)")
 	teamsOnly := flag.Bool("teams-only", false, "Only sync teams, skip fixtures")
 	fixturesOnly := flag.Bool("fixtures-only", false, "Only sync fixtures, skip teams")
+	requestsPerMinute := flag.Int("rpm", 10, "Maximum API-Football requests per minute (0 = unlimited)")
+	workers := flag.Int("workers", 4, "Fixtures stored concurrently within a round")
+	bulk := flag.Bool("bulk", true, "Store a season's fixtures with one bulk upsert (COPY) instead of a statement per fixture")
+	force := flag.Bool("force", false, "Re-run seasons completed by a previous backfill")
 	help := flag.Bool("help", false, "Show help")
 
 	flag.Parse()
@@ -29,19 +35,35 @@ func main() {
 		return
 	}
 
+	if *workers < 1 {
+		log.Fatalf("Invalid workers: %d, must be at least 1", *workers)
+	}
+
 	// Parse seasons
 	seasons, err := parseSeasons(*seasonsFlag)
 	if err != nil {
 		log.Fatalf("Invalid seasons format: %v", err)
 	}
 
-	log.Printf("Starting backfill for seasons: %v", seasons)
-
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
+	if err := cfg.RequireAPIFootballKey(); err != nil {
+		log.Fatalf("Invalid config: %v", err)
+	}
+
+	// Parse leagues, falling back to configured leagues
+	leagueIDs := cfg.LeagueIDs
+	if *leaguesFlag != "" {
+		leagueIDs, err = parseLeagues(*leaguesFlag)
+		if err != nil {
+			log.Fatalf("Invalid leagues format: %v", err)
+		}
+	}
+
+	log.Printf("Starting backfill for leagues %v, seasons: %v", leagueIDs, seasons)
 
 	// Initialize database
 	db, err := database.New(cfg.DatabaseURL)
@@ -53,55 +75,188 @@ func main() {
 	log.Println("Connected to database")
 
 	// Initialize API clients
-	apiFootballClient := apifootball.NewClient(cfg.APIFootballKey)
+	apiFootballClient := apifootball.NewClient(cfg.APIFootballKey, *requestsPerMinute)
 
 	// Initialize repositories
 	teamsRepo := repository.NewTeamsRepository(db.Pool)
 	fixturesRepo := repository.NewFixturesRepository(db.Pool)
+	leaguesRepo := repository.NewLeaguesRepository(db.Pool)
 
 	// Initialize sync service
 	fixtureSyncService := services.NewFixtureSyncService(
 		apiFootballClient,
 		teamsRepo,
 		fixturesRepo,
+		leaguesRepo,
+		nil, // Bets are settled by the API's results sync
+		nil, // Season backfills aren't recorded in sync_status
+		leagueIDs,
+	)
+
+	teamStatsRepo := repository.NewTeamStatsRepository(db.Pool)
+	standingsSyncService := services.NewStandingsSyncService(
+		apiFootballClient,
+		teamsRepo,
+		teamStatsRepo,
+		leaguesRepo,
+		leagueIDs,
 	)
+	teamStatsService := services.NewTeamStatsService(fixturesRepo, teamStatsRepo)
+	progressRepo := repository.NewBackfillProgressRepository(db.Pool)
 
 	// Create context
 	ctx := context.Background()
 
-	// Execute backfill
-	for _, season := range seasons {
-		log.Printf("\n=== Processing Season %d ===\n", season)
+	// Load checkpoints from earlier runs
+	progress, err := loadProgress(ctx, progressRepo, leagueIDs, seasons, *force)
+	if err != nil {
+		log.Fatalf("Failed to load backfill progress: %v", err)
+	}
+	printResumeSummary(leagueIDs, seasons, progress, *force)
 
-		// Sync teams (unless fixtures-only)
-		if !*fixturesOnly {
-			log.Printf("Syncing teams for season %d...", season)
-			if err := fixtureSyncService.SyncTeams(ctx, season); err != nil {
-				log.Printf("ERROR: Failed to sync teams: %v", err)
+	// Execute backfill
+	for _, leagueID := range leagueIDs {
+		for _, season := range seasons {
+			p := progress[seasonKey{leagueID, season}]
+			needTeams := !*fixturesOnly && !p.TeamsDone
+			needFixtures := !*teamsOnly && !p.FixturesDone
+			if !needTeams && !needFixtures {
+				log.Printf("Skipping League %d Season %d: already completed", leagueID, season)
 				continue
 			}
-			log.Println("✓ Teams synced successfully")
-		}
 
-		// Sync fixtures (unless teams-only)
-		if !*teamsOnly {
-			log.Printf("Syncing fixtures for season %d...", season)
-			if err := fixtureSyncService.SyncFixturesBySeason(ctx, season); err != nil {
-				log.Printf("ERROR: Failed to sync fixtures: %v", err)
-				continue
+			log.Printf("\n=== Processing League %d Season %d ===\n", leagueID, season)
+
+			// Sync teams (unless fixtures-only or already done)
+			if needTeams {
+				log.Printf("Syncing teams for season %d...", season)
+				if err := fixtureSyncService.SyncTeams(ctx, leagueID, season); err != nil {
+					log.Printf("ERROR: Failed to sync teams: %v", err)
+					continue
+				}
+				if err := progressRepo.MarkTeamsDone(ctx, leagueID, season); err != nil {
+					log.Printf("WARNING: %v", err)
+				}
+				log.Println("✓ Teams synced successfully")
 			}
-			log.Println("✓ Fixtures synced successfully")
-		}
 
-		log.Printf("=== Completed Season %d ===\n", season)
+			// Sync fixtures (unless teams-only or already done), resuming after the
+			// last checkpointed round
+			if needFixtures {
+				log.Printf("Syncing fixtures for season %d...", season)
+				opts := services.SeasonSyncOptions{
+					OnRound: func(ctx context.Context, round string, synced int) error {
+						return progressRepo.RecordRound(ctx, leagueID, season, round, p.FixturesSynced+synced)
+					},
+					Workers: *workers,
+					Bulk:    *bulk,
+				}
+				if p.LastRound != nil {
+					opts.ResumeAfter = *p.LastRound
+				}
+				if err := fixtureSyncService.SyncFixturesBySeason(ctx, leagueID, season, opts); err != nil {
+					log.Printf("ERROR: Failed to sync fixtures: %v", err)
+					continue
+				}
+				log.Println("✓ Fixtures synced successfully")
+
+				log.Printf("Syncing standings for season %d...", season)
+				if err := standingsSyncService.SyncLeagueStandings(ctx, leagueID, season); err != nil {
+					// Fall back to deriving stats from the fixtures we just stored
+					log.Printf("Standings unavailable (%v), computing team stats from fixtures", err)
+					if league, err := leaguesRepo.GetByAPIFootballID(ctx, leagueID); err != nil {
+						log.Printf("ERROR: Failed to resolve league for team stats: %v", err)
+					} else if err := teamStatsService.ComputeSeasonStats(ctx, league.ID, season); err != nil {
+						log.Printf("ERROR: Failed to compute team stats: %v", err)
+					}
+				} else {
+					log.Println("✓ Standings synced successfully")
+				}
+
+				if err := progressRepo.MarkFixturesDone(ctx, leagueID, season); err != nil {
+					log.Printf("WARNING: %v", err)
+				}
+			}
+
+			log.Printf("=== Completed League %d Season %d ===\n", leagueID, season)
+		}
 	}
 
 	// Print summary
 	printSummary(ctx, teamsRepo, fixturesRepo)
 
+	quota := apiFootballClient.RemainingQuota()
+	log.Printf("API-Football quota remaining: %d/%d today", quota.DailyRemaining, quota.DailyLimit)
+
 	log.Println("\n✓ Backfill completed successfully")
 }
 
+// seasonKey identifies a league season in the backfill
+type seasonKey struct {
+	leagueID int
+	season   int
+}
+
+// loadProgress loads the checkpoint of every league season to backfill. Seasons with no
+// progress (or all seasons, with force) start from scratch.
+func loadProgress(ctx context.Context, repo *repository.BackfillProgressRepository, leagueIDs, seasons []int, force bool) (map[seasonKey]*models.BackfillProgress, error) {
+	progress := make(map[seasonKey]*models.BackfillProgress)
+	for _, leagueID := range leagueIDs {
+		for _, season := range seasons {
+			key := seasonKey{leagueID, season}
+			progress[key] = &models.BackfillProgress{LeagueID: leagueID, Season: season}
+
+			if force {
+				if err := repo.Reset(ctx, leagueID, season); err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			p, found, err := repo.Get(ctx, leagueID, season)
+			if err != nil {
+				return nil, err
+			}
+			if found {
+				progress[key] = p
+			}
+		}
+	}
+
+	return progress, nil
+}
+
+// printResumeSummary logs what the backfill will do for each league season
+func printResumeSummary(leagueIDs, seasons []int, progress map[seasonKey]*models.BackfillProgress, force bool) {
+	log.Println("\n=== Resume Summary ===")
+	if force {
+		log.Println("-force set: previous progress cleared, backfilling all seasons")
+	}
+
+	for _, leagueID := range leagueIDs {
+		for _, season := range seasons {
+			p := progress[seasonKey{leagueID, season}]
+
+			var state string
+			switch {
+			case p.TeamsDone && p.FixturesDone:
+				state = "complete, skipping"
+			case p.FixturesDone:
+				state = "fixtures done, teams not synced"
+			case p.LastRound != nil:
+				state = fmt.Sprintf("fixtures resume after round %q (%d stored)", *p.LastRound, p.FixturesSynced)
+			case p.TeamsDone:
+				state = "teams done, fixtures not started"
+			default:
+				state = "not started"
+			}
+			log.Printf("League %d Season %d: %s", leagueID, season, state)
+		}
+	}
+
+	log.Println("======================")
+}
+
 func parseSeasons(seasonsStr string) ([]int, error) {
 	parts := strings.Split(seasonsStr, ",")
 	seasons := make([]int, 0, len(parts))
@@ -118,6 +273,22 @@ func parseSeasons(seasonsStr string) ([]int, error) {
 	return seasons, nil
 }
 
+func parseLeagues(leaguesStr string) ([]int, error) {
+	parts := strings.Split(leaguesStr, ",")
+	leagues := make([]int, 0, len(parts))
+
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		leagueID, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid league: %s", part)
+		}
+		leagues = append(leagues, leagueID)
+	}
+
+	return leagues, nil
+}
+
 func printSummary(ctx context.Context, teamsRepo *repository.TeamsRepository, fixturesRepo *repository.FixturesRepository) {
 	log.Println("\n=== Backfill Summary ===")
 
@@ -132,7 +303,7 @@ func printSummary(ctx context.Context, teamsRepo *repository.TeamsRepository, fi
 	// Count fixtures by season
 	seasons := []int{2022, 2023, 2024, 2025}
 	for _, season := range seasons {
-		fixtures, err := fixturesRepo.GetBySeason(ctx, season)
+		fixtures, err := fixturesRepo.GetBySeason(ctx, season, 0)
 		if err != nil {
 			log.Printf("Failed to count fixtures for season %d: %v", season, err)
 		} else {
@@ -152,10 +323,21 @@ func printHelp() {
 	fmt.Println("Flags:")
 	fmt.Println("  -seasons string")
 	fmt.Println("        Comma-separated list of seasons to backfill (default \"2022,2023,2024,2025\")")
+	fmt.Println("  -leagues string")
+	fmt.Println("        Comma-separated API-Football league IDs (default from LEAGUES, e.g. \"39,140,78\")")
 	fmt.Println("  -teams-only")
 	fmt.Println("        Only sync teams, skip fixtures")
 	fmt.Println("  -fixtures-only")
 	fmt.Println("        Only sync fixtures, skip teams")
+	fmt.Println("  -rpm int")
+	fmt.Println("        Maximum API-Football requests per minute, 0 = unlimited (default 10)")
+	fmt.Println("  -workers int")
+	fmt.Println("        Fixtures stored concurrently within a round (default 4)")
+	fmt.Println("  -bulk")
+	fmt.Println("        Store a season's fixtures with one bulk upsert via COPY; -bulk=false")
+	fmt.Println("        upserts fixtures one statement at a time, checkpointing each round (default true)")
+	fmt.Println("  -force")
+	fmt.Println("        Re-run seasons completed by a previous backfill instead of skipping them")
 	fmt.Println("  -help")
 	fmt.Println("        Show this help message")
 	fmt.Println()
@@ -166,11 +348,18 @@ func printHelp() {
 	fmt.Println("  # Backfill only 2024 season")
 	fmt.Println("  go run cmd/backfill/main.go -seasons 2024")
 	fmt.Println()
+	fmt.Println("  # Backfill La Liga and Bundesliga for 2024")
+	fmt.Println("  go run cmd/backfill/main.go -leagues 140,78 -seasons 2024")
+	fmt.Println()
+	fmt.Println("  # Re-run 2024 from scratch, ignoring saved progress")
+	fmt.Println("  go run cmd/backfill/main.go -seasons 2024 -force")
+	fmt.Println()
 	fmt.Println("  # Backfill only teams")
 	fmt.Println("  go run cmd/backfill/main.go -teams-only")
 	fmt.Println()
 	fmt.Println("Environment Variables:")
 	fmt.Println("  DATABASE_URL         PostgreSQL connection string")
 	fmt.Println("  API_FOOTBALL_KEY     API-Football API key")
+	fmt.Println("  LEAGUES              Default league IDs when -leagues is not set")
 	fmt.Println()
 }
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dEnchanter/OddsIQ/backend/config"
+	"github.com/dEnchanter/OddsIQ/backend/internal/repository"
+	"github.com/dEnchanter/OddsIQ/backend/internal/services"
+	"github.com/dEnchanter/OddsIQ/backend/pkg/database"
+)
+
+func main() {
+	// Command-line flags
+	season := flag.Int("season", 0, "Season to backtest (e.g. 2024)")
+	marketsFlag := flag.String("markets", "h2h,totals,btts", "Comma-separated markets to bet on (h2h, totals, btts, spreads)")
+	bankroll := flag.Float64("bankroll", 0, "Starting bankroll (default INITIAL_BANKROLL)")
+	minEV := flag.Float64("min-ev", -1, "Minimum EV for a bet (default MIN_EV_THRESHOLD)")
+	cutoff := flag.Duration("cutoff", time.Hour, "Only use odds recorded at least this long before kickoff")
+	verbose := flag.Bool("v", false, "Print every simulated bet")
+	help := flag.Bool("help", false, "Show help")
+
+	flag.Parse()
+
+	if *help {
+		printHelp()
+		return
+	}
+
+	if *season == 0 {
+		log.Fatal("-season is required")
+	}
+
+	markets, err := parseMarkets(*marketsFlag)
+	if err != nil {
+		log.Fatalf("Invalid markets: %v", err)
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	if *bankroll <= 0 {
+		*bankroll = cfg.InitialBankroll
+	}
+	if *minEV < 0 {
+		*minEV = cfg.MinEVThreshold
+	}
+
+	// Initialize database
+	db, err := database.New(cfg.DatabaseURL)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	backtester := services.NewBacktester(
+		cfg,
+		services.NewMLClient(cfg.MLServiceURL),
+		repository.NewFixturesRepository(db.Pool),
+		repository.NewOddsRepository(db.Pool),
+	)
+
+	log.Printf("Backtesting season %d (markets %v, bankroll %.2f, min EV %.3f, cutoff %s)",
+		*season, markets, *bankroll, *minEV, *cutoff)
+
+	result, err := backtester.Run(context.Background(), services.BacktestOptions{
+		Season:   *season,
+		Markets:  markets,
+		Bankroll: *bankroll,
+		MinEV:    *minEV,
+		Cutoff:   *cutoff,
+	})
+	if err != nil {
+		log.Fatalf("Backtest failed: %v", err)
+	}
+
+	if *verbose {
+		printBets(result)
+	}
+	printProfitCurve(result)
+	printSummary(result)
+}
+
+func parseMarkets(marketsStr string) ([]services.MarketType, error) {
+	var markets []services.MarketType
+	for _, part := range strings.Split(marketsStr, ",") {
+		market, ok := services.ParseMarketType(part)
+		if !ok {
+			return nil, fmt.Errorf("unknown market: %s", strings.TrimSpace(part))
+		}
+		markets = append(markets, market)
+	}
+	return markets, nil
+}
+
+func printBets(result *services.BacktestResult) {
+	fmt.Println("\n=== Bets ===")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DATE\tFIXTURE\tMARKET\tOUTCOME\tODDS\tSTAKE\tRESULT\tP/L\tCLV\tBANKROLL")
+	for _, bet := range result.Bets {
+		clv := "-"
+		if bet.CLV != nil {
+			clv = fmt.Sprintf("%+.2f%%", *bet.CLV*100)
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%.2f\t%.2f\t%s\t%+.2f\t%s\t%.2f\n",
+			bet.MatchDate.Format("2006-01-02"), bet.FixtureID, bet.Market, bet.Outcome,
+			bet.Odds, bet.Stake, bet.Result, bet.ProfitLoss, clv, bet.BankrollAfter)
+	}
+	w.Flush()
+}
+
+// printProfitCurve prints the bankroll at the end of each month
+func printProfitCurve(result *services.BacktestResult) {
+	fmt.Println("\n=== Profit Curve ===")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "MONTH\tBETS\tBANKROLL\tPROFIT")
+
+	month := ""
+	count := 0
+	var last services.BacktestBet
+	flush := func() {
+		if count > 0 {
+			fmt.Fprintf(w, "%s\t%d\t%.2f\t%+.2f\n", month, count, last.BankrollAfter, last.BankrollAfter-result.Options.Bankroll)
+		}
+	}
+
+	for _, bet := range result.Bets {
+		if m := bet.MatchDate.Format("2006-01"); m != month {
+			flush()
+			month, count = m, 0
+		}
+		count++
+		last = bet
+	}
+	flush()
+	w.Flush()
+}
+
+func printSummary(result *services.BacktestResult) {
+	m := result.Metrics
+
+	fmt.Println("\n=== Backtest Summary ===")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Season\t%d\n", result.Options.Season)
+	fmt.Fprintf(w, "Fixtures evaluated\t%d\n", result.FixturesEvaluated)
+	fmt.Fprintf(w, "Fixtures skipped\t%d\n", result.FixturesSkipped)
+	fmt.Fprintf(w, "Bets\t%d (%d won, %d lost)\n", m.TotalBets, m.NumWins, m.NumLosses)
+	fmt.Fprintf(w, "Total staked\t%.2f\n", m.TotalStaked)
+	fmt.Fprintf(w, "Profit\t%+.2f\n", m.TotalProfit)
+	fmt.Fprintf(w, "ROI\t%.2f%%\n", m.ROIPercentage)
+	fmt.Fprintf(w, "Win rate\t%.2f%%\n", m.WinRate*100)
+	fmt.Fprintf(w, "Avg odds\t%.2f\n", m.AvgOdds)
+	fmt.Fprintf(w, "Avg CLV\t%+.2f%%\n", m.CLVAverage*100)
+	fmt.Fprintf(w, "Max drawdown\t%.2f\n", m.MaxDrawdown)
+	fmt.Fprintf(w, "Sharpe ratio\t%.3f\n", m.SharpeRatio)
+	fmt.Fprintf(w, "Bankroll\t%.2f -> %.2f\n", result.Options.Bankroll, result.FinalBankroll)
+	w.Flush()
+}
+
+func printHelp() {
+	fmt.Println("OddsIQ Strategy Backtest Tool")
+	fmt.Println()
+	fmt.Println("Replays a season's finished fixtures through the betting logic using odds")
+	fmt.Println("stored before kickoff, then settles the picks against actual results.")
+	fmt.Println()
+	fmt.Println("Usage:")
+	fmt.Println("  go run cmd/backtest/main.go -season 2024 [flags]")
+	fmt.Println()
+	fmt.Println("Flags:")
+	fmt.Println("  -season int")
+	fmt.Println("        Season to backtest (required)")
+	fmt.Println("  -markets string")
+	fmt.Println("        Comma-separated markets to bet on: h2h, totals, btts, spreads (default \"h2h,totals,btts\")")
+	fmt.Println("  -bankroll float")
+	fmt.Println("        Starting bankroll (default INITIAL_BANKROLL)")
+	fmt.Println("  -min-ev float")
+	fmt.Println("        Minimum EV for a bet (default MIN_EV_THRESHOLD)")
+	fmt.Println("  -cutoff duration")
+	fmt.Println("        Only use odds recorded at least this long before kickoff (default 1h)")
+	fmt.Println("  -v")
+	fmt.Println("        Print every simulated bet")
+	fmt.Println("  -help")
+	fmt.Println("        Show this help message")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  # Backtest 2024 on match result only with a 5% EV threshold")
+	fmt.Println("  go run cmd/backtest/main.go -season 2024 -markets h2h -min-ev 0.05")
+	fmt.Println()
+	fmt.Println("Environment Variables:")
+	fmt.Println("  DATABASE_URL         PostgreSQL connection string")
+	fmt.Println("  ML_SERVICE_URL       ML service used for predictions")
+	fmt.Println()
+}
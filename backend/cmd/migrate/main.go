@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/dEnchanter/OddsIQ/backend/config"
+	"github.com/dEnchanter/OddsIQ/backend/pkg/database"
+)
+
+func main() {
+	// Command-line flags
+	status := flag.Bool("status", false, "List migrations and whether each is applied")
+	down := flag.Int("down", 0, "Roll back this many of the latest applied migrations")
+	baseline := flag.Int("baseline", 0, "Record migrations up to this version as applied without running them")
+	help := flag.Bool("help", false, "Show help")
+
+	flag.Parse()
+
+	if *help {
+		printHelp()
+		return
+	}
+
+	if *down < 0 {
+		log.Fatalf("Invalid down: %d, must be positive", *down)
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	db, err := database.New(cfg.DatabaseURL)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+
+	switch {
+	case *status:
+		statuses, err := database.MigrationStatuses(ctx, db.Pool)
+		if err != nil {
+			log.Fatalf("Failed to get migration status: %v", err)
+		}
+		for _, s := range statuses {
+			applied := "pending"
+			if s.AppliedAt != nil {
+				applied = "applied " + s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%03d_%-40s %s\n", s.Version, s.Name, applied)
+		}
+
+	case *down > 0:
+		if err := database.RollbackMigrations(ctx, db.Pool, *down); err != nil {
+			log.Fatalf("Failed to roll back migrations: %v", err)
+		}
+
+	default:
+		if *baseline > 0 {
+			if err := database.Baseline(ctx, db.Pool, *baseline); err != nil {
+				log.Fatalf("Failed to record migration baseline: %v", err)
+			}
+		}
+		if err := database.ApplyMigrations(ctx, db.Pool); err != nil {
+			log.Fatalf("Failed to apply migrations: %v", err)
+		}
+		log.Println("✓ Database schema is up to date")
+	}
+}
+
+func printHelp() {
+	fmt.Println("OddsIQ Database Migration Tool")
+	fmt.Println()
+	fmt.Println("Applies the pending migrations in pkg/database/migrations by default.")
+	fmt.Println()
+	fmt.Println("Usage:")
+	fmt.Println("  go run cmd/migrate/main.go [flags]")
+	fmt.Println()
+	fmt.Println("Flags:")
+	fmt.Println("  -status")
+	fmt.Println("        List migrations and whether each is applied")
+	fmt.Println("  -down int")
+	fmt.Println("        Roll back this many of the latest applied migrations using their down files")
+	fmt.Println("  -baseline int")
+	fmt.Println("        Before applying, record migrations up to this version as already applied;")
+	fmt.Println("        for databases set up by running the SQL files by hand")
+	fmt.Println("  -help")
+	fmt.Println("        Show this help message")
+}
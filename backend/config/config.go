@@ -1,46 +1,411 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"net/mail"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	DatabaseURL      string
-	APIFootballKey   string
-	OddsAPIKey       string
-	MLServiceURL     string
-	Port             string
-	Env              string
-	InitialBankroll  float64
-	KellyFraction    float64
-	MinEVThreshold   float64
-	MaxBetPercentage float64
+	DatabaseURL        string
+	AutoMigrate        bool          // Apply pending schema migrations when the API starts
+	MigrationsBaseline int           // Migrations already applied by hand to an untracked database (0 = none)
+	APIFootballKey     string
+	OddsAPIKey         string
+	MLServiceURL       string
+	Port               string
+	Env                string
+	InitialBankroll    float64
+	KellyFraction      float64
+	MinEVThreshold     float64
+	MaxBetPercentage   float64
+	MaxTotalExposure   float64 // Cap on the combined stake of simultaneous picks, as a fraction of bankroll
+	MinStake           float64
+	MinStakePolicy     string
+	StakeRounding      float64 // Stakes are rounded to the nearest multiple of this (0.01, 0.5 or 1)
+	StakeCurrency      string  // Currency or unit stakes are shown in
+	MinOdds            float64 // Value bets priced below this are excluded (0 = no minimum)
+	MaxOdds            float64 // Value bets priced above this are excluded (0 = no maximum)
+	ValueAlertMinEdge  float64 // Default model minus market probability that raises a value alert
+	LeagueIDs          []int         // API-Football league IDs to sync
+	MetricsCacheTTL    time.Duration // How long model metrics are cached
+	OddsCacheTTL       time.Duration // How long a fixture's latest odds are cached (0 disables)
+	EnableModelReload  bool          // Expose POST /api/model/reload
+	AdminAPIKey        string        // Key required by /api/admin endpoints (disabled if empty)
+	APIKeys            []string      // Keys accepted by write endpoints in X-API-Key (unprotected if empty)
+	TeamMatchThreshold float64       // Minimum similarity to auto-match bookmaker team names
+	SharpBookmakers    []string      // Bookmaker keys classed as sharp (lowercase); all others are soft
+	OddsLookahead      time.Duration // How far ahead odds are synced (0 syncs every listed event)
+	OddsAPILowCredits  int           // Warn when fewer Odds API credits than this remain (0 disables)
+	EnableScheduler    bool          // Run the sync scheduler inside the API server
+	ShutdownTimeout    time.Duration // How long to wait for in-flight work on shutdown
+	NotifyWebhookURL   string        // Discord or Slack webhook the weekly digest is posted to (disabled if empty)
+	NotifyWebhookType  string        // discord or slack
+	NotifyTopPicks     int           // Value picks included in the weekly digest
+	SMTP               SMTPConfig    // Mail server the weekly digest is emailed through (disabled if no host)
+	RateLimit          RateLimit     // Per-client limit on every /api endpoint
+	ExpensiveRateLimit RateLimit     // Additional per-client limit on ML-backed and combinatorial endpoints
+	Scheduler          SchedulerConfig
+}
+
+// RateLimit is a token bucket limit per client: PerMinute requests refill the bucket
+// each minute and up to Burst can be made at once. A zero PerMinute disables the limit.
+type RateLimit struct {
+	PerMinute int
+	Burst     int
+}
+
+// SMTPConfig is the mail server and recipients for the weekly digest email.
+// Username and Password are only used when Username is set.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+// SchedulerConfig holds cron schedules (with seconds field) for the sync jobs
+type SchedulerConfig struct {
+	FixturesCron      string         // Sync upcoming fixtures
+	ResultsCron       string         // Update fixture results (match days only)
+	OddsAllCron       string         // Sync odds for all markets
+	OddsH2HCron       string         // Sync H2H odds
+	ClosingLinesCron  string         // Mark closing lines
+	CleanupCron       string         // Delete old odds
+	LiveOddsCron      string         // Sync in-play odds (no-op while nothing is live)
+	WeeklyDigestCron  string         // Send the weekly picks digest (when notifications are configured)
+	BookmakersCron    string         // Refresh the bookmakers reference list
+	DevFixturesCron   string         // Development schedule: sync fixtures
+	DevOddsCron       string         // Development schedule: sync odds
+	OddsRetentionDays int            // Days of odds kept by the cleanup job
+	MatchDays         []time.Weekday // Days on which fixture results are polled
 }
 
 func Load() (*Config, error) {
 	// Load .env file if it exists
 	_ = godotenv.Load()
 
-	initialBankroll, _ := strconv.ParseFloat(getEnv("INITIAL_BANKROLL", "10000.00"), 64)
-	kellyFraction, _ := strconv.ParseFloat(getEnv("KELLY_FRACTION", "0.25"), 64)
-	minEVThreshold, _ := strconv.ParseFloat(getEnv("MIN_EV_THRESHOLD", "0.03"), 64)
-	maxBetPercentage, _ := strconv.ParseFloat(getEnv("MAX_BET_PERCENTAGE", "0.05"), 64)
-
-	return &Config{
-		DatabaseURL:      getEnv("DATABASE_URL", "postgres://localhost:5432/oddsiq?sslmode=disable"),
-		APIFootballKey:   getEnv("API_FOOTBALL_KEY", ""),
-		OddsAPIKey:       getEnv("ODDS_API_KEY", ""),
-		MLServiceURL:     getEnv("ML_SERVICE_URL", "http://localhost:8001"),
-		Port:             getEnv("PORT", "8000"),
-		Env:              getEnv("ENV", "development"),
-		InitialBankroll:  initialBankroll,
-		KellyFraction:    kellyFraction,
-		MinEVThreshold:   minEVThreshold,
-		MaxBetPercentage: maxBetPercentage,
-	}, nil
+	var errs []error
+	initialBankroll := getEnvFloat("INITIAL_BANKROLL", "10000.00", &errs)
+	kellyFraction := getEnvFloat("KELLY_FRACTION", "0.25", &errs)
+	minEVThreshold := getEnvFloat("MIN_EV_THRESHOLD", "0.03", &errs)
+	maxBetPercentage := getEnvFloat("MAX_BET_PERCENTAGE", "0.05", &errs)
+	maxTotalExposure := getEnvFloat("MAX_TOTAL_EXPOSURE", "0.25", &errs)
+	minStake := getEnvFloat("MIN_STAKE", "0", &errs)
+	stakeRounding := getEnvFloat("STAKE_ROUNDING", "0.01", &errs)
+	minOdds := getEnvFloat("MIN_ODDS", "0", &errs)
+	maxOdds := getEnvFloat("MAX_ODDS", "0", &errs)
+	valueAlertMinEdge := getEnvFloat("VALUE_ALERT_MIN_EDGE", "0.05", &errs)
+	metricsCacheTTL := getEnvInt("METRICS_CACHE_TTL_SECONDS", "60", &errs)
+	oddsCacheTTL := getEnvInt("ODDS_CACHE_TTL_SECONDS", "10", &errs)
+	enableModelReload := getEnvBool("ENABLE_MODEL_RELOAD", "false", &errs)
+	oddsRetentionDays := getEnvInt("ODDS_RETENTION_DAYS", "30", &errs)
+	teamMatchThreshold := getEnvFloat("TEAM_MATCH_THRESHOLD", "0.85", &errs)
+	oddsLookaheadDays := getEnvInt("ODDS_LOOKAHEAD_DAYS", "7", &errs)
+	oddsAPILowCredits := getEnvInt("ODDS_API_LOW_CREDITS", "50", &errs)
+	enableScheduler := getEnvBool("ENABLE_SCHEDULER", "false", &errs)
+	autoMigrate := getEnvBool("AUTO_MIGRATE", "true", &errs)
+	migrationsBaseline := getEnvInt("MIGRATIONS_BASELINE", "0", &errs)
+	shutdownTimeout := getEnvInt("SHUTDOWN_TIMEOUT_SECONDS", "15", &errs)
+	notifyTopPicks := getEnvInt("NOTIFY_TOP_PICKS", "5", &errs)
+	smtpPort := getEnvInt("SMTP_PORT", "587", &errs)
+	rateLimitPerMinute := getEnvInt("RATE_LIMIT_PER_MINUTE", "120", &errs)
+	rateLimitBurst := getEnvInt("RATE_LIMIT_BURST", "30", &errs)
+	expensiveRateLimitPerMinute := getEnvInt("EXPENSIVE_RATE_LIMIT_PER_MINUTE", "20", &errs)
+	expensiveRateLimitBurst := getEnvInt("EXPENSIVE_RATE_LIMIT_BURST", "5", &errs)
+	leagueIDs := parseIntList("LEAGUES", getEnv("LEAGUES", "39"), &errs)
+	matchDays, err := parseWeekdays(getEnv("MATCH_DAYS", "fri,sat,sun,mon"))
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid MATCH_DAYS: %w", err))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{
+		DatabaseURL:        getEnv("DATABASE_URL", "postgres://localhost:5432/oddsiq?sslmode=disable"),
+		AutoMigrate:        autoMigrate,
+		MigrationsBaseline: migrationsBaseline,
+		APIFootballKey:     getEnv("API_FOOTBALL_KEY", ""),
+		OddsAPIKey:         getEnv("ODDS_API_KEY", ""),
+		MLServiceURL:       getEnv("ML_SERVICE_URL", "http://localhost:8001"),
+		Port:               getEnv("PORT", "8000"),
+		Env:                getEnv("ENV", "development"),
+		InitialBankroll:    initialBankroll,
+		KellyFraction:      kellyFraction,
+		MinEVThreshold:     minEVThreshold,
+		MaxBetPercentage:   maxBetPercentage,
+		MaxTotalExposure:   maxTotalExposure,
+		MinStake:           minStake,
+		MinStakePolicy:     getEnv("MIN_STAKE_POLICY", "round_up"),
+		StakeRounding:      stakeRounding,
+		StakeCurrency:      getEnv("STAKE_CURRENCY", "units"),
+		MinOdds:            minOdds,
+		MaxOdds:            maxOdds,
+		ValueAlertMinEdge:  valueAlertMinEdge,
+		LeagueIDs:          leagueIDs,
+		MetricsCacheTTL:    time.Duration(metricsCacheTTL) * time.Second,
+		OddsCacheTTL:       time.Duration(oddsCacheTTL) * time.Second,
+		EnableModelReload:  enableModelReload,
+		AdminAPIKey:        getEnv("ADMIN_API_KEY", ""),
+		APIKeys:            parseKeyList(getEnv("API_KEYS", "")),
+		TeamMatchThreshold: teamMatchThreshold,
+		SharpBookmakers:    parseStringList(getEnv("SHARP_BOOKMAKERS", "pinnacle,betfair_ex_uk,betfair_ex_eu,matchbook")),
+		OddsLookahead:      time.Duration(oddsLookaheadDays) * 24 * time.Hour,
+		OddsAPILowCredits:  oddsAPILowCredits,
+		EnableScheduler:    enableScheduler,
+		ShutdownTimeout:    time.Duration(shutdownTimeout) * time.Second,
+		NotifyWebhookURL:   getEnv("NOTIFY_WEBHOOK_URL", ""),
+		NotifyWebhookType:  strings.ToLower(getEnv("NOTIFY_WEBHOOK_TYPE", "discord")),
+		NotifyTopPicks:     notifyTopPicks,
+		SMTP: SMTPConfig{
+			Host:     getEnv("SMTP_HOST", ""),
+			Port:     smtpPort,
+			Username: getEnv("SMTP_USERNAME", ""),
+			Password: getEnv("SMTP_PASSWORD", ""),
+			From:     getEnv("NOTIFY_EMAIL_FROM", ""),
+			To:       parseKeyList(getEnv("NOTIFY_EMAIL_TO", "")),
+		},
+		RateLimit:          RateLimit{PerMinute: rateLimitPerMinute, Burst: rateLimitBurst},
+		ExpensiveRateLimit: RateLimit{PerMinute: expensiveRateLimitPerMinute, Burst: expensiveRateLimitBurst},
+		Scheduler: SchedulerConfig{
+			FixturesCron:      getEnv("CRON_FIXTURES", "0 0 6 * * *"),
+			ResultsCron:       getEnv("CRON_RESULTS", "0 */30 * * * *"),
+			OddsAllCron:       getEnv("CRON_ODDS_ALL", "0 0 */2 * * *"),
+			OddsH2HCron:       getEnv("CRON_ODDS_H2H", "0 0 * * * *"),
+			ClosingLinesCron:  getEnv("CRON_CLOSING_LINES", "0 5,35 * * * *"),
+			CleanupCron:       getEnv("CRON_CLEANUP", "0 0 3 * * 0"),
+			LiveOddsCron:      getEnv("CRON_LIVE_ODDS", "0 * * * * *"),
+			WeeklyDigestCron:  getEnv("CRON_WEEKLY_DIGEST", "0 0 9 * * 5"),
+			BookmakersCron:    getEnv("CRON_BOOKMAKERS", "0 0 4 * * 1"),
+			DevFixturesCron:   getEnv("CRON_DEV_FIXTURES", "0 0 12 * * *"),
+			DevOddsCron:       getEnv("CRON_DEV_ODDS", "0 0 10,18 * * *"),
+			OddsRetentionDays: oddsRetentionDays,
+			MatchDays:         matchDays,
+		},
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Validate checks that required values are set and numeric values are in range.
+// API keys are checked separately with RequireAPIFootballKey and RequireOddsAPIKey
+// since only some entry points need them.
+func (c *Config) Validate() error {
+	var errs []error
+
+	if c.DatabaseURL == "" {
+		errs = append(errs, errors.New("DATABASE_URL is required"))
+	}
+	if c.InitialBankroll <= 0 {
+		errs = append(errs, fmt.Errorf("INITIAL_BANKROLL must be positive, got %v", c.InitialBankroll))
+	}
+	if c.KellyFraction <= 0 || c.KellyFraction > 1 {
+		errs = append(errs, fmt.Errorf("KELLY_FRACTION must be in (0, 1], got %v", c.KellyFraction))
+	}
+	if c.MinEVThreshold < 0 {
+		errs = append(errs, fmt.Errorf("MIN_EV_THRESHOLD must be >= 0, got %v", c.MinEVThreshold))
+	}
+	if c.MaxBetPercentage <= 0 || c.MaxBetPercentage > 1 {
+		errs = append(errs, fmt.Errorf("MAX_BET_PERCENTAGE must be in (0, 1], got %v", c.MaxBetPercentage))
+	}
+	if c.MaxTotalExposure <= 0 || c.MaxTotalExposure > 1 {
+		errs = append(errs, fmt.Errorf("MAX_TOTAL_EXPOSURE must be in (0, 1], got %v", c.MaxTotalExposure))
+	}
+	if c.MinStake < 0 {
+		errs = append(errs, fmt.Errorf("MIN_STAKE must be >= 0, got %v", c.MinStake))
+	}
+	if c.MinStakePolicy != "round_up" && c.MinStakePolicy != "drop" {
+		errs = append(errs, fmt.Errorf("MIN_STAKE_POLICY must be round_up or drop, got %q", c.MinStakePolicy))
+	}
+	if c.StakeRounding != 0.01 && c.StakeRounding != 0.5 && c.StakeRounding != 1 {
+		errs = append(errs, fmt.Errorf("STAKE_ROUNDING must be 0.01, 0.5 or 1, got %v", c.StakeRounding))
+	}
+	if strings.TrimSpace(c.StakeCurrency) == "" {
+		errs = append(errs, errors.New("STAKE_CURRENCY must not be empty"))
+	}
+	if c.MinOdds < 0 {
+		errs = append(errs, fmt.Errorf("MIN_ODDS must be >= 0, got %v", c.MinOdds))
+	}
+	if c.MaxOdds < 0 {
+		errs = append(errs, fmt.Errorf("MAX_ODDS must be >= 0, got %v", c.MaxOdds))
+	}
+	if c.MaxOdds > 0 && c.MaxOdds < c.MinOdds {
+		errs = append(errs, fmt.Errorf("MAX_ODDS must be >= MIN_ODDS, got %v < %v", c.MaxOdds, c.MinOdds))
+	}
+	if c.ValueAlertMinEdge <= 0 || c.ValueAlertMinEdge >= 1 {
+		errs = append(errs, fmt.Errorf("VALUE_ALERT_MIN_EDGE must be in (0, 1), got %v", c.ValueAlertMinEdge))
+	}
+	if len(c.LeagueIDs) == 0 {
+		errs = append(errs, errors.New("LEAGUES must contain at least one league ID"))
+	}
+	if c.TeamMatchThreshold <= 0 || c.TeamMatchThreshold > 1 {
+		errs = append(errs, fmt.Errorf("TEAM_MATCH_THRESHOLD must be in (0, 1], got %v", c.TeamMatchThreshold))
+	}
+	if c.OddsLookahead < 0 {
+		errs = append(errs, fmt.Errorf("ODDS_LOOKAHEAD_DAYS must be >= 0, got %v", c.OddsLookahead))
+	}
+	if c.OddsAPILowCredits < 0 {
+		errs = append(errs, fmt.Errorf("ODDS_API_LOW_CREDITS must be >= 0, got %v", c.OddsAPILowCredits))
+	}
+	if c.MigrationsBaseline < 0 {
+		errs = append(errs, fmt.Errorf("MIGRATIONS_BASELINE must be >= 0, got %d", c.MigrationsBaseline))
+	}
+	if c.ShutdownTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("SHUTDOWN_TIMEOUT_SECONDS must be positive, got %v", c.ShutdownTimeout))
+	}
+	if c.NotifyWebhookURL != "" && c.NotifyWebhookType != "discord" && c.NotifyWebhookType != "slack" {
+		errs = append(errs, fmt.Errorf("NOTIFY_WEBHOOK_TYPE must be discord or slack, got %q", c.NotifyWebhookType))
+	}
+	if c.SMTP.Host != "" {
+		if c.SMTP.Port < 1 || c.SMTP.Port > 65535 {
+			errs = append(errs, fmt.Errorf("SMTP_PORT must be between 1 and 65535, got %d", c.SMTP.Port))
+		}
+		if c.SMTP.From == "" {
+			errs = append(errs, fmt.Errorf("NOTIFY_EMAIL_FROM is required when SMTP_HOST is set"))
+		} else if _, err := mail.ParseAddress(c.SMTP.From); err != nil {
+			errs = append(errs, fmt.Errorf("NOTIFY_EMAIL_FROM is not a valid address: %w", err))
+		}
+		if len(c.SMTP.To) == 0 {
+			errs = append(errs, fmt.Errorf("NOTIFY_EMAIL_TO is required when SMTP_HOST is set"))
+		}
+		for _, to := range c.SMTP.To {
+			if _, err := mail.ParseAddress(to); err != nil {
+				errs = append(errs, fmt.Errorf("NOTIFY_EMAIL_TO has an invalid address %q: %w", to, err))
+			}
+		}
+	}
+	if c.NotifyTopPicks < 1 {
+		errs = append(errs, fmt.Errorf("NOTIFY_TOP_PICKS must be at least 1, got %d", c.NotifyTopPicks))
+	}
+	if err := c.RateLimit.validate("RATE_LIMIT"); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.ExpensiveRateLimit.validate("EXPENSIVE_RATE_LIMIT"); err != nil {
+		errs = append(errs, err)
+	}
+	if c.MetricsCacheTTL < 0 {
+		errs = append(errs, fmt.Errorf("METRICS_CACHE_TTL_SECONDS must be >= 0, got %v", c.MetricsCacheTTL))
+	}
+	if c.OddsCacheTTL < 0 {
+		errs = append(errs, fmt.Errorf("ODDS_CACHE_TTL_SECONDS must be >= 0, got %v", c.OddsCacheTTL))
+	}
+
+	return errors.Join(errs...)
+}
+
+// validate checks a rate limit read from the env vars starting with prefix
+func (l RateLimit) validate(prefix string) error {
+	if l.PerMinute < 0 {
+		return fmt.Errorf("%s_PER_MINUTE must be >= 0, got %d", prefix, l.PerMinute)
+	}
+	if l.PerMinute > 0 && l.Burst < 1 {
+		return fmt.Errorf("%s_BURST must be at least 1, got %d", prefix, l.Burst)
+	}
+	return nil
+}
+
+// RequireAPIFootballKey returns an error if API_FOOTBALL_KEY is not set
+func (c *Config) RequireAPIFootballKey() error {
+	if c.APIFootballKey == "" {
+		return errors.New("API_FOOTBALL_KEY is required")
+	}
+	return nil
+}
+
+// RequireOddsAPIKey returns an error if ODDS_API_KEY is not set
+func (c *Config) RequireOddsAPIKey() error {
+	if c.OddsAPIKey == "" {
+		return errors.New("ODDS_API_KEY is required")
+	}
+	return nil
+}
+
+// parseIntList parses a comma-separated list of integers, skipping empty entries
+// and recording an error in errs for each invalid one
+func parseIntList(key, value string, errs *[]error) []int {
+	var result []int
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			*errs = append(*errs, fmt.Errorf("invalid %s entry %q: %w", key, part, err))
+			continue
+		}
+		result = append(result, n)
+	}
+	return result
+}
+
+// parseStringList parses a comma-separated list into trimmed, lowercase values, skipping empty entries
+func parseStringList(value string) []string {
+	var result []string
+	for _, part := range strings.Split(value, ",") {
+		if item := strings.ToLower(strings.TrimSpace(part)); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// parseKeyList parses a comma-separated list of keys or addresses, keeping their case
+func parseKeyList(value string) []string {
+	var result []string
+	for _, part := range strings.Split(value, ",") {
+		if item := strings.TrimSpace(part); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// weekdayNames maps three-letter day abbreviations to weekdays
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// parseWeekdays parses a comma-separated list of day names or abbreviations
+// ("mon", "Monday"), skipping empty entries and rejecting any it doesn't recognise
+func parseWeekdays(value string) ([]time.Weekday, error) {
+	var result []time.Weekday
+	var invalid []string
+	for _, part := range strings.Split(value, ",") {
+		name := strings.ToLower(strings.TrimSpace(part))
+		if name == "" {
+			continue
+		}
+		day, ok := weekdayNames[name[:min(len(name), 3)]]
+		if !ok || !strings.HasPrefix(strings.ToLower(day.String()), name) {
+			invalid = append(invalid, strings.TrimSpace(part))
+			continue
+		}
+		result = append(result, day)
+	}
+	if len(invalid) > 0 {
+		return nil, fmt.Errorf("unknown weekday(s) %q", invalid)
+	}
+	return result, nil
 }
 
 func getEnv(key, defaultValue string) string {
@@ -49,3 +414,30 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getEnvFloat parses a float environment variable, appending any parse error to errs
+func getEnvFloat(key, defaultValue string, errs *[]error) float64 {
+	value, err := strconv.ParseFloat(getEnv(key, defaultValue), 64)
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("invalid %s: %w", key, err))
+	}
+	return value
+}
+
+// getEnvInt parses an integer environment variable, appending any parse error to errs
+func getEnvInt(key, defaultValue string, errs *[]error) int {
+	value, err := strconv.Atoi(getEnv(key, defaultValue))
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("invalid %s: %w", key, err))
+	}
+	return value
+}
+
+// getEnvBool parses a boolean environment variable, appending any parse error to errs
+func getEnvBool(key, defaultValue string, errs *[]error) bool {
+	value, err := strconv.ParseBool(getEnv(key, defaultValue))
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("invalid %s: %w", key, err))
+	}
+	return value
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseWeekdays_AllSevenDays_ReturnsEachDay(t *testing.T) {
+	expected := []time.Weekday{
+		time.Sunday, time.Monday, time.Tuesday, time.Wednesday,
+		time.Thursday, time.Friday, time.Saturday,
+	}
+
+	days, err := parseWeekdays("sun,mon,tue,wed,thu,fri,sat")
+	if err != nil {
+		t.Fatalf("parseWeekdays returned error: %v", err)
+	}
+	if !reflect.DeepEqual(days, expected) {
+		t.Errorf("Expected %v, got %v", expected, days)
+	}
+}
+
+func TestParseWeekdays_FullNamesAndMixedCase_ReturnsDays(t *testing.T) {
+	days, err := parseWeekdays(" Friday, SAT ,sunday,")
+	if err != nil {
+		t.Fatalf("parseWeekdays returned error: %v", err)
+	}
+	expected := []time.Weekday{time.Friday, time.Saturday, time.Sunday}
+	if !reflect.DeepEqual(days, expected) {
+		t.Errorf("Expected %v, got %v", expected, days)
+	}
+}
+
+func TestParseWeekdays_InvalidEntry_ReturnsError(t *testing.T) {
+	for _, value := range []string{"fri,funday", "mo", "satx", "mon,7"} {
+		if days, err := parseWeekdays(value); err == nil {
+			t.Errorf("%q: expected error, got %v", value, days)
+		}
+	}
+}
+
+func TestLoad_InvalidMatchDays_ReturnsError(t *testing.T) {
+	t.Setenv("MATCH_DAYS", "fri,sat,sundy")
+
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for invalid MATCH_DAYS, got nil")
+	}
+}
+
+func TestParseIntList_ValidEntries_ReturnsIntegers(t *testing.T) {
+	var errs []error
+
+	ids := parseIntList("LEAGUES", "39, 140,135,", &errs)
+
+	if len(errs) != 0 {
+		t.Errorf("Expected no errors, got %v", errs)
+	}
+	if expected := []int{39, 140, 135}; !reflect.DeepEqual(ids, expected) {
+		t.Errorf("Expected %v, got %v", expected, ids)
+	}
+}
+
+func TestParseIntList_InvalidEntries_AppendsErrors(t *testing.T) {
+	var errs []error
+
+	ids := parseIntList("LEAGUES", "39,epl,140,6x", &errs)
+
+	if len(errs) != 2 {
+		t.Errorf("Expected 2 errors, got %d: %v", len(errs), errs)
+	}
+	if expected := []int{39, 140}; !reflect.DeepEqual(ids, expected) {
+		t.Errorf("Expected %v, got %v", expected, ids)
+	}
+}
+
+func TestLoad_InvalidLeagues_ReturnsError(t *testing.T) {
+	t.Setenv("LEAGUES", "39,premier")
+
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for invalid LEAGUES, got nil")
+	}
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// csvStream writes a CSV attachment straight to the response. The response is only
+// started on the first write, so handlers can still return a JSON error if nothing
+// has been written yet.
+type csvStream struct {
+	c        *gin.Context
+	filename string
+	header   []string
+	w        *csv.Writer
+}
+
+// newCSVStream creates a CSV stream with the given download filename and column headers
+func newCSVStream(c *gin.Context, filename string, header []string) *csvStream {
+	return &csvStream{c: c, filename: filename, header: header}
+}
+
+// Started reports whether the response has been started
+func (s *csvStream) Started() bool {
+	return s.w != nil
+}
+
+// start writes the response headers and the CSV header row
+func (s *csvStream) start() error {
+	s.c.Header("Content-Type", "text/csv; charset=utf-8")
+	s.c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, s.filename))
+	s.c.Status(http.StatusOK)
+
+	s.w = csv.NewWriter(s.c.Writer)
+	return s.w.Write(s.header)
+}
+
+// Write writes one record, starting the response if needed
+func (s *csvStream) Write(record []string) error {
+	if s.w == nil {
+		if err := s.start(); err != nil {
+			return err
+		}
+	}
+	return s.w.Write(record)
+}
+
+// Close flushes buffered records, writing just the header row if there were none
+func (s *csvStream) Close() error {
+	if s.w == nil {
+		if err := s.start(); err != nil {
+			return err
+		}
+	}
+	s.w.Flush()
+	return s.w.Error()
+}
+
+// csvFloat formats a number for CSV output with the given decimal places
+func csvFloat(value float64, places int) string {
+	return strconv.FormatFloat(value, 'f', places, 64)
+}
+
+// csvOptionalFloat formats an optional number, leaving the cell empty when nil
+func csvOptionalFloat(value *float64, places int) string {
+	if value == nil {
+		return ""
+	}
+	return csvFloat(*value, places)
+}
+
+// csvTime formats a time as RFC 3339 in UTC, leaving the cell empty when zero
+func csvTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -1,16 +1,25 @@
 package api
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/dEnchanter/OddsIQ/backend/config"
+	"github.com/dEnchanter/OddsIQ/backend/internal/markets"
 	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+	"github.com/dEnchanter/OddsIQ/backend/internal/oddsstream"
 	"github.com/dEnchanter/OddsIQ/backend/internal/repository"
 	"github.com/dEnchanter/OddsIQ/backend/internal/services"
+	"github.com/dEnchanter/OddsIQ/backend/pkg/apifootball"
+	"github.com/dEnchanter/OddsIQ/backend/pkg/oddsapi"
 )
 
 // ManualFixtureRequest represents a request to create a fixture manually
@@ -21,15 +30,56 @@ type ManualFixtureRequest struct {
 	Season     int    `json:"season" binding:"required"`
 	Round      string `json:"round"`
 	VenueName  string `json:"venue_name"`
+	Status     string `json:"status"`     // NS (default), or FT/AET/PEN for a completed fixture
+	HomeScore  *int   `json:"home_score"` // Required for completed fixtures
+	AwayScore  *int   `json:"away_score"` // Required for completed fixtures
+}
+
+// manualFixtureGracePeriod is how far in the past a not-started manual fixture may kick off,
+// allowing for clock skew and fixtures entered just after kick-off
+const manualFixtureGracePeriod = 15 * time.Minute
+
+// validate checks the status, scores and match date of a manual fixture, returning the
+// status to store
+func (req *ManualFixtureRequest) validate(matchDate, now time.Time) (string, error) {
+	status := strings.ToUpper(req.Status)
+	if status == "" {
+		status = "NS"
+	}
+
+	switch {
+	case status == "NS":
+		if req.HomeScore != nil || req.AwayScore != nil {
+			return "", errors.New("scores can only be set for completed fixtures (status FT, AET or PEN)")
+		}
+		if matchDate.Before(now.Add(-manualFixtureGracePeriod)) {
+			return "", fmt.Errorf("match_date %s is in the past; not-started fixtures must kick off in the future", matchDate.Format(time.RFC3339))
+		}
+	case services.IsFinished(status):
+		if req.HomeScore == nil || req.AwayScore == nil {
+			return "", fmt.Errorf("%s fixtures require both home_score and away_score", status)
+		}
+		if *req.HomeScore < 0 || *req.AwayScore < 0 {
+			return "", errors.New("scores must not be negative")
+		}
+		if matchDate.After(now) {
+			return "", fmt.Errorf("match_date %s is in the future; completed fixtures must have kicked off", matchDate.Format(time.RFC3339))
+		}
+	default:
+		return "", fmt.Errorf("invalid status %q, use NS, FT, AET or PEN", req.Status)
+	}
+
+	return status, nil
 }
 
 // ManualOddsRequest represents a request to add odds manually
 type ManualOddsRequest struct {
-	FixtureID  int     `json:"fixture_id" binding:"required"`
-	Bookmaker  string  `json:"bookmaker" binding:"required"`
-	MarketType string  `json:"market_type" binding:"required"` // h2h, totals, btts
-	Outcome    string  `json:"outcome" binding:"required"`     // Home, Draw, Away, Over, Under, Yes, No
-	OddsValue  float64 `json:"odds_value" binding:"required"`
+	FixtureID  int      `json:"fixture_id" binding:"required"`
+	Bookmaker  string   `json:"bookmaker" binding:"required"`
+	MarketType string   `json:"market_type" binding:"required"` // A key from the markets registry (h2h, totals, btts, spreads, ...)
+	Outcome    string   `json:"outcome" binding:"required"`     // Home, Draw, Away, Over, Under, Yes, No
+	OddsValue  float64  `json:"odds_value" binding:"required"`
+	Line       *float64 `json:"line"` // Totals/handicap line, defaults to 2.5 for totals
 }
 
 // ManualOddsBatchRequest represents a request to add multiple odds at once
@@ -41,9 +91,54 @@ type ManualOddsBatchRequest struct {
 
 // OddsEntryInput represents a single odds entry
 type OddsEntryInput struct {
-	MarketType string  `json:"market_type" binding:"required"`
-	Outcome    string  `json:"outcome" binding:"required"`
-	OddsValue  float64 `json:"odds_value" binding:"required"`
+	MarketType string   `json:"market_type" binding:"required"`
+	Outcome    string   `json:"outcome" binding:"required"`
+	OddsValue  float64  `json:"odds_value" binding:"required"`
+	Line       *float64 `json:"line"` // Totals/handicap line, defaults to 2.5 for totals
+}
+
+// UpdateOddsRequest represents a correction to a single odds row's price
+type UpdateOddsRequest struct {
+	OddsValue float64 `json:"odds_value" binding:"required"`
+}
+
+// CreateBetRequest represents a request to record a placed bet
+type CreateBetRequest struct {
+	FixtureID     int     `json:"fixture_id" binding:"required"`
+	PredictionID  *int    `json:"prediction_id"`
+	BetType       string  `json:"bet_type" binding:"required"` // e.g. home_win, over_2_5, btts_yes
+	Stake         float64 `json:"stake" binding:"required"`
+	Odds          float64 `json:"odds" binding:"required"`
+	ExpectedValue float64 `json:"expected_value"`
+	Bookmaker     string  `json:"bookmaker"`
+	Notes         string  `json:"notes"`
+}
+
+// EvaluateAccumulatorRequest represents a user-specified accumulator to price
+type EvaluateAccumulatorRequest struct {
+	Legs []AccumulatorLegRequest `json:"legs" binding:"required"`
+}
+
+// AccumulatorLegRequest is one leg of an accumulator to price
+type AccumulatorLegRequest struct {
+	FixtureID int    `json:"fixture_id" binding:"required"`
+	Market    string `json:"market" binding:"required"`  // e.g. 1x2, over_under, h2h
+	Outcome   string `json:"outcome" binding:"required"` // e.g. home_win, over_2_5, yes
+}
+
+// AddWatchlistRequest represents an outcome to save to the watchlist
+type AddWatchlistRequest struct {
+	FixtureID int    `json:"fixture_id" binding:"required"`
+	Market    string `json:"market" binding:"required"`  // e.g. 1x2, over_under, h2h
+	Outcome   string `json:"outcome" binding:"required"` // e.g. home_win, over_2_5, yes
+}
+
+// maxEvaluatedAccumulatorLegs caps the legs accepted by the accumulator evaluation endpoint
+const maxEvaluatedAccumulatorLegs = 10
+
+// SettleBetRequest represents a request to settle a bet
+type SettleBetRequest struct {
+	Status string `json:"status" binding:"required"` // won, lost, void
 }
 
 // API holds all the dependencies for handlers
@@ -54,31 +149,107 @@ type API struct {
 	fixturesRepo        *repository.FixturesRepository
 	oddsRepo            *repository.OddsRepository
 	statsRepo           *repository.TeamStatsRepository
+	betsRepo            *repository.BetsRepository
+	syncStatusRepo      *repository.SyncStatusRepository
+	bookmakersRepo      *repository.BookmakersRepository
 	predictionService   *services.PredictionService
 	bettingService      *services.BettingService
 	accumulatorService  *services.AccumulatorService
+	watchlistService    *services.WatchlistService
+	valueAlertService   *services.ValueAlertService
+	notificationService *services.NotificationService
+	settlementService   *services.BetSettlementService
+	bankrollService     *services.BankrollService
+	fixtureSyncService  *services.FixtureSyncService
+	oddsSyncService     *services.OddsSyncService
+	liveOddsService     *services.LiveOddsSyncService
+	footballOddsService *services.APIFootballOddsSyncService
+	bookmakerSync       *services.BookmakerSyncService
+	syncJobs            *services.SyncJobManager
+	marketService       *services.MarketService
+	oddsHub             *oddsstream.Hub
 }
 
 // NewAPI creates a new API instance
 func NewAPI(db *pgxpool.Pool, cfg *config.Config) *API {
 	fixturesRepo := repository.NewFixturesRepository(db)
 	oddsRepo := repository.NewOddsRepository(db)
+	oddsRepo.EnableCache(cfg.OddsCacheTTL)
+	fixturesRepo.InvalidateOddsOf(oddsRepo)
+	oddsHub := oddsstream.NewHub()
+	oddsRepo.PublishTo(oddsHub)
+	footballClient := apifootball.NewClient(cfg.APIFootballKey, 10)
 	mlClient := services.NewMLClient(cfg.MLServiceURL)
 	bettingService := services.NewBettingService(cfg, mlClient, fixturesRepo, oddsRepo)
+	betsRepo := repository.NewBetsRepository(db)
+	teamsRepo := repository.NewTeamsRepository(db)
+	statsRepo := repository.NewTeamStatsRepository(db)
+	bankrollService := services.NewBankrollService(betsRepo, repository.NewBankrollRepository(db), cfg.InitialBankroll)
+	settlementService := services.NewBetSettlementService(betsRepo, oddsRepo, fixturesRepo, bankrollService)
+	syncStatusRepo := repository.NewSyncStatusRepository(db)
+	accumulatorService := services.NewAccumulatorService(bettingService, cfg, repository.NewSettingsRepository(db))
+	bookmakersRepo := repository.NewBookmakersRepository(db)
 
 	return &API{
 		db:                  db,
 		cfg:                 cfg,
-		teamsRepo:           repository.NewTeamsRepository(db),
+		teamsRepo:           teamsRepo,
 		fixturesRepo:        fixturesRepo,
 		oddsRepo:            oddsRepo,
-		statsRepo:           repository.NewTeamStatsRepository(db),
-		predictionService:   services.NewPredictionService(cfg, fixturesRepo, oddsRepo),
+		statsRepo:           statsRepo,
+		betsRepo:            betsRepo,
+		syncStatusRepo:      syncStatusRepo,
+		bookmakersRepo:      bookmakersRepo,
+		predictionService:   services.NewPredictionService(cfg, fixturesRepo, oddsRepo, repository.NewPredictionsRepository(db), statsRepo),
 		bettingService:      bettingService,
-		accumulatorService:  services.NewAccumulatorService(bettingService, cfg),
+		accumulatorService:  accumulatorService,
+		watchlistService:    services.NewWatchlistService(bettingService, repository.NewWatchlistRepository(db)),
+		valueAlertService:   services.NewValueAlertService(bettingService, repository.NewValueAlertsRepository(db)),
+		notificationService: services.NewNotificationService(cfg, bettingService, accumulatorService, teamsRepo),
+		settlementService:   settlementService,
+		bankrollService:     bankrollService,
+		fixtureSyncService: services.NewFixtureSyncService(
+			footballClient,
+			teamsRepo, fixturesRepo, repository.NewLeaguesRepository(db), settlementService, syncStatusRepo, cfg.LeagueIDs,
+		),
+		oddsSyncService: services.NewOddsSyncService(
+			oddsapi.NewClient(cfg.OddsAPIKey, cfg.OddsAPILowCredits), fixturesRepo, oddsRepo, teamsRepo,
+			repository.NewTeamAliasRepository(db), syncStatusRepo, cfg.TeamMatchThreshold, cfg.OddsLookahead, cfg.LeagueIDs,
+		),
+		liveOddsService: services.NewLiveOddsSyncService(footballClient, fixturesRepo, oddsRepo),
+		footballOddsService: services.NewAPIFootballOddsSyncService(
+			footballClient, fixturesRepo, oddsRepo, syncStatusRepo, cfg.OddsLookahead,
+		),
+		bookmakerSync: services.NewBookmakerSyncService(footballClient, bookmakersRepo, syncStatusRepo, cfg.SharpBookmakers),
+		syncJobs:      services.NewSyncJobManager(),
+		marketService: services.NewMarketService(oddsRepo),
+		oddsHub:       oddsHub,
 	}
 }
 
+// LoadSettings applies settings stored through the API, such as the accumulator configuration
+func (api *API) LoadSettings(ctx context.Context) error {
+	return api.accumulatorService.LoadConfig(ctx)
+}
+
+// NewScheduler creates a sync scheduler sharing the API's sync services
+func (api *API) NewScheduler() (*services.Scheduler, error) {
+	return services.NewScheduler(
+		api.cfg.Scheduler, api.fixtureSyncService, api.oddsSyncService, api.liveOddsService, api.notificationService,
+		api.bookmakerSync,
+	)
+}
+
+// CloseStreams ends open odds streams so server shutdown isn't held up by them
+func (api *API) CloseStreams() {
+	api.oddsHub.Close()
+}
+
+// Shutdown cancels background sync jobs and waits for them to finish
+func (api *API) Shutdown(ctx context.Context) error {
+	return api.syncJobs.Shutdown(ctx)
+}
+
 // healthCheck returns a health check handler
 func (api *API) healthCheck() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -101,56 +272,167 @@ func (api *API) healthCheck() gin.HandlerFunc {
 	}
 }
 
+// mlHealthTimeout bounds the ML service check in the detailed health report
+const mlHealthTimeout = 3 * time.Second
+
+// detailedHealthCheck returns a health handler reporting database pool saturation,
+// ML service reachability and data freshness. Degraded (ML down) still returns 200;
+// only a database failure returns 503.
+func (api *API) detailedHealthCheck() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		status := "healthy"
+		httpStatus := http.StatusOK
+
+		database := gin.H{"status": "healthy"}
+		if err := api.db.Ping(ctx); err != nil {
+			status, httpStatus = "unhealthy", http.StatusServiceUnavailable
+			database["status"] = "unhealthy"
+			database["error"] = err.Error()
+		}
+
+		stat := api.db.Stat()
+		utilization := 0.0
+		if stat.MaxConns() > 0 {
+			utilization = float64(stat.AcquiredConns()) / float64(stat.MaxConns())
+		}
+		database["pool"] = gin.H{
+			"total_conns":         stat.TotalConns(),
+			"idle_conns":          stat.IdleConns(),
+			"acquired_conns":      stat.AcquiredConns(),
+			"constructing_conns":  stat.ConstructingConns(),
+			"max_conns":           stat.MaxConns(),
+			"utilization":         math.Round(utilization*10000) / 10000,
+			"empty_acquire_count": stat.EmptyAcquireCount(), // Acquires that had to wait for a connection
+		}
+
+		mlCtx, cancel := context.WithTimeout(ctx, mlHealthTimeout)
+		defer cancel()
+
+		mlService := gin.H{"status": "healthy"}
+		if healthy, err := api.predictionService.CheckMLServiceHealth(mlCtx); err != nil || !healthy {
+			mlService["status"] = "unhealthy"
+			if err != nil {
+				mlService["error"] = err.Error()
+			}
+			if status == "healthy" {
+				status = "degraded"
+			}
+		}
+
+		syncs := gin.H{}
+		if lastFixtures, err := api.fixturesRepo.GetLastUpdatedAt(ctx); err == nil {
+			syncs["fixtures_updated_at"] = lastFixtures
+		}
+		if lastOdds, err := api.oddsRepo.GetLatestTimestamp(ctx); err == nil {
+			syncs["odds_updated_at"] = lastOdds
+		}
+
+		c.JSON(httpStatus, gin.H{
+			"status":     status,
+			"service":    "oddsiq-backend",
+			"version":    "0.1.0",
+			"database":   database,
+			"ml_service": mlService,
+			"sync":       syncs,
+			"checked_at": time.Now(),
+		})
+	}
+}
+
 // getFixtures returns fixtures list handler
 func (api *API) getFixtures() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		ctx := c.Request.Context()
 
-		// Get query parameters
-		seasonStr := c.Query("season")
-		status := c.Query("status")
+		limit, offset, err := parsePagination(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 
-		var fixtures []interface{}
+		filter := repository.FixtureFilter{
+			Status: c.Query("status"),
+			Sort:   repository.FixtureSortDate,
+			Limit:  limit,
+			Offset: offset,
+		}
 
-		if seasonStr != "" {
-			season, parseErr := strconv.Atoi(seasonStr)
-			if parseErr != nil {
-				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid season parameter"})
-				return
-			}
-			fixturesList, err := api.fixturesRepo.GetBySeason(ctx, season)
+		if seasonStr := c.Query("season"); seasonStr != "" {
+			season, err := strconv.Atoi(seasonStr)
 			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid season parameter"})
 				return
 			}
-			for _, f := range fixturesList {
-				fixtures = append(fixtures, f)
-			}
-		} else if status != "" {
-			fixturesList, err := api.fixturesRepo.GetByStatus(ctx, status)
+			filter.Season = season
+		}
+
+		// Optional league filter (internal league ID)
+		if leagueStr := c.Query("league_id"); leagueStr != "" {
+			leagueID, err := strconv.Atoi(leagueStr)
 			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid league_id parameter"})
 				return
 			}
-			for _, f := range fixturesList {
-				fixtures = append(fixtures, f)
-			}
-		} else {
-			// Get upcoming fixtures by default
-			limit := 20
-			fixturesList, err := api.fixturesRepo.GetUpcoming(ctx, limit)
-			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			filter.LeagueID = leagueID
+		}
+
+		if sort := c.Query("sort"); sort != "" {
+			if sort != repository.FixtureSortDate && sort != repository.FixtureSortStatus {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "sort must be date or status"})
 				return
 			}
-			for _, f := range fixturesList {
-				fixtures = append(fixtures, f)
+			filter.Sort = sort
+		}
+
+		switch order := c.Query("order"); order {
+		case "", "asc":
+		case "desc":
+			filter.Descending = true
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "order must be asc or desc"})
+			return
+		}
+
+		// Without a season or status, list upcoming fixtures (optionally within a window)
+		if filter.Season == 0 && filter.Status == "" {
+			filter.Upcoming = true
+			if c.Query("days") != "" || c.Query("from") != "" || c.Query("to") != "" {
+				window, err := parseFixtureWindow(c)
+				if err != nil {
+					c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+					return
+				}
+				filter.From, filter.To = window.From, window.To
 			}
 		}
 
+		loc, err := parseTimezone(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		fixtures, total, err := api.fixturesRepo.List(ctx, filter)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		if fixtures == nil {
+			fixtures = []models.Fixture{}
+		}
+		for i := range fixtures {
+			localizeFixture(&fixtures[i], loc)
+		}
+
 		c.JSON(http.StatusOK, gin.H{
 			"fixtures": fixtures,
-			"total":    len(fixtures),
+			"total":    total,
+			"limit":    limit,
+			"offset":   offset,
+			"has_more": offset+len(fixtures) < total,
 		})
 	}
 }
@@ -166,20 +448,23 @@ func (api *API) getFixture() gin.HandlerFunc {
 			return
 		}
 
-		fixture, err := api.fixturesRepo.GetByID(ctx, fixtureID)
+		loc, err := parseTimezone(c)
 		if err != nil {
-			c.JSON(http.StatusNotFound, gin.H{"error": "fixture not found"})
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
 
-		// Get teams
-		homeTeam, _ := api.teamsRepo.GetByID(ctx, fixture.HomeTeamID)
-		awayTeam, _ := api.teamsRepo.GetByID(ctx, fixture.AwayTeamID)
+		fixture, err := api.fixturesRepo.GetByIDWithTeams(ctx, fixtureID)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "fixture not found"})
+			return
+		}
+		localizeFixture(fixture, loc)
 
 		c.JSON(http.StatusOK, gin.H{
 			"fixture":   fixture,
-			"home_team": homeTeam,
-			"away_team": awayTeam,
+			"home_team": fixture.HomeTeam, // Kept at the top level for existing clients
+			"away_team": fixture.AwayTeam,
 		})
 	}
 }
@@ -195,8 +480,23 @@ func (api *API) getFixtureOdds() gin.HandlerFunc {
 			return
 		}
 
+		// Pre-match odds unless in-play odds are asked for
+		live := false
+		if liveStr := c.Query("live"); liveStr != "" {
+			live, err = strconv.ParseBool(liveStr)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "live must be true or false"})
+				return
+			}
+		}
+
 		// Get latest odds for the fixture
-		odds, err := api.oddsRepo.GetLatestByFixture(ctx, fixtureID)
+		var odds []models.Odds
+		if live {
+			odds, err = api.oddsRepo.GetLiveByFixture(ctx, fixtureID)
+		} else {
+			odds, err = api.oddsRepo.GetLatestByFixture(ctx, fixtureID)
+		}
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
@@ -214,47 +514,62 @@ func (api *API) getFixtureOdds() gin.HandlerFunc {
 	}
 }
 
-// getWeeklyPicks returns weekly picks handler
-func (api *API) getWeeklyPicks() gin.HandlerFunc {
+// oddsStreamHeartbeat is how often an idle odds stream sends a keep-alive comment
+const oddsStreamHeartbeat = 15 * time.Second
+
+// streamFixtureOdds streams newly stored odds for a fixture as server-sent "odds" events
+// until the client disconnects. Slow clients miss their oldest updates rather than
+// holding up ingestion.
+func (api *API) streamFixtureOdds() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		ctx := c.Request.Context()
 
-		// Get bankroll from query or use default
-		bankroll := api.cfg.InitialBankroll
-		if bankrollStr := c.Query("bankroll"); bankrollStr != "" {
-			if b, err := strconv.ParseFloat(bankrollStr, 64); err == nil {
-				bankroll = b
-			}
-		}
-
-		picks, err := api.predictionService.GetWeeklyPicks(ctx, bankroll)
+		fixtureID, err := strconv.Atoi(c.Param("id"))
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid fixture ID"})
 			return
 		}
 
-		// Calculate summary
-		var totalEV float64
-		var totalStake float64
-		for _, p := range picks {
-			totalEV += p.ExpectedValue
-			totalStake += p.SuggestedStake
+		if _, err := api.fixturesRepo.GetByID(ctx, fixtureID); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "fixture not found"})
+			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{
-			"picks": picks,
-			"summary": gin.H{
-				"total_picks":        len(picks),
-				"total_suggested_stake": totalStake,
-				"total_expected_value":  totalEV,
-				"bankroll":           bankroll,
-			},
-		})
+		updates, unsubscribe := api.oddsHub.Subscribe(fixtureID)
+		defer unsubscribe()
+
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+		c.Header("X-Accel-Buffering", "no") // Disable proxy buffering
+		c.Status(http.StatusOK)
+		c.Writer.Flush()
+
+		heartbeat := time.NewTicker(oddsStreamHeartbeat)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case update, ok := <-updates:
+				if !ok {
+					return // Server shutting down
+				}
+				c.SSEvent("odds", update)
+				c.Writer.Flush()
+			case <-heartbeat.C:
+				if _, err := c.Writer.WriteString(": ping\n\n"); err != nil {
+					return
+				}
+				c.Writer.Flush()
+			}
+		}
 	}
 }
 
-// getPrediction returns prediction for a single fixture
-func (api *API) getPrediction() gin.HandlerFunc {
+// getFixtureOddsHistory returns the price movement for a fixture, market and outcome
+func (api *API) getFixtureOddsHistory() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		ctx := c.Request.Context()
 
@@ -264,64 +579,68 @@ func (api *API) getPrediction() gin.HandlerFunc {
 			return
 		}
 
-		fixture, err := api.fixturesRepo.GetByID(ctx, fixtureID)
-		if err != nil {
-			c.JSON(http.StatusNotFound, gin.H{"error": "fixture not found"})
+		market := c.DefaultQuery("market", "h2h")
+		outcome := c.Query("outcome")
+		if outcome == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "outcome is required"})
 			return
 		}
+		bookmaker := c.Query("bookmaker")
 
-		prediction, err := api.predictionService.GetPrediction(ctx, fixture)
+		odds, err := api.oddsRepo.GetHistory(ctx, fixtureID, market, outcome, bookmaker)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
 
 		c.JSON(http.StatusOK, gin.H{
-			"fixture":    fixture,
-			"prediction": prediction,
+			"fixture_id": fixtureID,
+			"market":     market,
+			"outcome":    outcome,
+			"bookmaker":  bookmaker,
+			"series":     services.BuildOddsHistory(odds),
 		})
 	}
 }
 
-// getMultiMarketPicks returns weekly picks across all markets (Smart Market Selector)
-func (api *API) getMultiMarketPicks() gin.HandlerFunc {
+// getFixtureArbs returns arbitrage opportunities across bookmakers for a fixture
+func (api *API) getFixtureArbs() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		ctx := c.Request.Context()
 
-		// Get bankroll from query or use default
-		bankroll := api.cfg.InitialBankroll
-		if bankrollStr := c.Query("bankroll"); bankrollStr != "" {
-			if b, err := strconv.ParseFloat(bankrollStr, 64); err == nil {
-				bankroll = b
-			}
+		fixtureID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid fixture ID"})
+			return
 		}
 
-		// Get limit from query (default 15)
-		limit := 15
-		if limitStr := c.Query("limit"); limitStr != "" {
-			if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
-				limit = l
+		// Total stake to split across the legs
+		stake := 100.0
+		if stakeStr := c.Query("stake"); stakeStr != "" {
+			s, err := strconv.ParseFloat(stakeStr, 64)
+			if err != nil || s <= 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "stake must be a positive number"})
+				return
 			}
+			stake = s
 		}
 
-		picks, err := api.bettingService.GetTopPicks(ctx, bankroll, limit)
+		arbs, err := api.bettingService.GetArbitrage(ctx, fixtureID, stake)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
 
-		// Get summary
-		summary := api.bettingService.GetPicksSummary(picks, bankroll)
-
 		c.JSON(http.StatusOK, gin.H{
-			"picks":   picks,
-			"summary": summary,
+			"fixture_id": fixtureID,
+			"arbs":       arbs,
+			"count":      len(arbs),
 		})
 	}
 }
 
-// evaluateFixture evaluates all markets for a single fixture
-func (api *API) evaluateFixture() gin.HandlerFunc {
+// getFixtureImplied returns implied and vig-free probabilities for a fixture's market
+func (api *API) getFixtureImplied() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		ctx := c.Request.Context()
 
@@ -331,102 +650,891 @@ func (api *API) evaluateFixture() gin.HandlerFunc {
 			return
 		}
 
-		fixture, err := api.fixturesRepo.GetByID(ctx, fixtureID)
-		if err != nil {
-			c.JSON(http.StatusNotFound, gin.H{"error": "fixture not found"})
+		market := c.DefaultQuery("market", "h2h")
+		method := c.DefaultQuery("method", services.PriceMethodAverage)
+		if method != services.PriceMethodAverage && method != services.PriceMethodBest {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "method must be average or best"})
 			return
 		}
 
-		// Get bankroll from query or use default
-		bankroll := api.cfg.InitialBankroll
-		if bankrollStr := c.Query("bankroll"); bankrollStr != "" {
-			if b, err := strconv.ParseFloat(bankrollStr, 64); err == nil {
-				bankroll = b
-			}
-		}
-
-		evaluation, err := api.bettingService.EvaluateFixture(ctx, fixture, bankroll)
+		implied, err := api.marketService.GetImpliedProbabilities(ctx, fixtureID, market, method)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
 
-		// Get teams for response
-		homeTeam, _ := api.teamsRepo.GetByID(ctx, fixture.HomeTeamID)
-		awayTeam, _ := api.teamsRepo.GetByID(ctx, fixture.AwayTeamID)
-
 		c.JSON(http.StatusOK, gin.H{
-			"fixture":    fixture,
-			"home_team":  homeTeam,
-			"away_team":  awayTeam,
-			"evaluation": evaluation,
+			"fixture_id": fixtureID,
+			"market":     market,
+			"method":     method,
+			"lines":      implied,
 		})
 	}
 }
 
-// getAllMarketsMetrics returns metrics for all market models
-func (api *API) getAllMarketsMetrics() gin.HandlerFunc {
+// getFixtureBestOdds returns the best current price for an outcome across all odds
+// sources, with the source and bookmaker offering it
+func (api *API) getFixtureBestOdds() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		ctx := c.Request.Context()
 
-		metrics, err := api.predictionService.GetAllMarketsMetrics(ctx)
+		fixtureID, err := strconv.Atoi(c.Param("id"))
 		if err != nil {
-			c.JSON(http.StatusServiceUnavailable, gin.H{
-				"error":   "ML service unavailable",
-				"details": err.Error(),
-			})
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid fixture ID"})
 			return
 		}
 
-		c.JSON(http.StatusOK, metrics)
-	}
-}
+		market, ok := markets.Get(c.DefaultQuery("market", markets.KeyH2H))
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":         fmt.Sprintf("unknown market %q", c.Query("market")),
+				"valid_markets": markets.All(),
+			})
+			return
+		}
 
-// getWeeklyAccumulators returns weekly accumulator recommendations
-func (api *API) getWeeklyAccumulators() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		ctx := c.Request.Context()
+		outcome, ok := market.NormalizeOutcome(c.Query("outcome"))
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":          fmt.Sprintf("outcome must be one of %v", market.Outcomes),
+				"valid_outcomes": market.Outcomes,
+			})
+			return
+		}
 
-		// Get bankroll from query or use default
-		bankroll := api.cfg.InitialBankroll
-		if bankrollStr := c.Query("bankroll"); bankrollStr != "" {
-			if b, err := strconv.ParseFloat(bankrollStr, 64); err == nil {
-				bankroll = b
+		var linePtr *float64
+		if lineStr := c.Query("line"); lineStr != "" {
+			line, err := strconv.ParseFloat(lineStr, 64)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid line"})
+				return
 			}
+			linePtr = &line
+		}
+		line, err := market.ValidateLine(linePtr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
 		}
 
-		result, err := api.accumulatorService.GetWeeklyAccumulators(ctx, bankroll)
+		best, found, err := api.marketService.GetBestAcrossSources(ctx, fixtureID, market.Key, outcome, line)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
+		if !found {
+			c.JSON(http.StatusNotFound, gin.H{"error": "no odds for this outcome"})
+			return
+		}
 
-		c.JSON(http.StatusOK, result)
-	}
-}
-
-// getAccumulatorConfig returns current accumulator configuration
-func (api *API) getAccumulatorConfig() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		config := services.DefaultAccumulatorConfig()
 		c.JSON(http.StatusOK, gin.H{
-			"config": config,
-			"description": gin.H{
-				"min_legs":           "Minimum number of legs in accumulator",
-				"max_legs":           "Maximum number of legs in accumulator",
-				"min_ev_threshold":   "Minimum EV required for accumulator (5% = 0.05)",
-				"min_leg_probability": "Minimum probability per leg (40% = 0.40)",
-				"kelly_fraction":     "Kelly fraction for stake sizing (1/8 = 0.125)",
-				"max_stake_percent":  "Maximum % of bankroll on accumulators (20% = 0.20)",
-				"allow_same_team":    "Allow same team in different fixtures",
-				"allow_same_fixture": "Allow multiple markets from same fixture",
-			},
+			"fixture_id": fixtureID,
+			"best":       best,
 		})
 	}
 }
 
-// getModelMetrics returns ML model performance metrics
-func (api *API) getModelMetrics() gin.HandlerFunc {
+// getFixtureOddsGrid returns a fixture's latest odds for a market as a grid of
+// bookmakers against outcomes, with the best price per outcome
+func (api *API) getFixtureOddsGrid() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		fixtureID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid fixture ID"})
+			return
+		}
+
+		market, ok := markets.Get(c.DefaultQuery("market", markets.KeyH2H))
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":         fmt.Sprintf("unknown market %q", c.Query("market")),
+				"valid_markets": markets.All(),
+			})
+			return
+		}
+
+		// Every line is returned unless one is asked for
+		var linePtr *float64
+		if lineStr := c.Query("line"); lineStr != "" {
+			line, err := strconv.ParseFloat(lineStr, 64)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid line"})
+				return
+			}
+			if _, err := market.ValidateLine(&line); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			linePtr = &line
+		}
+
+		grids, err := api.marketService.GetOddsGrid(ctx, fixtureID, market, linePtr)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"fixture_id": fixtureID,
+			"market":     market.Key,
+			"grids":      grids,
+			"count":      len(grids),
+		})
+	}
+}
+
+// getWeeklyPicks returns the legacy 1X2 weekly picks, filtered by min_ev (default the
+// configured threshold) and min_confidence and capped by limit (default no cap)
+func (api *API) getWeeklyPicks() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		// Get bankroll from query or use default
+		bankroll := api.cfg.InitialBankroll
+		if bankrollStr := c.Query("bankroll"); bankrollStr != "" {
+			if b, err := strconv.ParseFloat(bankrollStr, 64); err == nil {
+				bankroll = b
+			}
+		}
+
+		filter := services.WeeklyPicksFilter{MinEV: api.cfg.MinEVThreshold}
+
+		if minEVStr := c.Query("min_ev"); minEVStr != "" {
+			minEV, err := strconv.ParseFloat(minEVStr, 64)
+			if err != nil || minEV < 0 || minEV > 1 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "min_ev must be a number between 0 and 1"})
+				return
+			}
+			filter.MinEV = minEV
+		}
+
+		if minConfStr := c.Query("min_confidence"); minConfStr != "" {
+			minConf, err := strconv.ParseFloat(minConfStr, 64)
+			if err != nil || minConf < 0 || minConf > 1 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "min_confidence must be a number between 0 and 1"})
+				return
+			}
+			filter.MinConfidence = minConf
+		}
+
+		if limitStr := c.Query("limit"); limitStr != "" {
+			limit, err := strconv.Atoi(limitStr)
+			if err != nil || limit < 1 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive integer"})
+				return
+			}
+			filter.Limit = limit
+		}
+
+		window, err := parseFixtureWindow(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		filter.Window = window
+
+		picks, err := api.predictionService.GetWeeklyPicks(ctx, bankroll, filter)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		for _, p := range picks {
+			localizeFixture(&p.Fixture, window.Location())
+		}
+
+		// Calculate summary
+		var totalEV float64
+		var totalStake float64
+		for _, p := range picks {
+			totalEV += p.ExpectedValue
+			totalStake += p.SuggestedStake
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"picks": picks,
+			"summary": gin.H{
+				"total_picks":        len(picks),
+				"total_suggested_stake": totalStake,
+				"total_expected_value":  totalEV,
+				"bankroll":           bankroll,
+				"currency":           api.cfg.StakeCurrency,
+			},
+			"window": window,
+		})
+	}
+}
+
+// getPrediction returns prediction for a single fixture
+func (api *API) getPrediction() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		fixtureID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid fixture ID"})
+			return
+		}
+
+		fixture, err := api.fixturesRepo.GetByID(ctx, fixtureID)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "fixture not found"})
+			return
+		}
+
+		prediction, err := api.predictionService.GetPrediction(ctx, fixture)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"fixture":    fixture,
+			"prediction": prediction,
+		})
+	}
+}
+
+// getMultiMarketPicks returns weekly picks across all markets (Smart Market Selector)
+func (api *API) getMultiMarketPicks() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		result, bankroll, window, ok := api.topPicks(c)
+		if !ok {
+			return
+		}
+
+		// Get summary
+		summary := api.bettingService.GetPicksSummary(result.Picks, bankroll)
+		summary.Skipped = result.Skipped
+		summary.MLAvailable = result.MLAvailable
+		summary.StakeScale = result.StakeScale
+
+		c.JSON(http.StatusOK, gin.H{
+			"picks":   result.Picks,
+			"summary": summary,
+			"window":  window,
+		})
+	}
+}
+
+// picksCSVHeader is the column layout of the picks CSV export
+var picksCSVHeader = []string{"fixture", "date", "market", "outcome", "model_prob", "best_odds", "bookmaker", "ev", "kelly_stake"}
+
+// getMultiMarketPicksCSV exports the multi-market picks as CSV, one row per value outcome
+func (api *API) getMultiMarketPicksCSV() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		result, _, _, ok := api.topPicks(c)
+		if !ok {
+			return
+		}
+
+		teamIDs := make([]int, 0, len(result.Picks)*2)
+		for _, pick := range result.Picks {
+			teamIDs = append(teamIDs, pick.Fixture.HomeTeamID, pick.Fixture.AwayTeamID)
+		}
+		teams, err := api.teamsRepo.GetByIDs(ctx, teamIDs)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		stream := newCSVStream(c, "picks.csv", picksCSVHeader)
+		for _, pick := range result.Picks {
+			fixture := fmt.Sprintf("%s vs %s", teams[pick.Fixture.HomeTeamID].Name, teams[pick.Fixture.AwayTeamID].Name)
+			for _, outcome := range pick.ValueOutcomes {
+				err := stream.Write([]string{
+					fixture,
+					pick.Fixture.MatchDate.Format(time.RFC3339), // Already in the requested timezone
+					string(outcome.Market),
+					outcome.Outcome,
+					csvFloat(outcome.Probability, 4),
+					csvFloat(outcome.BestOdds, 2),
+					outcome.Bookmaker,
+					csvFloat(outcome.EV, 4),
+					csvFloat(outcome.KellyStake, 2),
+				})
+				if err != nil {
+					c.Error(err)
+					return
+				}
+			}
+		}
+		if err := stream.Close(); err != nil {
+			c.Error(err)
+		}
+	}
+}
+
+// topPicks reads the bankroll, limit, window and odds range query parameters and fetches the top
+// multi-market picks, writing an error response and returning false on failure
+func (api *API) topPicks(c *gin.Context) (*services.PicksResult, float64, services.FixtureWindow, bool) {
+	// Get bankroll from query or use default
+	bankroll := api.cfg.InitialBankroll
+	if bankrollStr := c.Query("bankroll"); bankrollStr != "" {
+		if b, err := strconv.ParseFloat(bankrollStr, 64); err == nil {
+			bankroll = b
+		}
+	}
+
+	// Get limit from query (default 15)
+	limit := 15
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	window, err := parseFixtureWindow(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, 0, window, false
+	}
+
+	oddsRange, err := parseOddsRange(c, api.bettingService.OddsRange())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, 0, window, false
+	}
+
+	result, err := api.bettingService.GetTopPicks(c.Request.Context(), bankroll, limit, window, oddsRange)
+	if errors.Is(err, services.ErrAllFixturesFailed) {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error":        err.Error(),
+			"skipped":      result.Skipped,
+			"ml_available": result.MLAvailable,
+		})
+		return nil, 0, window, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, 0, window, false
+	}
+
+	for _, pick := range result.Picks {
+		localizeFixture(&pick.Fixture, window.Location())
+	}
+
+	return result, bankroll, window, true
+}
+
+// getValueBets returns value bets filtered by minimum EV, confidence, and markets
+func (api *API) getValueBets() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		bankroll := api.cfg.InitialBankroll
+		if bankrollStr := c.Query("bankroll"); bankrollStr != "" {
+			if b, err := strconv.ParseFloat(bankrollStr, 64); err == nil {
+				bankroll = b
+			}
+		}
+
+		filter := services.ValueBetFilter{MinEV: api.cfg.MinEVThreshold}
+
+		if minEVStr := c.Query("min_ev"); minEVStr != "" {
+			minEV, err := strconv.ParseFloat(minEVStr, 64)
+			if err != nil || minEV < 0 || minEV > 1 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "min_ev must be a number between 0 and 1"})
+				return
+			}
+			filter.MinEV = minEV
+		}
+
+		if minConfStr := c.Query("min_confidence"); minConfStr != "" {
+			minConf, err := strconv.ParseFloat(minConfStr, 64)
+			if err != nil || minConf < 0 || minConf > 1 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "min_confidence must be a number between 0 and 1"})
+				return
+			}
+			filter.MinConfidence = minConf
+		}
+
+		if marketsStr := c.Query("markets"); marketsStr != "" {
+			for _, name := range strings.Split(marketsStr, ",") {
+				market, ok := services.ParseMarketType(name)
+				if !ok {
+					c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unknown market: %s", name)})
+					return
+				}
+				filter.Markets = append(filter.Markets, market)
+			}
+		}
+
+		oddsRange, err := parseOddsRange(c, api.bettingService.OddsRange())
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		filter.Odds = oddsRange
+
+		window, err := parseFixtureWindow(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		filter.Window = window
+
+		valueBets, err := api.bettingService.GetValueBets(ctx, bankroll, filter)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		for i := range valueBets {
+			localizeFixture(&valueBets[i].Fixture, window.Location())
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"value_bets": valueBets,
+			"total":      len(valueBets),
+			"window":     window,
+		})
+	}
+}
+
+// evaluateFixture evaluates all markets for a single fixture
+func (api *API) evaluateFixture() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		fixtureID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid fixture ID"})
+			return
+		}
+
+		fixture, err := api.fixturesRepo.GetByID(ctx, fixtureID)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "fixture not found"})
+			return
+		}
+
+		// Get bankroll from query or use default
+		bankroll := api.cfg.InitialBankroll
+		if bankrollStr := c.Query("bankroll"); bankrollStr != "" {
+			if b, err := strconv.ParseFloat(bankrollStr, 64); err == nil {
+				bankroll = b
+			}
+		}
+
+		// Optionally price EV against sharp bookmakers only
+		sharpOnly := false
+		if sharpStr := c.Query("sharp_only"); sharpStr != "" {
+			sharpOnly, err = strconv.ParseBool(sharpStr)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "sharp_only must be true or false"})
+				return
+			}
+		}
+
+		probOverrides, err := parseProbOverrides(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		evaluation, err := api.bettingService.EvaluateFixture(ctx, fixture, bankroll, sharpOnly, probOverrides)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Get teams for response
+		homeTeam, _ := api.teamsRepo.GetByID(ctx, fixture.HomeTeamID)
+		awayTeam, _ := api.teamsRepo.GetByID(ctx, fixture.AwayTeamID)
+
+		c.JSON(http.StatusOK, gin.H{
+			"fixture":    fixture,
+			"home_team":  homeTeam,
+			"away_team":  awayTeam,
+			"evaluation": evaluation,
+		})
+	}
+}
+
+// parseProbOverrides reads custom model probabilities from the JSON body
+// ({"probabilities": {"1x2": {"home_win": 0.5, ...}}}) or from query params of the
+// form prob[1x2.home_win]=0.5. Returns nil when none are given.
+func parseProbOverrides(c *gin.Context) (map[string]map[string]float64, error) {
+	var req struct {
+		Probabilities map[string]map[string]float64 `json:"probabilities"`
+	}
+	if c.Request.ContentLength != 0 && c.Request.Body != nil {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			return nil, fmt.Errorf("invalid request body: %w", err)
+		}
+	}
+
+	overrides := req.Probabilities
+	for key, value := range c.QueryMap("prob") {
+		market, outcome, ok := strings.Cut(key, ".")
+		if !ok {
+			return nil, fmt.Errorf("prob[%s] must be keyed as market.outcome", key)
+		}
+		prob, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("prob[%s] must be a number", key)
+		}
+
+		if overrides == nil {
+			overrides = make(map[string]map[string]float64)
+		}
+		if overrides[market] == nil {
+			overrides[market] = make(map[string]float64)
+		}
+		overrides[market][outcome] = prob
+	}
+
+	if err := services.ValidateProbOverrides(overrides); err != nil {
+		return nil, err
+	}
+	return overrides, nil
+}
+
+// getAllMarketsMetrics returns metrics for all market models
+func (api *API) getAllMarketsMetrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		metrics, err := api.predictionService.GetAllMarketsMetrics(ctx)
+		if err != nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"error":   "ML service unavailable",
+				"details": err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, metrics)
+	}
+}
+
+// getWeeklyAccumulators returns weekly accumulator recommendations
+func (api *API) getWeeklyAccumulators() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		// Get bankroll from query or use default
+		bankroll := api.cfg.InitialBankroll
+		if bankrollStr := c.Query("bankroll"); bankrollStr != "" {
+			if b, err := strconv.ParseFloat(bankrollStr, 64); err == nil {
+				bankroll = b
+			}
+		}
+
+		window, err := parseFixtureWindow(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		accConfig, err := parseAccumulatorOverrides(c, api.accumulatorService.Config())
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		mode, ok := services.ParseAccumulatorMode(c.Query("mode"))
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "mode must be safe or value"})
+			return
+		}
+
+		result, err := api.accumulatorService.GetWeeklyAccumulators(ctx, bankroll, window, accConfig, mode)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// parseAccumulatorOverrides applies one-off overrides from the min_legs, max_legs, min_ev,
+// kelly_fraction and allow_same_fixture query parameters to a copy of the configuration
+func parseAccumulatorOverrides(c *gin.Context, cfg services.AccumulatorConfig) (services.AccumulatorConfig, error) {
+	for param, target := range map[string]*int{"min_legs": &cfg.MinLegs, "max_legs": &cfg.MaxLegs} {
+		if value := c.Query(param); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return cfg, fmt.Errorf("%s must be an integer", param)
+			}
+			*target = n
+		}
+	}
+
+	for param, target := range map[string]*float64{"min_ev": &cfg.MinEVThreshold, "kelly_fraction": &cfg.KellyFraction} {
+		if value := c.Query(param); value != "" {
+			f, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return cfg, fmt.Errorf("%s must be a number", param)
+			}
+			*target = f
+		}
+	}
+
+	if value := c.Query("allow_same_fixture"); value != "" {
+		allow, err := strconv.ParseBool(value)
+		if err != nil {
+			return cfg, fmt.Errorf("allow_same_fixture must be true or false")
+		}
+		cfg.AllowSameFixture = allow
+	}
+
+	return cfg, cfg.Validate()
+}
+
+// evaluateAccumulator prices a user-specified accumulator with current odds and model probabilities
+func (api *API) evaluateAccumulator() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		bankroll := api.cfg.InitialBankroll
+		if bankrollStr := c.Query("bankroll"); bankrollStr != "" {
+			if b, err := strconv.ParseFloat(bankrollStr, 64); err == nil {
+				bankroll = b
+			}
+		}
+
+		var req EvaluateAccumulatorRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if len(req.Legs) < 2 || len(req.Legs) > maxEvaluatedAccumulatorLegs {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("an accumulator needs between 2 and %d legs", maxEvaluatedAccumulatorLegs)})
+			return
+		}
+
+		selections := make([]services.AccumulatorSelection, len(req.Legs))
+		for i, leg := range req.Legs {
+			market, ok := services.ParseMarketType(leg.Market)
+			if !ok {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unknown market: %s", leg.Market)})
+				return
+			}
+			outcome := strings.ToLower(strings.TrimSpace(leg.Outcome))
+			if m, ok := markets.ForModel(string(market)); !ok || !m.IsOutcomeKey(outcome) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unknown outcome %q for market %s", leg.Outcome, market)})
+				return
+			}
+
+			selections[i] = services.AccumulatorSelection{
+				FixtureID: leg.FixtureID,
+				Market:    market,
+				Outcome:   outcome,
+			}
+		}
+
+		evaluation := api.accumulatorService.EvaluateAccumulator(c.Request.Context(), selections, bankroll)
+		c.JSON(http.StatusOK, gin.H{
+			"evaluation": evaluation,
+			"bankroll":   bankroll,
+			"currency":   api.cfg.StakeCurrency,
+		})
+	}
+}
+
+// getValueAlerts scans upcoming fixtures for outcomes where the model probability beats
+// the market no-vig probability by at least min_edge (default VALUE_ALERT_MIN_EDGE)
+func (api *API) getValueAlerts() gin.HandlerFunc {
+	return api.valueAlertsHandler(api.valueAlertService.Scan)
+}
+
+// recordValueAlerts runs the same scan as getValueAlerts and records the alerts raised
+func (api *API) recordValueAlerts() gin.HandlerFunc {
+	return api.valueAlertsHandler(api.valueAlertService.Record)
+}
+
+// valueAlertsHandler parses the value alert query parameters and responds with the
+// alerts raised by scan
+func (api *API) valueAlertsHandler(scan func(context.Context, float64, services.FixtureWindow) (*services.ValueAlertScan, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		minEdge := api.cfg.ValueAlertMinEdge
+		if minEdgeStr := c.Query("min_edge"); minEdgeStr != "" {
+			m, err := strconv.ParseFloat(minEdgeStr, 64)
+			if err != nil || m <= 0 || m >= 1 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "min_edge must be a number between 0 and 1"})
+				return
+			}
+			minEdge = m
+		}
+
+		window, err := parseFixtureWindow(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		result, err := scan(c.Request.Context(), minEdge, window)
+		if errors.Is(err, services.ErrAllFixturesFailed) {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		for i := range result.Alerts {
+			localizeFixture(&result.Alerts[i].Fixture, window.Location())
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"alerts":    result.Alerts,
+			"total":     len(result.Alerts),
+			"min_edge":  result.MinEdge,
+			"evaluated": result.Evaluated,
+			"skipped":   result.Skipped,
+			"window":    window,
+		})
+	}
+}
+
+// addToWatchlist saves a fixture outcome to the watchlist
+func (api *API) addToWatchlist() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req AddWatchlistRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		market, ok := services.ParseMarketType(req.Market)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unknown market: %s", req.Market)})
+			return
+		}
+		outcome := strings.ToLower(strings.TrimSpace(req.Outcome))
+		if m, ok := markets.ForModel(string(market)); !ok || !m.IsOutcomeKey(outcome) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unknown outcome %q for market %s", req.Outcome, market)})
+			return
+		}
+
+		ctx := c.Request.Context()
+		if _, err := api.fixturesRepo.GetByID(ctx, req.FixtureID); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "fixture not found"})
+			return
+		}
+
+		item, err := api.watchlistService.Add(ctx, req.FixtureID, market, outcome)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add to watchlist: " + err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Added to watchlist",
+			"item":    item,
+		})
+	}
+}
+
+// getWatchlist returns saved outcomes re-evaluated against current odds and predictions
+func (api *API) getWatchlist() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		bankroll := api.cfg.InitialBankroll
+		if bankrollStr := c.Query("bankroll"); bankrollStr != "" {
+			if b, err := strconv.ParseFloat(bankrollStr, 64); err == nil {
+				bankroll = b
+			}
+		}
+
+		entries, err := api.watchlistService.List(c.Request.Context(), bankroll)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"items":    entries,
+			"total":    len(entries),
+			"bankroll": bankroll,
+			"currency": api.cfg.StakeCurrency,
+		})
+	}
+}
+
+// removeFromWatchlist deletes a saved outcome
+func (api *API) removeFromWatchlist() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid watchlist item ID"})
+			return
+		}
+
+		found, err := api.watchlistService.Remove(c.Request.Context(), id)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if !found {
+			c.JSON(http.StatusNotFound, gin.H{"error": "watchlist item not found"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Removed from watchlist",
+			"id":      id,
+		})
+	}
+}
+
+// getAccumulatorConfig returns current accumulator configuration
+func (api *API) getAccumulatorConfig() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		config := api.accumulatorService.Config()
+		c.JSON(http.StatusOK, gin.H{
+			"config": config,
+			"description": gin.H{
+				"min_legs":           "Minimum number of legs in accumulator",
+				"max_legs":           "Maximum number of legs in accumulator",
+				"min_ev_threshold":   "Minimum EV required for accumulator (5% = 0.05)",
+				"min_leg_probability": "Minimum probability per leg (40% = 0.40)",
+				"kelly_fraction":     "Kelly fraction for stake sizing (1/8 = 0.125)",
+				"max_stake_percent":  "Maximum % of bankroll on accumulators (20% = 0.20)",
+				"allow_same_team":    "Allow same team in different fixtures",
+				"allow_same_fixture": "Allow multiple markets from same fixture",
+				"max_combinations":   "Maximum leg combinations evaluated per accumulator size",
+				"max_leg_pool":       "Maximum legs (best EV first) considered for combining",
+				"dedupe_subsets":     "Drop accumulators whose legs all appear in a better accumulator",
+				"correlation_adjustment": "Price same-fixture legs as correlated rather than independent",
+				"leg_correlations":   "Correlation between same-fixture outcomes, keyed market:outcome|market:outcome",
+				"safe_min_leg_probability": "Minimum probability per leg in safe mode (65% = 0.65)",
+				"safe_min_combined_odds":   "Minimum combined odds in safe mode",
+				"safe_kelly_fraction":      "Kelly fraction for safe mode stakes (1/16 = 0.0625)",
+			},
+		})
+	}
+}
+
+// updateAccumulatorConfig validates, stores and applies a new accumulator configuration.
+// Fields left out of the body keep their current values.
+func (api *API) updateAccumulatorConfig() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		config := api.accumulatorService.Config()
+		if err := c.ShouldBindJSON(&config); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if err := config.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if err := api.accumulatorService.UpdateConfig(c.Request.Context(), config); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"config": api.accumulatorService.Config()})
+	}
+}
+
+// getModelMetrics returns ML model performance metrics
+func (api *API) getModelMetrics() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		ctx := c.Request.Context()
 
@@ -445,6 +1553,99 @@ func (api *API) getModelMetrics() gin.HandlerFunc {
 	}
 }
 
+// getModelAccuracy scores stored predictions against finished fixtures: accuracy,
+// log-loss and Brier score overall and bucketed by confidence
+func (api *API) getModelAccuracy() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		// Only 1X2 probabilities are stored with predictions
+		if market := c.DefaultQuery("market", markets.Model1X2); market != markets.Model1X2 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("accuracy is only tracked for the %s market", markets.Model1X2)})
+			return
+		}
+
+		season := 0
+		if seasonStr := c.Query("season"); seasonStr != "" {
+			s, err := strconv.Atoi(seasonStr)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid season parameter"})
+				return
+			}
+			season = s
+		}
+
+		buckets := services.DefaultCalibrationBuckets
+		if bucketsStr := c.Query("buckets"); bucketsStr != "" {
+			b, err := strconv.Atoi(bucketsStr)
+			if err != nil || b < 1 || b > 100 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "buckets must be between 1 and 100"})
+				return
+			}
+			buckets = b
+		}
+
+		report, err := api.predictionService.GetAccuracy(ctx, season, buckets)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"accuracy": report,
+		})
+	}
+}
+
+// getModelCalibration returns reliability diagram data for past predictions
+func (api *API) getModelCalibration() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		buckets := services.DefaultCalibrationBuckets
+		if bucketsStr := c.Query("buckets"); bucketsStr != "" {
+			b, err := strconv.Atoi(bucketsStr)
+			if err != nil || b < 1 || b > 100 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "buckets must be between 1 and 100"})
+				return
+			}
+			buckets = b
+		}
+
+		report, err := api.predictionService.GetCalibration(ctx, buckets)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"calibration": report,
+		})
+	}
+}
+
+// reloadModel reloads the ML models and returns the new model version
+func (api *API) reloadModel() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		metrics, err := api.predictionService.ReloadModel(ctx)
+		if err != nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"error":   "ML service unavailable",
+				"details": err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status":        "reloaded",
+			"model_version": metrics.ModelVersion,
+			"model":         metrics,
+		})
+	}
+}
+
 // getMLHealth returns ML service health status
 func (api *API) getMLHealth() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -475,21 +1676,215 @@ func (api *API) getMLHealth() gin.HandlerFunc {
 // getBets returns bets list handler
 func (api *API) getBets() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// TODO: Implement get bets
+		ctx := c.Request.Context()
+
+		limit, offset, err := parsePagination(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		filter := repository.BetFilter{
+			Status: c.Query("status"),
+			Limit:  limit,
+			Offset: offset,
+		}
+
+		if fixtureIDStr := c.Query("fixture_id"); fixtureIDStr != "" {
+			fixtureID, err := strconv.Atoi(fixtureIDStr)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid fixture_id parameter"})
+				return
+			}
+			filter.FixtureID = fixtureID
+		}
+
+		bets, total, err := api.betsRepo.List(ctx, filter)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		if bets == nil {
+			bets = []models.Bet{}
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"bets":   bets,
+			"total":  total,
+			"limit":  limit,
+			"offset": offset,
+		})
+	}
+}
+
+// getOdds lists stored odds, newest first, filtered by bookmaker, market and quote
+// time (from/to as YYYY-MM-DD or RFC3339) with limit/offset pagination
+func (api *API) getOdds() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit, offset, err := parsePagination(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		filter := repository.OddsFilter{
+			Bookmaker: strings.TrimSpace(c.Query("bookmaker")),
+			Limit:     limit,
+			Offset:    offset,
+		}
+
+		if market := c.Query("market"); market != "" {
+			if _, ok := markets.Get(market); !ok {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unknown market: %s", market)})
+				return
+			}
+			filter.Market = market
+		}
+
+		if filter.From, err = parseTimeParam(c.Query("from"), false); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from, use YYYY-MM-DD or RFC3339"})
+			return
+		}
+		if filter.To, err = parseTimeParam(c.Query("to"), true); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to, use YYYY-MM-DD or RFC3339"})
+			return
+		}
+		if !filter.From.IsZero() && !filter.To.IsZero() && filter.To.Before(filter.From) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "to must not be before from"})
+			return
+		}
+
+		odds, total, err := api.oddsRepo.List(c.Request.Context(), filter)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		if odds == nil {
+			odds = []models.Odds{}
+		}
+
 		c.JSON(http.StatusOK, gin.H{
-			"bets":  []interface{}{},
-			"total": 0,
+			"odds":   odds,
+			"total":  total,
+			"limit":  limit,
+			"offset": offset,
+		})
+	}
+}
+
+// betsCSVHeader is the column layout of the bets CSV export
+var betsCSVHeader = []string{
+	"bet_id", "fixture", "date", "market", "outcome", "odds", "bookmaker", "ev", "stake",
+	"status", "payout", "profit_loss", "placed_at", "settled_at",
+}
+
+// getBetsCSV exports bets as CSV, streaming rows from the database. Accepts the same
+// status and fixture_id filters as getBets, without pagination.
+func (api *API) getBetsCSV() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		filter := repository.BetFilter{Status: c.Query("status")}
+		if fixtureIDStr := c.Query("fixture_id"); fixtureIDStr != "" {
+			fixtureID, err := strconv.Atoi(fixtureIDStr)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid fixture_id parameter"})
+				return
+			}
+			filter.FixtureID = fixtureID
+		}
+
+		stream := newCSVStream(c, "bets.csv", betsCSVHeader)
+		err := api.betsRepo.StreamExport(c.Request.Context(), filter, func(bet *repository.BetExport) error {
+			market, outcome, ok := services.BetTypeToMarketOutcome(bet.BetType)
+			if !ok {
+				outcome = bet.BetType
+			}
+
+			settledAt := ""
+			if bet.SettledAt != nil {
+				settledAt = csvTime(*bet.SettledAt)
+			}
+
+			return stream.Write([]string{
+				strconv.Itoa(bet.ID),
+				fmt.Sprintf("%s vs %s", bet.HomeTeam, bet.AwayTeam),
+				csvTime(bet.MatchDate),
+				string(market),
+				outcome,
+				csvFloat(bet.Odds, 2),
+				bet.Bookmaker,
+				csvFloat(bet.ExpectedValue, 4),
+				csvFloat(bet.Stake, 2),
+				bet.Status,
+				csvOptionalFloat(bet.Payout, 2),
+				csvOptionalFloat(bet.ProfitLoss, 2),
+				csvTime(bet.PlacedAt),
+				settledAt,
+			})
 		})
+		if err != nil {
+			// Once rows are streamed the status is sent; the truncated file is all we can return
+			if !stream.Started() {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			c.Error(err)
+			return
+		}
+
+		if err := stream.Close(); err != nil {
+			c.Error(err)
+		}
 	}
 }
 
 // createBet returns create bet handler
 func (api *API) createBet() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// TODO: Implement create bet
+		ctx := c.Request.Context()
+
+		var req CreateBetRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if req.Stake <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "stake must be greater than 0"})
+			return
+		}
+
+		if req.Odds <= 1.0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "odds must be greater than 1.0"})
+			return
+		}
+
+		// Validate fixture exists
+		if _, err := api.fixturesRepo.GetByID(ctx, req.FixtureID); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "fixture not found"})
+			return
+		}
+
+		bet := &models.Bet{
+			FixtureID:     req.FixtureID,
+			PredictionID:  req.PredictionID,
+			BetType:       req.BetType,
+			Stake:         req.Stake,
+			Odds:          req.Odds,
+			ExpectedValue: req.ExpectedValue,
+			Bookmaker:     req.Bookmaker,
+			Notes:         req.Notes,
+			Status:        services.BetStatusPending,
+		}
+
+		if err := api.betsRepo.Create(ctx, bet); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create bet: " + err.Error()})
+			return
+		}
+
 		c.JSON(http.StatusCreated, gin.H{
-			"id":     1,
-			"status": "pending",
+			"bet": bet,
 		})
 	}
 }
@@ -497,11 +1892,37 @@ func (api *API) createBet() gin.HandlerFunc {
 // settleBet returns settle bet handler
 func (api *API) settleBet() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// TODO: Implement settle bet
-		betID := c.Param("id")
+		ctx := c.Request.Context()
+
+		betID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid bet ID"})
+			return
+		}
+
+		var req SettleBetRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		bet, err := api.settlementService.SettleBet(ctx, betID, req.Status)
+		if err != nil {
+			switch {
+			case errors.Is(err, services.ErrInvalidBetResult):
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			case errors.Is(err, services.ErrBetAlreadySettled):
+				c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			case errors.Is(err, repository.ErrBetNotFound):
+				c.JSON(http.StatusNotFound, gin.H{"error": "bet not found"})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			}
+			return
+		}
+
 		c.JSON(http.StatusOK, gin.H{
-			"id":     betID,
-			"status": "won",
+			"bet": bet,
 		})
 	}
 }
@@ -509,12 +1930,16 @@ func (api *API) settleBet() gin.HandlerFunc {
 // getPerformanceSummary returns performance summary handler
 func (api *API) getPerformanceSummary() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// TODO: Implement performance summary
+		ctx := c.Request.Context()
+
+		bets, err := api.betsRepo.GetSettled(ctx)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
 		c.JSON(http.StatusOK, gin.H{
-			"metrics": gin.H{
-				"total_bets": 0,
-				"roi":        0.0,
-			},
+			"metrics": services.CalculatePerformanceMetrics(bets),
 		})
 	}
 }
@@ -522,9 +1947,28 @@ func (api *API) getPerformanceSummary() gin.HandlerFunc {
 // getDailyPerformance returns daily performance handler
 func (api *API) getDailyPerformance() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// TODO: Implement daily performance
+		ctx := c.Request.Context()
+
+		loc, err := parseTimezone(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		bets, err := api.betsRepo.GetSettled(ctx)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		daily := services.CalculateDailyPerformance(bets, loc)
+		if daily == nil {
+			daily = []models.DailyPerformance{}
+		}
+
 		c.JSON(http.StatusOK, gin.H{
-			"daily_performance": []interface{}{},
+			"daily_performance": daily,
+			"timezone":          loc.String(),
 		})
 	}
 }
@@ -532,9 +1976,56 @@ func (api *API) getDailyPerformance() gin.HandlerFunc {
 // getBankrollHistory returns bankroll history handler
 func (api *API) getBankrollHistory() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// TODO: Implement bankroll history
+		ctx := c.Request.Context()
+
+		var from, to time.Time
+		if fromStr := c.Query("from"); fromStr != "" {
+			parsed, err := time.Parse("2006-01-02", fromStr)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from date, use YYYY-MM-DD"})
+				return
+			}
+			from = parsed
+		}
+		if toStr := c.Query("to"); toStr != "" {
+			parsed, err := time.Parse("2006-01-02", toStr)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to date, use YYYY-MM-DD"})
+				return
+			}
+			to = parsed.AddDate(0, 0, 1).Add(-time.Nanosecond) // Include the whole day
+		}
+
+		history, err := api.bankrollService.GetHistory(ctx, from, to)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if history == nil {
+			history = []models.Bankroll{}
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"history": history,
+			"count":   len(history),
+		})
+	}
+}
+
+// getCurrentBankroll returns the latest bankroll snapshot handler
+func (api *API) getCurrentBankroll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		bankroll, err := api.bankrollService.GetCurrent(ctx)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
 		c.JSON(http.StatusOK, gin.H{
-			"history": []interface{}{},
+			"bankroll": bankroll,
+			"currency": api.cfg.StakeCurrency,
 		})
 	}
 }
@@ -561,6 +2052,153 @@ func (api *API) getTeams() gin.HandlerFunc {
 	}
 }
 
+// getBookmakers returns the bookmakers reference list with display names and the
+// sharp/soft classification, optionally for one source (odds_api or apifootball)
+func (api *API) getBookmakers() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		source := c.Query("source")
+		if source != "" && source != models.OddsSourceOddsAPI && source != models.OddsSourceAPIFootball {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":         "invalid source parameter",
+				"valid_sources": []string{models.OddsSourceOddsAPI, models.OddsSourceAPIFootball},
+			})
+			return
+		}
+
+		bookmakers, err := api.bookmakersRepo.GetAll(c.Request.Context(), source)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"bookmakers": bookmakers,
+			"total":      len(bookmakers),
+		})
+	}
+}
+
+// StandingsEntry represents a row in the league table
+type StandingsEntry struct {
+	Rank  int              `json:"rank"`
+	Team  *models.Team     `json:"team,omitempty"`
+	Stats models.TeamStats `json:"stats"`
+}
+
+// getTeamForm returns a team's last N completed fixtures with a W/D/L tally, form
+// string and goals over the window
+func (api *API) getTeamForm() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		teamID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid team ID"})
+			return
+		}
+
+		limit := 5
+		if limitStr := c.Query("limit"); limitStr != "" {
+			l, err := strconv.Atoi(limitStr)
+			if err != nil || l < 1 || l > 50 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 50"})
+				return
+			}
+			limit = l
+		}
+
+		team, err := api.teamsRepo.GetByID(ctx, teamID)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "team not found"})
+			return
+		}
+
+		fixtures, err := api.fixturesRepo.GetRecentByTeam(ctx, teamID, limit)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		opponentIDs := make([]int, 0, len(fixtures))
+		for _, f := range fixtures {
+			opponentIDs = append(opponentIDs, f.HomeTeamID, f.AwayTeamID)
+		}
+		teams, err := api.teamsRepo.GetByIDs(ctx, opponentIDs)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"team":  team,
+			"form":  services.CalculateTeamForm(teamID, fixtures, teams),
+			"limit": limit,
+		})
+	}
+}
+
+// getStandings returns the league tables for a season, optionally for one league
+// (league_id, internal ID), in standings order
+func (api *API) getStandings() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		season, err := strconv.Atoi(c.Query("season"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "season query parameter is required"})
+			return
+		}
+
+		leagueID := 0
+		if leagueStr := c.Query("league_id"); leagueStr != "" {
+			leagueID, err = strconv.Atoi(leagueStr)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid league_id parameter"})
+				return
+			}
+		}
+
+		statsList, err := api.statsRepo.GetBySeason(ctx, leagueID, season)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		teamIDs := make([]int, len(statsList))
+		for i, stats := range statsList {
+			teamIDs[i] = stats.TeamID
+		}
+		teams, err := api.teamsRepo.GetByIDs(ctx, teamIDs)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Rank is the synced standings position, or the position within the league
+		// for stats derived from fixtures
+		positions := make(map[int]int)
+		standings := make([]StandingsEntry, 0, len(statsList))
+		for _, stats := range statsList {
+			positions[stats.LeagueID]++
+			entry := StandingsEntry{Rank: positions[stats.LeagueID], Stats: stats}
+			if stats.Rank != nil {
+				entry.Rank = *stats.Rank
+			}
+			if team, ok := teams[stats.TeamID]; ok {
+				entry.Team = &team
+			}
+			standings = append(standings, entry)
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"season":    season,
+			"league_id": leagueID,
+			"standings": standings,
+			"total":     len(standings),
+		})
+	}
+}
+
 // createManualFixture creates a fixture manually
 func (api *API) createManualFixture() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -575,7 +2213,13 @@ func (api *API) createManualFixture() gin.HandlerFunc {
 		// Parse match date
 		matchDate, err := time.Parse(time.RFC3339, req.MatchDate)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid match_date format, use RFC3339 (e.g., 2025-01-20T15:00:00Z)"})
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid match_date format, use RFC3339 (e.g., 2025-01-20T15:00:00Z)"})
+			return
+		}
+
+		status, err := req.validate(matchDate, time.Now())
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
 
@@ -614,7 +2258,9 @@ func (api *API) createManualFixture() gin.HandlerFunc {
 			MatchDate:     matchDate,
 			HomeTeamID:    req.HomeTeamID,
 			AwayTeamID:    req.AwayTeamID,
-			Status:        "NS", // Not Started
+			Status:        status,
+			HomeScore:     req.HomeScore,
+			AwayScore:     req.AwayScore,
 			VenueName:     req.VenueName,
 		}
 
@@ -656,15 +2302,12 @@ func (api *API) createManualOdds() gin.HandlerFunc {
 			return
 		}
 
-		// Validate market type and outcome
-		if !isValidMarketOutcome(req.MarketType, req.Outcome) {
+		// Validate market type, outcome and line
+		marketType, outcome, line, err := validateManualOdds(req.MarketType, req.Outcome, req.Line)
+		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "invalid market_type/outcome combination",
-				"valid_combinations": gin.H{
-					"h2h":    []string{"Home", "Draw", "Away"},
-					"totals": []string{"Over", "Under"},
-					"btts":   []string{"Yes", "No"},
-				},
+				"error":         err.Error(),
+				"valid_markets": markets.All(),
 			})
 			return
 		}
@@ -672,9 +2315,11 @@ func (api *API) createManualOdds() gin.HandlerFunc {
 		odds := &models.Odds{
 			FixtureID:  req.FixtureID,
 			Bookmaker:  req.Bookmaker,
-			MarketType: req.MarketType,
-			Outcome:    req.Outcome,
+			Source:     models.OddsSourceManual,
+			MarketType: marketType,
+			Outcome:    outcome,
 			OddsValue:  req.OddsValue,
+			Line:       line,
 			Timestamp:  time.Now(),
 		}
 
@@ -722,15 +2367,12 @@ func (api *API) createManualOddsBatch() gin.HandlerFunc {
 				return
 			}
 
-			if !isValidMarketOutcome(entry.MarketType, entry.Outcome) {
+			marketType, outcome, line, err := validateManualOdds(entry.MarketType, entry.Outcome, entry.Line)
+			if err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{
-					"error": "invalid market_type/outcome combination",
-					"index": i,
-					"valid_combinations": gin.H{
-						"h2h":    []string{"Home", "Draw", "Away"},
-						"totals": []string{"Over", "Under"},
-						"btts":   []string{"Yes", "No"},
-					},
+					"error":         err.Error(),
+					"index":         i,
+					"valid_markets": markets.All(),
 				})
 				return
 			}
@@ -738,38 +2380,148 @@ func (api *API) createManualOddsBatch() gin.HandlerFunc {
 			oddsList = append(oddsList, models.Odds{
 				FixtureID:  req.FixtureID,
 				Bookmaker:  req.Bookmaker,
-				MarketType: entry.MarketType,
-				Outcome:    entry.Outcome,
+				Source:     models.OddsSourceManual,
+				MarketType: marketType,
+				Outcome:    outcome,
 				OddsValue:  entry.OddsValue,
+				Line:       line,
 				Timestamp:  now,
 			})
 		}
 
 		// Insert all odds
-		if err := api.oddsRepo.CreateBatch(ctx, oddsList); err != nil {
+		inserted, skipped, err := api.oddsRepo.CreateBatch(ctx, oddsList)
+		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create odds: " + err.Error()})
 			return
 		}
 
 		c.JSON(http.StatusCreated, gin.H{
-			"odds_count": len(oddsList),
+			"odds_count": inserted,
+			"skipped":    skipped,
 			"fixture":    fixture,
 			"message":    "Odds added successfully. Fixture is now ready for predictions.",
 		})
 	}
 }
 
+// updateOdds corrects the price of a single odds row
+func (api *API) updateOdds() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		oddsID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid odds ID"})
+			return
+		}
+
+		var req UpdateOddsRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if req.OddsValue <= 1.0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "odds_value must be greater than 1.0"})
+			return
+		}
+
+		odds, found, err := api.oddsRepo.GetByID(ctx, oddsID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if !found {
+			c.JSON(http.StatusNotFound, gin.H{"error": "odds not found"})
+			return
+		}
+
+		// Only rows in markets that can be entered manually can be corrected
+		if !isValidMarketOutcome(odds.MarketType, odds.Outcome) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("odds with market_type %q and outcome %q can't be edited manually", odds.MarketType, odds.Outcome),
+			})
+			return
+		}
+
+		if err := api.oddsRepo.UpdateValue(ctx, oddsID, req.OddsValue); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update odds: " + err.Error()})
+			return
+		}
+		odds.OddsValue = req.OddsValue
+
+		c.JSON(http.StatusOK, gin.H{
+			"odds":    odds,
+			"message": "Odds updated successfully",
+		})
+	}
+}
+
+// deleteOdds removes a single odds row. Odds on fixtures with settled bets are kept
+// since settlement and CLV were computed from them.
+func (api *API) deleteOdds() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		oddsID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid odds ID"})
+			return
+		}
+
+		odds, found, err := api.oddsRepo.GetByID(ctx, oddsID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if !found {
+			c.JSON(http.StatusNotFound, gin.H{"error": "odds not found"})
+			return
+		}
+
+		settled, err := api.betsRepo.HasSettledByFixture(ctx, odds.FixtureID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if settled {
+			c.JSON(http.StatusConflict, gin.H{"error": "can't delete odds for a fixture with settled bets"})
+			return
+		}
+
+		if err := api.oddsRepo.Delete(ctx, oddsID); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete odds: " + err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Odds deleted successfully",
+			"odds_id": oddsID,
+		})
+	}
+}
+
 // getManualFixtures returns manually entered upcoming fixtures
 func (api *API) getManualFixtures() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		ctx := c.Request.Context()
 
+		loc, err := parseTimezone(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
 		// Get upcoming fixtures (includes manual entries)
-		fixtures, err := api.fixturesRepo.GetUpcoming(ctx, 50)
+		fixtures, err := api.fixturesRepo.GetUpcoming(ctx, 50, 0)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
+		for i := range fixtures {
+			localizeFixture(&fixtures[i], loc)
+		}
 
 		// Enrich with team names and odds status
 		type EnrichedFixture struct {
@@ -780,24 +2532,35 @@ func (api *API) getManualFixtures() gin.HandlerFunc {
 			OddsCount    int    `json:"odds_count"`
 		}
 
-		var enriched []EnrichedFixture
+		// Load teams and odds counts for all fixtures up front
+		teamIDs := make([]int, 0, len(fixtures)*2)
+		fixtureIDs := make([]int, 0, len(fixtures))
 		for _, f := range fixtures {
-			ef := EnrichedFixture{Fixture: f}
+			teamIDs = append(teamIDs, f.HomeTeamID, f.AwayTeamID)
+			fixtureIDs = append(fixtureIDs, f.ID)
+		}
 
-			// Get team names
-			if homeTeam, err := api.teamsRepo.GetByID(ctx, f.HomeTeamID); err == nil {
-				ef.HomeTeamName = homeTeam.Name
-			}
-			if awayTeam, err := api.teamsRepo.GetByID(ctx, f.AwayTeamID); err == nil {
-				ef.AwayTeamName = awayTeam.Name
-			}
+		teams, err := api.teamsRepo.GetByIDs(ctx, teamIDs)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
 
-			// Check if odds exist
-			odds, err := api.oddsRepo.GetLatestByFixture(ctx, f.ID)
-			if err == nil {
-				ef.HasOdds = len(odds) > 0
-				ef.OddsCount = len(odds)
+		oddsCounts, err := api.oddsRepo.CountByFixtureIDs(ctx, fixtureIDs)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		var enriched []EnrichedFixture
+		for _, f := range fixtures {
+			ef := EnrichedFixture{
+				Fixture:      f,
+				HomeTeamName: teams[f.HomeTeamID].Name,
+				AwayTeamName: teams[f.AwayTeamID].Name,
+				OddsCount:    oddsCounts[f.ID],
 			}
+			ef.HasOdds = ef.OddsCount > 0
 
 			enriched = append(enriched, ef)
 		}
@@ -833,35 +2596,354 @@ func (api *API) deleteManualFixture() gin.HandlerFunc {
 			return
 		}
 
+		// Soft delete: odds and predictions are kept so the fixture can be restored
 		if err := api.fixturesRepo.Delete(ctx, fixtureID); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete fixture: " + err.Error()})
+			respondFixtureDeleteError(c, err)
 			return
 		}
 
 		c.JSON(http.StatusOK, gin.H{
-			"message":    "Fixture deleted successfully",
+			"message":    "Fixture deleted successfully. Restore it using POST /api/fixtures/:id/restore",
 			"fixture_id": fixtureID,
 		})
 	}
 }
 
-// isValidMarketOutcome validates market type and outcome combinations
-func isValidMarketOutcome(marketType, outcome string) bool {
-	validCombinations := map[string][]string{
-		"h2h":    {"Home", "Draw", "Away"},
-		"totals": {"Over", "Under"},
-		"btts":   {"Yes", "No"},
+// getDeletedFixtures lists soft-deleted fixtures, most recently deleted first
+func (api *API) getDeletedFixtures() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		limit, offset, err := parsePagination(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		fixtures, total, err := api.fixturesRepo.ListDeleted(ctx, limit, offset)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		if fixtures == nil {
+			fixtures = []models.Fixture{}
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"fixtures": fixtures,
+			"total":    total,
+			"limit":    limit,
+			"offset":   offset,
+		})
+	}
+}
+
+// restoreFixture undoes a soft delete, returning the restored fixture
+func (api *API) restoreFixture() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		fixtureID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid fixture ID"})
+			return
+		}
+
+		found, err := api.fixturesRepo.Restore(ctx, fixtureID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if !found {
+			c.JSON(http.StatusNotFound, gin.H{"error": "deleted fixture not found"})
+			return
+		}
+
+		fixture, err := api.fixturesRepo.GetByIDWithTeams(ctx, fixtureID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Fixture restored successfully",
+			"fixture": fixture,
+		})
+	}
+}
+
+// purgeFixture permanently deletes a soft-deleted fixture with its odds and predictions
+func (api *API) purgeFixture() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		fixtureID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid fixture ID"})
+			return
+		}
+
+		purge, err := api.fixturesRepo.HardDelete(ctx, fixtureID)
+		if errors.Is(err, repository.ErrFixtureNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "deleted fixture not found; soft-delete it first using DELETE /api/fixtures/:id"})
+			return
+		}
+		if err != nil {
+			respondFixtureDeleteError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message":    "Fixture permanently deleted",
+			"fixture_id": fixtureID,
+			"deleted":    purge,
+		})
+	}
+}
+
+// respondFixtureDeleteError responds 404 if the fixture doesn't exist, 409 with the
+// blocking bets if it can't be deleted because of settled bets, or 500 for any other error
+func respondFixtureDeleteError(c *gin.Context, err error) {
+	if errors.Is(err, repository.ErrFixtureNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "fixture not found"})
+		return
+	}
+
+	var settledErr *repository.SettledBetsError
+	if errors.As(err, &settledErr) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":         "fixture has settled bets and can't be deleted",
+			"blocking_bets": settledErr.Bets,
+		})
+		return
 	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete fixture: " + err.Error()})
+}
 
-	validOutcomes, exists := validCombinations[marketType]
+// isValidMarketOutcome reports whether the outcome belongs to a known market
+func isValidMarketOutcome(marketType, outcome string) bool {
+	market, exists := markets.Get(marketType)
 	if !exists {
 		return false
 	}
+	_, valid := market.NormalizeOutcome(outcome)
+	return valid
+}
+
+// validateManualOdds checks a manual odds entry's market, outcome and line against the
+// market registry and returns the canonical market, outcome and line to store
+func validateManualOdds(marketType, outcome string, line *float64) (string, string, float64, error) {
+	market, exists := markets.Get(marketType)
+	if !exists {
+		return "", "", 0, fmt.Errorf("invalid market_type %q", marketType)
+	}
+
+	canonical, valid := market.NormalizeOutcome(outcome)
+	if !valid {
+		return "", "", 0, fmt.Errorf("invalid outcome %q for %s, use one of %s", outcome, market.Key, strings.Join(market.Outcomes, ", "))
+	}
+
+	storedLine, err := market.ValidateLine(line)
+	if err != nil {
+		return "", "", 0, err
+	}
+
+	return market.Key, canonical, storedLine, nil
+}
+
+// parsePagination reads limit/offset query parameters (default 50, max 100)
+func parsePagination(c *gin.Context) (int, int, error) {
+	limit := 50
+	offset := 0
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		l, err := strconv.Atoi(limitStr)
+		if err != nil || l <= 0 {
+			return 0, 0, fmt.Errorf("invalid limit parameter")
+		}
+		limit = l
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		o, err := strconv.Atoi(offsetStr)
+		if err != nil || o < 0 {
+			return 0, 0, fmt.Errorf("invalid offset parameter")
+		}
+		offset = o
+	}
+
+	return limit, offset, nil
+}
+
+// parseTimeParam parses an optional time query parameter given as RFC3339 or as a UTC
+// date (YYYY-MM-DD). A date means the start of the day, or its end when endOfDay is set
+// so the whole day is included. An empty value returns the zero time.
+func parseTimeParam(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	day, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		return day.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+	}
+	return day, nil
+}
+
+// parseOddsRange reads the min_odds and max_odds query parameters, falling back to
+// the defaults for bounds not given
+func parseOddsRange(c *gin.Context, defaults services.OddsRange) (services.OddsRange, error) {
+	oddsRange := defaults
+	for param, bound := range map[string]*float64{"min_odds": &oddsRange.Min, "max_odds": &oddsRange.Max} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		odds, err := strconv.ParseFloat(value, 64)
+		if err != nil || odds < 1 {
+			return services.OddsRange{}, fmt.Errorf("%s must be decimal odds of at least 1", param)
+		}
+		*bound = odds
+	}
+
+	if oddsRange.Max > 0 && oddsRange.Max < oddsRange.Min {
+		return services.OddsRange{}, fmt.Errorf("max_odds must not be below min_odds")
+	}
+	return oddsRange, nil
+}
+
+// parseFixtureWindow reads the kick-off window for picks: from/to dates (YYYY-MM-DD,
+// inclusive) or days ahead (default 7, max 60). Dates are calendar days in the tz
+// parameter's timezone.
+func parseFixtureWindow(c *gin.Context) (services.FixtureWindow, error) {
+	loc, err := parseTimezone(c)
+	if err != nil {
+		return services.FixtureWindow{}, err
+	}
+
+	fromStr, toStr := c.Query("from"), c.Query("to")
+	if fromStr == "" && toStr == "" {
+		days := services.DefaultFixtureWindowDays
+		if daysStr := c.Query("days"); daysStr != "" {
+			d, err := strconv.Atoi(daysStr)
+			if err != nil || d < 1 || d > 60 {
+				return services.FixtureWindow{}, fmt.Errorf("days must be between 1 and 60")
+			}
+			days = d
+		}
+		return services.NextDays(days).In(loc), nil
+	}
+
+	window := services.NextDays(services.DefaultFixtureWindowDays).In(loc)
+	if fromStr != "" {
+		from, err := time.ParseInLocation("2006-01-02", fromStr, loc)
+		if err != nil {
+			return services.FixtureWindow{}, fmt.Errorf("invalid from date, use YYYY-MM-DD")
+		}
+		window.From = from
+		window.To = from.AddDate(0, 0, services.DefaultFixtureWindowDays)
+	}
+	if toStr != "" {
+		to, err := time.ParseInLocation("2006-01-02", toStr, loc)
+		if err != nil {
+			return services.FixtureWindow{}, fmt.Errorf("invalid to date, use YYYY-MM-DD")
+		}
+		window.To = to.AddDate(0, 0, 1).Add(-time.Nanosecond) // Include the whole day
+	}
+
+	if window.To.Before(window.From) {
+		return services.FixtureWindow{}, fmt.Errorf("to must not be before from")
+	}
+
+	return window, nil
+}
+
+// parseTimezone reads the optional tz query parameter, an IANA zone name such as
+// "Europe/London". Dates are stored in UTC, which is also the default.
+func parseTimezone(c *gin.Context) (*time.Location, error) {
+	name := c.Query("tz")
+	if name == "" {
+		return time.UTC, nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil || name == "Local" {
+		return nil, fmt.Errorf("invalid tz %q, use an IANA zone name such as Europe/London", name)
+	}
+	return loc, nil
+}
+
+// localizeFixture shifts a fixture's match date into loc for display
+func localizeFixture(fixture *models.Fixture, loc *time.Location) {
+	fixture.MatchDate = fixture.MatchDate.In(loc)
+}
+
+// triggerSync returns a handler that starts a background sync job of the given type
+func (api *API) triggerSync(jobType string, fn func(ctx context.Context) error) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		job, started := api.syncJobs.Start(jobType, fn)
+		if !started {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": fmt.Sprintf("%s sync already running", jobType),
+				"job":   job,
+			})
+			return
+		}
+
+		c.JSON(http.StatusAccepted, gin.H{
+			"job_id": job.ID,
+			"job":    job,
+		})
+	}
+}
+
+// getSyncStatus returns the status of a sync job
+func (api *API) getSyncStatus() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		job, ok := api.syncJobs.Get(c.Param("id"))
+		if !ok {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Sync job not found"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"job": job})
+	}
+}
+
+// getSyncStatuses returns the last success, last error and staleness of each sync type
+func (api *API) getSyncStatuses() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		statuses, err := api.syncStatusRepo.GetAll(c.Request.Context())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		now := time.Now()
+		reports := services.BuildSyncStatusReports(statuses, now)
 
-	for _, valid := range validOutcomes {
-		if outcome == valid {
-			return true
+		anyStale := false
+		for _, report := range reports {
+			if report.Stale {
+				anyStale = true
+				break
+			}
 		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"syncs":      reports,
+			"stale":      anyStale,
+			"checked_at": now,
+		})
 	}
-	return false
 }
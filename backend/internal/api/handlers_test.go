@@ -0,0 +1,39 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dEnchanter/OddsIQ/backend/internal/repository"
+	"github.com/gin-gonic/gin"
+)
+
+func TestRespondFixtureDeleteError_MapsErrorsToStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"missing fixture", fmt.Errorf("%w with id %d", repository.ErrFixtureNotFound, 7), http.StatusNotFound},
+		{"settled bets", &repository.SettledBetsError{FixtureID: 7, Bets: []repository.BlockingBet{{ID: 2, Status: "won"}}}, http.StatusConflict},
+		{"database error", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			respondFixtureDeleteError(c, tt.err)
+
+			if w.Code != tt.expected {
+				t.Errorf("Expected status %d, got %d", tt.expected, w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"crypto/subtle"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/dEnchanter/OddsIQ/backend/config"
+)
+
+// rateLimitSweepInterval is how often buckets of idle clients are discarded
+const rateLimitSweepInterval = time.Minute
+
+// tokenBucket holds a client's remaining requests as of last
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// rateLimiter is a token bucket per client. Each bucket holds up to burst requests and
+// refills at rate requests per second.
+type rateLimiter struct {
+	mu        sync.Mutex
+	rate      float64
+	burst     float64
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// newRateLimiter creates a limiter for the configured limit, or returns nil if the
+// limit is disabled
+func newRateLimiter(limit config.RateLimit) *rateLimiter {
+	if limit.PerMinute <= 0 {
+		return nil
+	}
+
+	return &rateLimiter{
+		rate:      float64(limit.PerMinute) / 60,
+		burst:     float64(limit.Burst),
+		buckets:   make(map[string]*tokenBucket),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+// allow takes a token from the client's bucket. When the bucket is empty it returns
+// false and how long until the next token is available.
+func (l *rateLimiter) allow(client string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	bucket, ok := l.buckets[client]
+	if !ok {
+		bucket = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[client] = bucket
+	}
+
+	bucket.tokens = math.Min(l.burst, bucket.tokens+now.Sub(bucket.last).Seconds()*l.rate)
+	bucket.last = now
+
+	if bucket.tokens < 1 {
+		wait := time.Duration((1 - bucket.tokens) / l.rate * float64(time.Second))
+		return false, wait
+	}
+
+	bucket.tokens--
+	return true, 0
+}
+
+// sweep discards buckets that have refilled completely, since a new bucket for the
+// client would be identical. Runs at most once per rateLimitSweepInterval.
+func (l *rateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for client, bucket := range l.buckets {
+		if bucket.tokens+now.Sub(bucket.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, client)
+		}
+	}
+}
+
+// rateLimit rejects requests over the limiter's limit with 429 and a Retry-After header.
+// Clients sending one of apiKeys are limited per key, all others per IP. A nil limiter
+// lets every request through.
+func rateLimit(limiter *rateLimiter, apiKeys []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if limiter == nil {
+			c.Next()
+			return
+		}
+
+		allowed, wait := limiter.allow(rateLimitClient(c, apiKeys))
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "rate limit exceeded"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// rateLimitClient identifies the client a request is limited as. Only configured keys
+// count, so an arbitrary X-API-Key can't be used to get a fresh bucket.
+func rateLimitClient(c *gin.Context, apiKeys []string) string {
+	if provided := c.GetHeader("X-API-Key"); provided != "" {
+		for _, key := range apiKeys {
+			if subtle.ConstantTimeCompare([]byte(provided), []byte(key)) == 1 {
+				return "key:" + key
+			}
+		}
+	}
+	return "ip:" + c.ClientIP()
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dEnchanter/OddsIQ/backend/config"
+	"github.com/gin-gonic/gin"
+)
+
+// newRateLimitedRouter serves GET /ping behind limiter, on a clock the test controls
+func newRateLimitedRouter(limiter *rateLimiter, now *time.Time, apiKeys []string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	limiter.now = func() time.Time { return *now }
+
+	router := gin.New()
+	router.Use(rateLimit(limiter, apiKeys))
+	router.GET("/ping", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return router
+}
+
+func doRequest(router *gin.Engine, ip, apiKey string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.RemoteAddr = ip + ":1234"
+	if apiKey != "" {
+		req.Header.Set("X-API-Key", apiKey)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimit_BurstExceeded_Returns429WithRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	// 30 per minute refills a token every 2 seconds
+	router := newRateLimitedRouter(newRateLimiter(config.RateLimit{PerMinute: 30, Burst: 3}), &now, nil)
+
+	for i := 0; i < 3; i++ {
+		if w := doRequest(router, "10.0.0.1", ""); w.Code != http.StatusOK {
+			t.Fatalf("Request %d: expected 200 within the burst, got %d", i+1, w.Code)
+		}
+	}
+
+	w := doRequest(router, "10.0.0.1", "")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected 429 past the burst, got %d", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "2" {
+		t.Errorf("Expected Retry-After 2, got %q", got)
+	}
+
+	// Half a refill later the wait rounds up to the next whole second
+	now = now.Add(500 * time.Millisecond)
+	if got := doRequest(router, "10.0.0.1", "").Header().Get("Retry-After"); got != "2" {
+		t.Errorf("Expected Retry-After 2 after 0.5s, got %q", got)
+	}
+	now = now.Add(time.Second)
+	if got := doRequest(router, "10.0.0.1", "").Header().Get("Retry-After"); got != "1" {
+		t.Errorf("Expected Retry-After 1 after 1.5s, got %q", got)
+	}
+}
+
+func TestRateLimit_AfterRetryAfter_AllowsRequest(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	router := newRateLimitedRouter(newRateLimiter(config.RateLimit{PerMinute: 60, Burst: 1}), &now, nil)
+
+	doRequest(router, "10.0.0.1", "")
+	if w := doRequest(router, "10.0.0.1", ""); w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected 429, got %d", w.Code)
+	}
+
+	now = now.Add(time.Second)
+	if w := doRequest(router, "10.0.0.1", ""); w.Code != http.StatusOK {
+		t.Errorf("Expected 200 once a token refilled, got %d", w.Code)
+	}
+}
+
+func TestRateLimit_SeparateClients_HaveSeparateBuckets(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	router := newRateLimitedRouter(newRateLimiter(config.RateLimit{PerMinute: 60, Burst: 1}), &now, []string{"secret"})
+
+	doRequest(router, "10.0.0.1", "")
+	if w := doRequest(router, "10.0.0.2", ""); w.Code != http.StatusOK {
+		t.Errorf("Expected another IP to be allowed, got %d", w.Code)
+	}
+	if w := doRequest(router, "10.0.0.1", "secret"); w.Code != http.StatusOK {
+		t.Errorf("Expected a configured API key to get its own bucket, got %d", w.Code)
+	}
+	if w := doRequest(router, "10.0.0.1", "made-up"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected an unknown API key to share the IP's bucket, got %d", w.Code)
+	}
+}
+
+func TestRateLimit_Disabled_AllowsEveryRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(rateLimit(newRateLimiter(config.RateLimit{PerMinute: 0}), nil))
+	router.GET("/ping", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for i := 0; i < 100; i++ {
+		if w := doRequest(router, "10.0.0.1", ""); w.Code != http.StatusOK {
+			t.Fatalf("Request %d: expected 200, got %d", i+1, w.Code)
+		}
+	}
+}
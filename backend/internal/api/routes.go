@@ -6,56 +6,105 @@ import (
 	"github.com/dEnchanter/OddsIQ/backend/config"
 )
 
-func SetupRoutes(router *gin.Engine, db *pgxpool.Pool, cfg *config.Config) {
+// SetupRoutes registers all routes and returns the API so callers can share its
+// services (e.g. with the scheduler) and shut it down
+func SetupRoutes(router *gin.Engine, db *pgxpool.Pool, cfg *config.Config) *API {
 	// Create API instance with repositories
 	api := NewAPI(db, cfg)
 
 	// Health check endpoint
 	router.GET("/health", api.healthCheck())
+	router.GET("/health/detailed", api.detailedHealthCheck())
+
+	// Write endpoints require an X-API-Key from cfg.APIKeys; read endpoints stay public.
+	// Every route that changes stored data is listed with this middleware.
+	protected := requireAPIKey(cfg.APIKeys)
+
+	// Every /api endpoint is rate limited per client; endpoints that call the ML service
+	// or generate combinations are also limited by the stricter expensive limit.
+	expensive := rateLimit(newRateLimiter(cfg.ExpensiveRateLimit), cfg.APIKeys)
 
 	// API v1 group
-	v1 := router.Group("/api")
+	v1 := router.Group("/api", rateLimit(newRateLimiter(cfg.RateLimit), cfg.APIKeys))
 	{
 		// Teams endpoint (for manual entry dropdowns)
 		v1.GET("/teams", api.getTeams())
+		v1.GET("/teams/:id/form", api.getTeamForm()) // Recent results and form string
+
+		// Bookmakers endpoint (reference list synced from the odds sources)
+		v1.GET("/bookmakers", api.getBookmakers())
+
+		// Standings endpoint (league table from team_stats)
+		v1.GET("/standings", api.getStandings())
 
 		// Fixtures endpoints
 		fixtures := v1.Group("/fixtures")
 		{
 			fixtures.GET("", api.getFixtures())
 			fixtures.GET("/upcoming", api.getManualFixtures()) // List upcoming fixtures with odds status
+			fixtures.GET("/deleted", api.getDeletedFixtures()) // Soft-deleted fixtures that can be restored
 			fixtures.GET("/:id", api.getFixture())
 			fixtures.GET("/:id/odds", api.getFixtureOdds())
-			fixtures.POST("/manual", api.createManualFixture())     // Manual fixture entry
-			fixtures.DELETE("/:id", api.deleteManualFixture())      // Delete fixture
+			fixtures.GET("/:id/odds/history", api.getFixtureOddsHistory()) // Line movement for one outcome
+			fixtures.GET("/:id/odds/stream", api.streamFixtureOdds())       // Server-sent events as odds arrive
+			fixtures.GET("/:id/odds/best", api.getFixtureBestOdds())        // Best price across odds sources
+			fixtures.GET("/:id/odds/grid", api.getFixtureOddsGrid())        // Bookmakers x outcomes comparison
+			fixtures.GET("/:id/arbs", api.getFixtureArbs())                 // Cross-bookmaker arbitrage
+			fixtures.GET("/:id/implied", api.getFixtureImplied())           // No-vig market probabilities
+			fixtures.POST("/manual", protected, api.createManualFixture())     // Manual fixture entry
+			fixtures.DELETE("/:id", protected, api.deleteManualFixture())      // Soft-delete fixture
+			fixtures.POST("/:id/restore", protected, api.restoreFixture())     // Undo a soft delete
 		}
 
-		// Odds endpoints (manual entry)
+		// Odds endpoints (listing and manual entry)
 		odds := v1.Group("/odds")
 		{
-			odds.POST("/manual", api.createManualOdds())        // Add single odds entry
-			odds.POST("/manual/batch", api.createManualOddsBatch()) // Add multiple odds at once
+			odds.GET("", api.getOdds())                                        // Stored odds filtered by bookmaker, market and time
+			odds.POST("/manual", protected, api.createManualOdds())            // Add single odds entry
+			odds.POST("/manual/batch", protected, api.createManualOddsBatch()) // Add multiple odds at once
+			odds.PUT("/:id", protected, api.updateOdds())                      // Correct an odds value
+			odds.DELETE("/:id", protected, api.deleteOdds())                   // Remove an odds entry
 		}
 
 		// Picks endpoints
-		picks := v1.Group("/picks")
+		picks := v1.Group("/picks", expensive)
 		{
 			picks.GET("/weekly", api.getWeeklyPicks())             // Legacy 1X2 only
 			picks.GET("/multi", api.getMultiMarketPicks())         // Smart Market Selector (all markets)
+			picks.GET("/multi.csv", api.getMultiMarketPicksCSV())  // Same picks as CSV
+			picks.GET("/value", api.getValueBets())                // Flat list of filtered value bets
 		}
 
 		// Accumulators endpoints
 		accumulators := v1.Group("/accumulators")
 		{
-			accumulators.GET("/weekly", api.getWeeklyAccumulators())   // Weekly accumulator recommendations
+			accumulators.GET("/weekly", expensive, api.getWeeklyAccumulators())   // Weekly accumulator recommendations
 			accumulators.GET("/config", api.getAccumulatorConfig())    // Get accumulator configuration
+			accumulators.PUT("/config", protected, api.updateAccumulatorConfig()) // Update and persist accumulator configuration
+			accumulators.POST("/evaluate", expensive, api.evaluateAccumulator())  // Price a user-specified accumulator
+		}
+
+		// Alerts endpoints (model vs market divergence; only the POST records alerts)
+		alerts := v1.Group("/alerts", expensive)
+		{
+			alerts.GET("/value", api.getValueAlerts())
+			alerts.POST("/value", protected, api.recordValueAlerts()) // Scan and record alerts
+		}
+
+		// Watchlist endpoints (saved outcomes, re-evaluated on read)
+		watchlist := v1.Group("/watchlist")
+		{
+			watchlist.GET("", expensive, api.getWatchlist())
+			watchlist.POST("", protected, api.addToWatchlist())
+			watchlist.DELETE("/:id", protected, api.removeFromWatchlist())
 		}
 
 		// Predictions endpoints
-		predictions := v1.Group("/predictions")
+		predictions := v1.Group("/predictions", expensive)
 		{
 			predictions.GET("/fixture/:id", api.getPrediction())
 			predictions.GET("/fixture/:id/evaluate", api.evaluateFixture())  // Evaluate all markets
+			predictions.POST("/fixture/:id/evaluate", api.evaluateFixture()) // Evaluate with custom probabilities
 		}
 
 		// Model endpoints
@@ -64,15 +113,21 @@ func SetupRoutes(router *gin.Engine, db *pgxpool.Pool, cfg *config.Config) {
 			model.GET("/metrics", api.getModelMetrics())
 			model.GET("/metrics/all", api.getAllMarketsMetrics())  // All market models
 			model.GET("/health", api.getMLHealth())
+			model.GET("/calibration", api.getModelCalibration()) // Reliability diagram data
+			model.GET("/accuracy", api.getModelAccuracy())       // Prediction accuracy vs results
+			if cfg.EnableModelReload {
+				model.POST("/reload", protected, api.reloadModel()) // Reload models after retraining
+			}
 		}
 
 		// Bets endpoints
 		bets := v1.Group("/bets")
 		{
 			bets.GET("", api.getBets())
-			bets.POST("", api.createBet())
-			bets.PUT("/:id/settle", api.settleBet())
+			bets.POST("", protected, api.createBet())
+			bets.PUT("/:id/settle", protected, api.settleBet())
 		}
+		v1.GET("/bets.csv", api.getBetsCSV()) // Bets export as CSV
 
 		// Performance endpoints
 		performance := v1.Group("/performance")
@@ -85,6 +140,29 @@ func SetupRoutes(router *gin.Engine, db *pgxpool.Pool, cfg *config.Config) {
 		bankroll := v1.Group("/bankroll")
 		{
 			bankroll.GET("/history", api.getBankrollHistory())
+			bankroll.GET("/current", api.getCurrentBankroll())
+		}
+
+		// Sync status endpoint (last success, last error and staleness per sync type)
+		v1.GET("/sync/status", api.getSyncStatuses())
+
+		// Admin endpoints (only registered when an admin key is configured)
+		if cfg.AdminAPIKey != "" {
+			admin := v1.Group("/admin", requireAdminKey(cfg.AdminAPIKey))
+			{
+				admin.POST("/sync/fixtures", api.triggerSync("fixtures", api.fixtureSyncService.SyncUpcomingFixtures))
+				admin.POST("/sync/odds", api.triggerSync("odds", api.oddsSyncService.SyncAllMarkets))
+				admin.POST("/sync/odds/apifootball", api.triggerSync("odds_apifootball", api.footballOddsService.SyncUpcomingOdds))
+				admin.POST("/sync/results", api.triggerSync("results", api.fixtureSyncService.UpdateFixtureResults))
+				admin.POST("/sync/bookmakers", api.triggerSync("bookmakers", api.bookmakerSync.SyncBookmakers))
+				admin.GET("/sync/status/:id", api.getSyncStatus())
+				admin.DELETE("/fixtures/:id", api.purgeFixture()) // Permanently delete a soft-deleted fixture
+				if api.notificationService.Enabled() {
+					admin.POST("/notify/weekly", api.triggerSync("notify_weekly", api.notificationService.SendWeeklyDigest)) // Send the digest now
+				}
+			}
 		}
 	}
+
+	return api
 }
@@ -0,0 +1,32 @@
+// Package logging carries a request ID through contexts so the log lines written while
+// serving a request can be correlated with its access log entry.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log"
+)
+
+// requestIDKey is the context key for the request ID
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx carrying the request ID
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID carried by ctx, or "" outside a request
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// Printf logs like log.Printf, tagging the line with ctx's request ID if it has one
+func Printf(ctx context.Context, format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	if id := RequestID(ctx); id != "" {
+		msg += " request_id=" + id
+	}
+	log.Output(2, msg)
+}
@@ -0,0 +1,371 @@
+// Package markets is the registry of betting markets: how each market is keyed in
+// stored odds, the ML service and the data providers, its outcomes, and how its
+// lines and outcome keys are formatted. Adding a market should only touch this file.
+package markets
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Stored market keys (odds.market_type), matching The Odds API market keys
+const (
+	KeyH2H              = "h2h"
+	KeyTotals           = "totals"
+	KeyAlternateTotals  = "alternate_totals"
+	KeyBTTS             = "btts"
+	KeySpreads          = "spreads"
+	KeyAlternateSpreads = "alternate_spreads"
+)
+
+// Market keys used by the ML service and in evaluated bet outcomes
+const (
+	Model1X2       = "1x2"
+	ModelOverUnder = "over_under"
+	ModelBTTS      = "btts"
+	ModelHandicap  = "asian_handicap"
+)
+
+// API-Football bet IDs
+const (
+	apiFootballMatchWinner    = 1
+	apiFootballAsianHandicap  = 4
+	apiFootballGoalsOverUnder = 5
+	apiFootballBothTeamsScore = 8
+)
+
+// DefaultTotalsLine is the goals line assumed when none is given
+const DefaultTotalsLine = 2.5
+
+// Market describes a betting market
+type Market struct {
+	Key              string   `json:"key"`            // Stored odds market_type
+	Base             string   `json:"base,omitempty"` // Main market for alternate-line markets
+	ModelKey         string   `json:"model_key"`      // ML service market key
+	Name             string   `json:"name"`
+	Outcomes         []string `json:"outcomes"`               // Canonical stored outcome names
+	OddsAPIKey       string   `json:"odds_api_key,omitempty"` // The Odds API market key
+	APIFootballBetID int      `json:"api_football_bet_id,omitempty"`
+	LineStep         float64  `json:"line_step,omitempty"`    // Lines must be multiples of this; 0 means no line
+	LineRequired     bool     `json:"line_required"`          // A line must be given (no default)
+	DefaultLine      float64  `json:"default_line,omitempty"` // Line used when none is given
+	Aliases          []string `json:"-"`                      // Legacy market_type values
+
+	describe func(outcome string) string
+}
+
+// registry lists every known market, main markets first
+var registry = []*Market{
+	{
+		Key:              KeyH2H,
+		ModelKey:         Model1X2,
+		Name:             "Match result (1X2)",
+		Outcomes:         []string{"Home", "Draw", "Away"},
+		OddsAPIKey:       KeyH2H,
+		APIFootballBetID: apiFootballMatchWinner,
+		Aliases:          []string{Model1X2},
+		describe:         describe1X2,
+	},
+	{
+		Key:              KeyTotals,
+		ModelKey:         ModelOverUnder,
+		Name:             "Total goals over/under",
+		Outcomes:         []string{"Over", "Under"},
+		OddsAPIKey:       KeyTotals,
+		APIFootballBetID: apiFootballGoalsOverUnder,
+		LineStep:         0.5,
+		DefaultLine:      DefaultTotalsLine,
+		Aliases:          []string{ModelOverUnder},
+		describe:         describeTotals,
+	},
+	{
+		Key:              KeyBTTS,
+		ModelKey:         ModelBTTS,
+		Name:             "Both teams to score",
+		Outcomes:         []string{"Yes", "No"},
+		OddsAPIKey:       KeyBTTS,
+		APIFootballBetID: apiFootballBothTeamsScore,
+		describe:         describeBTTS,
+	},
+	{
+		Key:              KeySpreads,
+		ModelKey:         ModelHandicap,
+		Name:             "Asian handicap (line from the outcome side's perspective)",
+		Outcomes:         []string{"Home", "Away"},
+		OddsAPIKey:       KeySpreads,
+		APIFootballBetID: apiFootballAsianHandicap,
+		LineStep:         0.25,
+		LineRequired:     true,
+		Aliases:          []string{ModelHandicap},
+		describe:         describeHandicap,
+	},
+	{
+		Key:          KeyAlternateTotals,
+		Base:         KeyTotals,
+		ModelKey:     ModelOverUnder,
+		Name:         "Alternate total goals lines",
+		Outcomes:     []string{"Over", "Under"},
+		OddsAPIKey:   KeyAlternateTotals,
+		LineStep:     0.5,
+		LineRequired: true,
+		describe:     describeTotals,
+	},
+	{
+		Key:          KeyAlternateSpreads,
+		Base:         KeySpreads,
+		ModelKey:     ModelHandicap,
+		Name:         "Alternate Asian handicap lines",
+		Outcomes:     []string{"Home", "Away"},
+		OddsAPIKey:   KeyAlternateSpreads,
+		LineStep:     0.25,
+		LineRequired: true,
+		describe:     describeHandicap,
+	},
+}
+
+// byKey indexes the registry by key and alias
+var byKey = func() map[string]*Market {
+	index := make(map[string]*Market)
+	for _, m := range registry {
+		index[m.Key] = m
+		for _, alias := range m.Aliases {
+			index[alias] = m
+		}
+	}
+	return index
+}()
+
+// All returns every registered market, main markets first
+func All() []*Market {
+	all := make([]*Market, len(registry))
+	copy(all, registry)
+	return all
+}
+
+// Get returns the market for a stored market_type or alias (case-insensitive)
+func Get(key string) (*Market, bool) {
+	m, ok := byKey[strings.ToLower(key)]
+	return m, ok
+}
+
+// ForModel returns the main market for an ML service market key
+func ForModel(modelKey string) (*Market, bool) {
+	for _, m := range registry {
+		if m.ModelKey == modelKey && m.Base == "" {
+			return m, true
+		}
+	}
+	return nil, false
+}
+
+// ForAPIFootballBet returns the market for an API-Football bet ID
+func ForAPIFootballBet(betID int) (*Market, bool) {
+	for _, m := range registry {
+		if m.APIFootballBetID != 0 && m.APIFootballBetID == betID {
+			return m, true
+		}
+	}
+	return nil, false
+}
+
+// OddsAPIMarkets returns The Odds API keys of the main markets, as synced in bulk.
+// Alternate lines are only offered per event, so they're left out.
+func OddsAPIMarkets() []string {
+	var keys []string
+	for _, m := range registry {
+		if m.OddsAPIKey != "" && m.Base == "" {
+			keys = append(keys, m.OddsAPIKey)
+		}
+	}
+	return keys
+}
+
+// OutcomeCount returns the number of mutually exclusive outcomes in a market, or 0 if
+// the market is unknown
+func OutcomeCount(key string) int {
+	if m, ok := Get(key); ok {
+		return len(m.Outcomes)
+	}
+	return 0
+}
+
+// MainKey returns the key of the market whose outcomes this market is priced with:
+// the base market for alternate lines, otherwise its own key
+func (m *Market) MainKey() string {
+	if m.Base != "" {
+		return m.Base
+	}
+	return m.Key
+}
+
+// HasLine reports whether the market's odds are quoted against a line
+func (m *Market) HasLine() bool {
+	return m.LineStep > 0
+}
+
+// NormalizeOutcome returns the canonical outcome name for name (case-insensitive),
+// or false if it isn't one of the market's outcomes
+func (m *Market) NormalizeOutcome(name string) (string, bool) {
+	for _, outcome := range m.Outcomes {
+		if strings.EqualFold(outcome, name) {
+			return outcome, true
+		}
+	}
+	return "", false
+}
+
+// ValidateLine checks a line against the market's rules and returns the line to store.
+// A nil line takes the market's default unless one is required.
+func (m *Market) ValidateLine(line *float64) (float64, error) {
+	if line == nil {
+		if m.LineRequired {
+			return 0, fmt.Errorf("line is required for %s", m.Key)
+		}
+		return m.DefaultLine, nil
+	}
+
+	if !m.HasLine() {
+		if *line != 0 {
+			return 0, fmt.Errorf("%s does not take a line", m.Key)
+		}
+		return 0, nil
+	}
+
+	steps := *line / m.LineStep
+	if math.Abs(steps-math.Round(steps)) > 1e-9 {
+		return 0, fmt.Errorf("invalid line %g for %s, must be a multiple of %g", *line, m.Key, m.LineStep)
+	}
+	if m.ModelKey == ModelOverUnder && *line <= 0 {
+		return 0, fmt.Errorf("invalid line %g for %s, must be positive", *line, m.Key)
+	}
+
+	return *line, nil
+}
+
+// ModelOutcome returns the ML outcome key for a stored outcome and line
+// (e.g. h2h Home -> "home_win", totals Over 3.5 -> "over_3_5", spreads Away +1 -> "away_plus_1")
+func (m *Market) ModelOutcome(outcome string, line float64) (string, bool) {
+	outcome, ok := m.NormalizeOutcome(outcome)
+	if !ok {
+		return "", false
+	}
+
+	switch m.ModelKey {
+	case Model1X2:
+		return map[string]string{"Home": "home_win", "Draw": "draw", "Away": "away_win"}[outcome], true
+	case ModelOverUnder:
+		if line == 0 {
+			line = DefaultTotalsLine
+		}
+		return TotalsOutcomeKey(outcome, line), true
+	case ModelBTTS:
+		return strings.ToLower(outcome), true
+	case ModelHandicap:
+		return HandicapOutcomeKey(outcome, line), true
+	}
+
+	return "", false
+}
+
+// Describe returns a human-readable description of an ML outcome key in this market
+func (m *Market) Describe(outcome string) string {
+	if m.describe != nil {
+		if desc := m.describe(outcome); desc != "" {
+			return desc
+		}
+	}
+	return outcome
+}
+
+// IsOutcomeKey reports whether outcome is an ML outcome key in this market
+func (m *Market) IsOutcomeKey(outcome string) bool {
+	return m.describe != nil && m.describe(outcome) != ""
+}
+
+// describe1X2 describes "home_win", "draw" and "away_win"
+func describe1X2(outcome string) string {
+	return map[string]string{"home_win": "Home Win", "draw": "Draw", "away_win": "Away Win"}[outcome]
+}
+
+// describeTotals describes line-aware totals keys ("over_3_5" -> "Over 3.5 Goals")
+func describeTotals(outcome string) string {
+	for _, side := range []string{"Over", "Under"} {
+		if key, ok := strings.CutPrefix(outcome, strings.ToLower(side)+"_"); ok {
+			if line, ok := ParseLineKey(key); ok {
+				return fmt.Sprintf("%s %s Goals", side, strconv.FormatFloat(line, 'f', -1, 64))
+			}
+		}
+	}
+	return ""
+}
+
+// describeBTTS describes "yes" and "no"
+func describeBTTS(outcome string) string {
+	return map[string]string{"yes": "BTTS Yes", "no": "BTTS No"}[outcome]
+}
+
+// describeHandicap describes handicap keys ("home_minus_0_5" -> "Home -0.5 (Asian Handicap)")
+func describeHandicap(outcome string) string {
+	if side, line, ok := ParseHandicapOutcomeKey(outcome); ok {
+		return fmt.Sprintf("%s %+g (Asian Handicap)", side, line)
+	}
+	return ""
+}
+
+// LineKey formats a line for use in outcome keys (2.5 -> "2_5")
+func LineKey(line float64) string {
+	return strings.ReplaceAll(strconv.FormatFloat(line, 'f', -1, 64), ".", "_")
+}
+
+// ParseLineKey parses a line from an outcome key suffix ("3_5" -> 3.5)
+func ParseLineKey(key string) (float64, bool) {
+	line, err := strconv.ParseFloat(strings.ReplaceAll(key, "_", "."), 64)
+	if err != nil {
+		return 0, false
+	}
+	return line, true
+}
+
+// TotalsOutcomeKey builds a line-aware Over/Under outcome key (e.g. "over_3_5")
+func TotalsOutcomeKey(side string, line float64) string {
+	return strings.ToLower(side) + "_" + LineKey(line)
+}
+
+// HandicapOutcomeKey builds an Asian handicap outcome key from the side's own line
+// (e.g. Home -0.5 -> "home_minus_0_5", Away +1 -> "away_plus_1", Home 0 -> "home_0")
+func HandicapOutcomeKey(side string, line float64) string {
+	side = strings.ToLower(side)
+	switch {
+	case line < 0:
+		return side + "_minus_" + LineKey(-line)
+	case line > 0:
+		return side + "_plus_" + LineKey(line)
+	default:
+		return side + "_0"
+	}
+}
+
+// ParseHandicapOutcomeKey parses a handicap outcome key into its side ("Home"/"Away") and line
+func ParseHandicapOutcomeKey(key string) (side string, line float64, ok bool) {
+	for prefix, name := range map[string]string{"home_": "Home", "away_": "Away"} {
+		rest, found := strings.CutPrefix(key, prefix)
+		if !found {
+			continue
+		}
+
+		sign := 1.0
+		if value, neg := strings.CutPrefix(rest, "minus_"); neg {
+			rest, sign = value, -1
+		} else if value, pos := strings.CutPrefix(rest, "plus_"); pos {
+			rest = value
+		}
+
+		if line, valid := ParseLineKey(rest); valid {
+			return name, sign * line, true
+		}
+	}
+
+	return "", 0, false
+}
@@ -0,0 +1,166 @@
+package markets
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestGet_EveryKeyAndAlias_ReturnsItsMarket(t *testing.T) {
+	for _, m := range All() {
+		for _, key := range append([]string{m.Key, strings.ToUpper(m.Key)}, m.Aliases...) {
+			got, ok := Get(key)
+			if !ok || got != m {
+				t.Errorf("Get(%q): expected %s, got %v (found %v)", key, m.Key, got, ok)
+			}
+		}
+	}
+
+	if _, ok := Get("corners"); ok {
+		t.Error("Expected unknown market to be missing")
+	}
+}
+
+func TestForModel_EveryModelKey_ReturnsMainMarket(t *testing.T) {
+	for _, m := range All() {
+		got, ok := ForModel(m.ModelKey)
+		if !ok {
+			t.Errorf("ForModel(%q): expected a market", m.ModelKey)
+			continue
+		}
+		if got.Base != "" || got.Key != m.MainKey() {
+			t.Errorf("ForModel(%q): expected main market %s, got %s", m.ModelKey, m.MainKey(), got.Key)
+		}
+	}
+}
+
+func TestForAPIFootballBet_EveryBetID_RoundTrips(t *testing.T) {
+	for _, m := range All() {
+		if m.APIFootballBetID == 0 {
+			continue
+		}
+		if got, ok := ForAPIFootballBet(m.APIFootballBetID); !ok || got != m {
+			t.Errorf("ForAPIFootballBet(%d): expected %s, got %v", m.APIFootballBetID, m.Key, got)
+		}
+	}
+
+	if _, ok := ForAPIFootballBet(0); ok {
+		t.Error("Expected bet ID 0 to match no market")
+	}
+}
+
+func TestOddsAPIMarkets_ExcludesAlternateLines(t *testing.T) {
+	expected := []string{KeyH2H, KeyTotals, KeyBTTS, KeySpreads}
+
+	if got := OddsAPIMarkets(); !slices.Equal(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestModelOutcome_EveryOutcome_IsDescribedOutcomeKey(t *testing.T) {
+	lines := map[string][]float64{
+		Model1X2:       {0},
+		ModelBTTS:      {0},
+		ModelOverUnder: {0.5, 2.5, 3},
+		ModelHandicap:  {-1.25, -0.5, 0, 0.75, 2},
+	}
+
+	for _, m := range All() {
+		for _, outcome := range m.Outcomes {
+			for _, line := range lines[m.ModelKey] {
+				key, ok := m.ModelOutcome(strings.ToLower(outcome), line)
+				if !ok {
+					t.Errorf("%s %s %g: expected an outcome key", m.Key, outcome, line)
+					continue
+				}
+				if !m.IsOutcomeKey(key) {
+					t.Errorf("%s: expected %q to be an outcome key", m.Key, key)
+				}
+				if m.Describe(key) == key {
+					t.Errorf("%s: expected a description for %q", m.Key, key)
+				}
+			}
+		}
+	}
+}
+
+func TestModelOutcome_KnownOutcomes_ReturnExpectedKeys(t *testing.T) {
+	tests := []struct {
+		market, outcome string
+		line            float64
+		expected        string
+	}{
+		{KeyH2H, "Home", 0, "home_win"},
+		{KeyH2H, "draw", 0, "draw"},
+		{KeyTotals, "Over", 3.5, "over_3_5"},
+		{KeyTotals, "Under", 0, "under_2_5"},
+		{KeyBTTS, "Yes", 0, "yes"},
+		{KeySpreads, "Away", 1, "away_plus_1"},
+		{KeyAlternateSpreads, "Home", -0.75, "home_minus_0_75"},
+	}
+
+	for _, tt := range tests {
+		m, _ := Get(tt.market)
+		if got, ok := m.ModelOutcome(tt.outcome, tt.line); !ok || got != tt.expected {
+			t.Errorf("%s %s %g: expected %q, got %q", tt.market, tt.outcome, tt.line, tt.expected, got)
+		}
+	}
+
+	m, _ := Get(KeyH2H)
+	if _, ok := m.ModelOutcome("Over", 0); ok {
+		t.Error("Expected Over to be rejected for h2h")
+	}
+}
+
+func TestHandicapOutcomeKey_ParseRoundTrips(t *testing.T) {
+	for _, side := range []string{"Home", "Away"} {
+		for _, line := range []float64{-2.5, -1, -0.25, 0, 0.5, 1.75} {
+			key := HandicapOutcomeKey(side, line)
+			gotSide, gotLine, ok := ParseHandicapOutcomeKey(key)
+			if !ok || gotSide != side || gotLine != line {
+				t.Errorf("%q: expected %s %g, got %s %g (ok %v)", key, side, line, gotSide, gotLine, ok)
+			}
+		}
+	}
+}
+
+func TestLineKey_ParseRoundTrips(t *testing.T) {
+	for _, line := range []float64{0.5, 1, 2.25, 3.5, 10} {
+		if got, ok := ParseLineKey(LineKey(line)); !ok || got != line {
+			t.Errorf("%g: expected round trip via %q, got %g", line, LineKey(line), got)
+		}
+	}
+}
+
+func TestValidateLine_MarketRules(t *testing.T) {
+	line := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		market   string
+		line     *float64
+		expected float64
+		wantErr  bool
+	}{
+		{KeyTotals, nil, DefaultTotalsLine, false},
+		{KeyTotals, line(3), 3, false},
+		{KeyTotals, line(2.25), 0, true},
+		{KeyTotals, line(-0.5), 0, true},
+		{KeySpreads, nil, 0, true},
+		{KeySpreads, line(-0.25), -0.25, false},
+		{KeySpreads, line(0.3), 0, true},
+		{KeyH2H, nil, 0, false},
+		{KeyH2H, line(1), 0, true},
+	}
+
+	for _, tt := range tests {
+		m, _ := Get(tt.market)
+		got, err := m.ValidateLine(tt.line)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s %v: expected error %v, got %v", tt.market, tt.line, tt.wantErr, err)
+			continue
+		}
+		if !tt.wantErr && got != tt.expected {
+			t.Errorf("%s: expected line %g, got %g", tt.market, tt.expected, got)
+		}
+	}
+}
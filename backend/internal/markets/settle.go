@@ -0,0 +1,67 @@
+package markets
+
+import "strings"
+
+// BetResult is the outcome of settling a selection against a final score
+type BetResult string
+
+// Settlement results. A push refunds the stake: the score landed exactly on a
+// whole-number line, so neither side won.
+const (
+	ResultWin     BetResult = "win"
+	ResultLose    BetResult = "lose"
+	ResultPush    BetResult = "push"
+	ResultUnknown BetResult = "" // Unrecognised side
+)
+
+// SettleH2H settles a match result selection ("Home", "Draw" or "Away")
+func SettleH2H(homeScore, awayScore int, side string) BetResult {
+	switch {
+	case strings.EqualFold(side, "Home"):
+		return winOrLose(homeScore > awayScore)
+	case strings.EqualFold(side, "Draw"):
+		return winOrLose(homeScore == awayScore)
+	case strings.EqualFold(side, "Away"):
+		return winOrLose(awayScore > homeScore)
+	}
+	return ResultUnknown
+}
+
+// SettleOverUnder settles a total goals selection ("Over" or "Under") against a line.
+// A total equal to a whole-number line (e.g. 3 goals on 3.0) is a push.
+func SettleOverUnder(homeScore, awayScore int, line float64, side string) BetResult {
+	over := strings.EqualFold(side, "Over")
+	if !over && !strings.EqualFold(side, "Under") {
+		return ResultUnknown
+	}
+
+	total := float64(homeScore + awayScore)
+	switch {
+	case total == line:
+		return ResultPush
+	case over:
+		return winOrLose(total > line)
+	default:
+		return winOrLose(total < line)
+	}
+}
+
+// SettleBTTS settles a both teams to score selection ("Yes" or "No")
+func SettleBTTS(homeScore, awayScore int, side string) BetResult {
+	bothScored := homeScore > 0 && awayScore > 0
+	switch {
+	case strings.EqualFold(side, "Yes"):
+		return winOrLose(bothScored)
+	case strings.EqualFold(side, "No"):
+		return winOrLose(!bothScored)
+	}
+	return ResultUnknown
+}
+
+// winOrLose converts a boolean into a result
+func winOrLose(won bool) BetResult {
+	if won {
+		return ResultWin
+	}
+	return ResultLose
+}
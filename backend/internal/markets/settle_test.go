@@ -0,0 +1,92 @@
+package markets
+
+import "testing"
+
+func TestSettleH2H(t *testing.T) {
+	tests := []struct {
+		name       string
+		home, away int
+		side       string
+		expected   BetResult
+	}{
+		{"home win backed", 2, 1, "Home", ResultWin},
+		{"home win laid on away", 2, 1, "Away", ResultLose},
+		{"home win laid on draw", 2, 1, "Draw", ResultLose},
+		{"away win backed", 0, 3, "Away", ResultWin},
+		{"away win laid on home", 0, 3, "Home", ResultLose},
+		{"draw backed", 1, 1, "Draw", ResultWin},
+		{"goalless draw laid on home", 0, 0, "Home", ResultLose},
+		{"lowercase side", 2, 0, "home", ResultWin},
+		{"uppercase side", 1, 1, "DRAW", ResultWin},
+		{"unknown side", 2, 1, "1", ResultUnknown},
+		{"empty side", 2, 1, "", ResultUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SettleH2H(tt.home, tt.away, tt.side); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSettleOverUnder(t *testing.T) {
+	tests := []struct {
+		name       string
+		home, away int
+		line       float64
+		side       string
+		expected   BetResult
+	}{
+		{"half line over wins", 2, 1, 2.5, "Over", ResultWin},
+		{"half line under loses", 2, 1, 2.5, "Under", ResultLose},
+		{"half line under wins", 1, 1, 2.5, "Under", ResultWin},
+		{"half line over loses", 1, 1, 2.5, "Over", ResultLose},
+		{"low half line goalless", 0, 0, 0.5, "Under", ResultWin},
+		{"whole line over wins", 3, 1, 3.0, "Over", ResultWin},
+		{"whole line under loses", 3, 1, 3.0, "Under", ResultLose},
+		{"whole line under wins", 1, 1, 3.0, "Under", ResultWin},
+		{"whole line over pushes on total", 2, 1, 3.0, "Over", ResultPush},
+		{"whole line under pushes on total", 1, 2, 3.0, "Under", ResultPush},
+		{"lowercase side", 3, 0, 2.5, "over", ResultWin},
+		{"uppercase side", 0, 1, 2.5, "UNDER", ResultWin},
+		{"unknown side", 2, 1, 2.5, "Yes", ResultUnknown},
+		{"unknown side on pushed total", 2, 1, 3.0, "", ResultUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SettleOverUnder(tt.home, tt.away, tt.line, tt.side); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSettleBTTS(t *testing.T) {
+	tests := []struct {
+		name       string
+		home, away int
+		side       string
+		expected   BetResult
+	}{
+		{"both scored yes wins", 2, 1, "Yes", ResultWin},
+		{"both scored no loses", 1, 1, "No", ResultLose},
+		{"home blanked yes loses", 0, 2, "Yes", ResultLose},
+		{"away blanked no wins", 3, 0, "No", ResultWin},
+		{"goalless no wins", 0, 0, "No", ResultWin},
+		{"goalless yes loses", 0, 0, "Yes", ResultLose},
+		{"lowercase side", 1, 1, "yes", ResultWin},
+		{"uppercase side", 1, 0, "NO", ResultWin},
+		{"unknown side", 1, 1, "Over", ResultUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SettleBTTS(tt.home, tt.away, tt.side); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
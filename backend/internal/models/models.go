@@ -17,24 +17,84 @@ type Team struct {
 	UpdatedAt      time.Time `json:"updated_at"`
 }
 
+// League represents a competition that fixtures and teams belong to
+type League struct {
+	ID              int       `json:"id"`
+	APIFootballID   int       `json:"api_football_id"`
+	Name            string    `json:"name"`
+	Country         string    `json:"country"`
+	OddsAPISportKey string    `json:"odds_api_sport_key"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// TeamAlias maps an alternative team name (e.g. from a bookmaker feed) to a team
+type TeamAlias struct {
+	ID        int       `json:"id"`
+	Alias     string    `json:"alias"`
+	TeamID    int       `json:"team_id"`
+	Source    string    `json:"source"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// UnmatchedTeamName is a team name that couldn't be resolved to a team
+type UnmatchedTeamName struct {
+	ID              int       `json:"id"`
+	Name            string    `json:"name"`
+	Source          string    `json:"source"`
+	Occurrences     int       `json:"occurrences"`
+	CandidateTeamID *int      `json:"candidate_team_id"` // Best fuzzy match below the threshold
+	CandidateScore  *float64  `json:"candidate_score"`
+	FirstSeenAt     time.Time `json:"first_seen_at"`
+	LastSeenAt      time.Time `json:"last_seen_at"`
+}
+
 // Fixture represents a match fixture
 type Fixture struct {
-	ID             int       `json:"id"`
-	APIFootballID  int       `json:"api_football_id"`
-	Season         int       `json:"season"`
-	Round          string    `json:"round"`
-	MatchDate      time.Time `json:"match_date"`
-	HomeTeamID     int       `json:"home_team_id"`
-	AwayTeamID     int       `json:"away_team_id"`
-	HomeTeam       *Team     `json:"home_team,omitempty"`
-	AwayTeam       *Team     `json:"away_team,omitempty"`
-	HomeScore      *int      `json:"home_score"`
-	AwayScore      *int      `json:"away_score"`
-	Status         string    `json:"status"`
-	VenueName      string    `json:"venue"`
-	Referee        string    `json:"referee"`
-	CreatedAt      time.Time `json:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at"`
+	ID             int        `json:"id"`
+	APIFootballID  int        `json:"api_football_id"`
+	LeagueID       *int       `json:"league_id"`
+	Season         int        `json:"season"`
+	Round          string     `json:"round"`
+	MatchDate      time.Time  `json:"match_date"`
+	HomeTeamID     int        `json:"home_team_id"`
+	AwayTeamID     int        `json:"away_team_id"`
+	HomeTeam       *Team      `json:"home_team,omitempty"`
+	AwayTeam       *Team      `json:"away_team,omitempty"`
+	HomeScore      *int       `json:"home_score"`
+	AwayScore      *int       `json:"away_score"`
+	Status         string     `json:"status"`
+	VenueName      string     `json:"venue"`
+	Referee        string     `json:"referee"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	DeletedAt      *time.Time `json:"deleted_at,omitempty"` // Set when soft-deleted
+}
+
+// Bookmaker classifications: sharp books take large limits and move first,
+// soft (recreational) books shade their lines
+const (
+	BookmakerTypeSharp = "sharp"
+	BookmakerTypeSoft  = "soft"
+)
+
+// Odds sources: the provider an odds row came from
+const (
+	OddsSourceOddsAPI     = "odds_api"
+	OddsSourceAPIFootball = "apifootball"
+	OddsSourceManual      = "manual"
+)
+
+// Bookmaker is a bookmaker in the reference list synced from an odds source
+type Bookmaker struct {
+	ID        int       `json:"id"`
+	Key       string    `json:"key"`    // Key stored on odds rows from this source
+	Name      string    `json:"name"`   // Display name
+	Source    string    `json:"source"` // Provider (odds_api or apifootball)
+	IsSharp   bool      `json:"is_sharp"`
+	Region    string    `json:"region,omitempty"` // Odds API region (uk, eu), empty if unknown
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // Odds represents bookmaker odds for a fixture
@@ -42,11 +102,14 @@ type Odds struct {
 	ID            int       `json:"id"`
 	FixtureID     int       `json:"fixture_id"`
 	Bookmaker     string    `json:"bookmaker"`
+	Source        string    `json:"source"` // Provider (odds_api, apifootball or manual)
 	MarketType    string    `json:"market_type"`
 	Outcome       string    `json:"outcome"`
 	OddsValue     float64   `json:"odds_value"`
+	Line          float64   `json:"line"` // Totals/handicap line (e.g. 2.5), 0 for markets without a line
 	Timestamp     time.Time `json:"recorded_at"`
 	IsClosingLine bool      `json:"is_closing_line"`
+	IsLive        bool      `json:"is_live"` // Captured while the match was in play
 	CreatedAt     time.Time `json:"created_at"`
 }
 
@@ -54,7 +117,10 @@ type Odds struct {
 type TeamStats struct {
 	ID               int       `json:"id"`
 	TeamID           int       `json:"team_id"`
+	LeagueID         int       `json:"league_id"`
 	Season           int       `json:"season"`
+	Rank             *int      `json:"rank"`        // Standings position; nil when derived from fixtures
+	Description      string    `json:"description"` // Standings note, e.g. "Promotion - Champions League (Group Stage)"
 	MatchesPlayed    int       `json:"matches_played"`
 	Wins             int       `json:"wins"`
 	Draws            int       `json:"draws"`
@@ -93,6 +159,16 @@ type Prediction struct {
 	CreatedAt        time.Time              `json:"created_at"`
 }
 
+// PredictionResult pairs a fixture's last pre-match 1X2 prediction with its final score
+type PredictionResult struct {
+	FixtureID   int     `json:"fixture_id"`
+	HomeWinProb float64 `json:"home_win_prob"`
+	DrawProb    float64 `json:"draw_prob"`
+	AwayWinProb float64 `json:"away_win_prob"`
+	HomeScore   int     `json:"home_score"`
+	AwayScore   int     `json:"away_score"`
+}
+
 // Bet represents a placed bet
 type Bet struct {
 	ID            int       `json:"id"`
@@ -109,6 +185,7 @@ type Bet struct {
 	Payout        *float64  `json:"payout"`
 	ProfitLoss    *float64  `json:"profit_loss"`
 	SettledAt     *time.Time `json:"settled_at"`
+	ClosingLineValue *float64 `json:"closing_line_value"` // (placed_odds / closing_odds) - 1
 	Notes         string    `json:"notes"`
 	CreatedAt     time.Time `json:"created_at"`
 	UpdatedAt     time.Time `json:"updated_at"`
@@ -130,6 +207,35 @@ type Bankroll struct {
 	CreatedAt       time.Time `json:"created_at"`
 }
 
+// Sync types recorded in sync_status
+const (
+	SyncTypeFixtures   = "fixtures"
+	SyncTypeResults    = "results"
+	SyncTypeOdds       = "odds"
+	SyncTypeBookmakers = "bookmakers"
+)
+
+// SyncStatus records the last successful and failed run of a sync type
+type SyncStatus struct {
+	SyncType        string     `json:"sync_type"`
+	LastSuccessAt   *time.Time `json:"last_success_at"`
+	LastSuccessRows int        `json:"last_success_rows"`
+	LastError       *string    `json:"last_error"`
+	LastErrorAt     *time.Time `json:"last_error_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// BackfillProgress is the checkpoint of a league season backfill
+type BackfillProgress struct {
+	LeagueID       int       `json:"league_id"` // API-Football league ID
+	Season         int       `json:"season"`
+	TeamsDone      bool      `json:"teams_done"`
+	FixturesDone   bool      `json:"fixtures_done"`
+	LastRound      *string   `json:"last_round"` // Last fully synced round, nil before the first
+	FixturesSynced int       `json:"fixtures_synced"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
 // WeeklyPick represents a betting recommendation
 type WeeklyPick struct {
 	Fixture      Fixture    `json:"fixture"`
@@ -142,6 +248,8 @@ type WeeklyPick struct {
 	KellyFraction float64   `json:"kelly_fraction"`
 	BetType      string     `json:"bet_type"`
 	Confidence   string     `json:"confidence"`
+	StakeAdjusted bool      `json:"stake_adjusted"` // Stake raised to the configured minimum
+	SyntheticOdds bool      `json:"synthetic_odds"` // No stored odds; price derived from the prediction
 }
 
 // PerformanceMetrics represents performance summary
@@ -164,3 +272,40 @@ type PerformanceMetrics struct {
 	FromDate       time.Time `json:"from_date"`
 	ToDate         time.Time `json:"to_date"`
 }
+
+// DailyPerformance summarises the bets settled on one calendar day
+type DailyPerformance struct {
+	Date          string  `json:"date"` // YYYY-MM-DD in the requested timezone
+	TotalBets     int     `json:"total_bets"`
+	NumWins       int     `json:"num_wins"`
+	NumLosses     int     `json:"num_losses"`
+	NumVoids      int     `json:"num_voids"`
+	TotalStaked   float64 `json:"total_staked"`
+	TotalProfit   float64 `json:"total_profit"`
+	ROIPercentage float64 `json:"roi_percentage"`
+}
+
+// WatchlistItem is an outcome saved to revisit later
+type WatchlistItem struct {
+	ID        int       `json:"id"`
+	FixtureID int       `json:"fixture_id"`
+	Market    string    `json:"market"`  // ML market key, e.g. 1x2, over_under
+	Outcome   string    `json:"outcome"` // ML outcome key, e.g. home_win, over_2_5
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ValueAlert records an outcome whose model probability exceeded the market's no-vig
+// probability by at least the alert threshold
+type ValueAlert struct {
+	ID                int       `json:"id"`
+	FixtureID         int       `json:"fixture_id"`
+	Market            string    `json:"market"`  // ML market key, e.g. 1x2, over_under
+	Outcome           string    `json:"outcome"` // ML outcome key, e.g. home_win, over_2_5
+	ModelProbability  float64   `json:"model_probability"`
+	MarketProbability float64   `json:"market_probability"`
+	Edge              float64   `json:"edge"` // Model probability minus market probability
+	BestOdds          float64   `json:"best_odds"`
+	Bookmaker         string    `json:"bookmaker"`
+	FirstSeenAt       time.Time `json:"first_seen_at"`
+	LastSeenAt        time.Time `json:"last_seen_at"`
+}
@@ -0,0 +1,134 @@
+// Package oddsstream fans out newly stored odds to per-fixture subscribers, such as
+// the server-sent events endpoint. Publishing never blocks: a subscriber that falls
+// behind loses its oldest pending updates.
+package oddsstream
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+)
+
+// subscriberBuffer is how many updates a subscriber can fall behind before the
+// oldest are dropped
+const subscriberBuffer = 16
+
+// Update is a batch of odds rows newly stored for a fixture
+type Update struct {
+	FixtureID   int           `json:"fixture_id"`
+	Odds        []models.Odds `json:"odds"`
+	PublishedAt time.Time     `json:"published_at"`
+}
+
+// subscriber is one connection's update channel
+type subscriber struct {
+	updates chan Update
+}
+
+// Hub routes published updates to the subscribers of each fixture
+type Hub struct {
+	mu     sync.Mutex
+	subs   map[int]map[*subscriber]struct{}
+	closed bool
+}
+
+// NewHub creates an empty hub
+func NewHub() *Hub {
+	return &Hub{subs: make(map[int]map[*subscriber]struct{})}
+}
+
+// Subscribe registers for updates to a fixture. The returned channel is closed when
+// the hub is closed; call unsubscribe when done (e.g. on client disconnect).
+func (h *Hub) Subscribe(fixtureID int) (updates <-chan Update, unsubscribe func()) {
+	sub := &subscriber{updates: make(chan Update, subscriberBuffer)}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		close(sub.updates)
+		return sub.updates, func() {}
+	}
+
+	if h.subs[fixtureID] == nil {
+		h.subs[fixtureID] = make(map[*subscriber]struct{})
+	}
+	h.subs[fixtureID][sub] = struct{}{}
+
+	var once sync.Once
+	return sub.updates, func() {
+		once.Do(func() { h.remove(fixtureID, sub) })
+	}
+}
+
+// remove unregisters a subscriber and closes its channel
+func (h *Hub) remove(fixtureID int, sub *subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	subs, ok := h.subs[fixtureID]
+	if !ok {
+		return
+	}
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(h.subs, fixtureID)
+	}
+	close(sub.updates)
+}
+
+// Publish sends odds to every subscriber of the fixture without blocking. A nil hub
+// or an empty batch is a no-op.
+func (h *Hub) Publish(fixtureID int, odds []models.Odds) {
+	if h == nil || len(odds) == 0 {
+		return
+	}
+
+	update := Update{FixtureID: fixtureID, Odds: odds, PublishedAt: time.Now()}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subs[fixtureID] {
+		select {
+		case sub.updates <- update:
+			continue
+		default:
+		}
+
+		// Full: drop the oldest pending update to make room. Only the subscriber
+		// drains concurrently, so the second send can't block.
+		select {
+		case <-sub.updates:
+		default:
+		}
+		select {
+		case sub.updates <- update:
+		default:
+		}
+	}
+}
+
+// Close closes every subscriber channel so streaming handlers return, and rejects
+// new subscriptions. Publishing after Close is a no-op.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return
+	}
+	h.closed = true
+
+	for fixtureID, subs := range h.subs {
+		for sub := range subs {
+			close(sub.updates)
+		}
+		delete(h.subs, fixtureID)
+	}
+}
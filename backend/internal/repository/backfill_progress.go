@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+)
+
+// BackfillProgressRepository stores per-season backfill checkpoints
+type BackfillProgressRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewBackfillProgressRepository creates a new backfill progress repository
+func NewBackfillProgressRepository(db *pgxpool.Pool) *BackfillProgressRepository {
+	return &BackfillProgressRepository{db: db}
+}
+
+// Get retrieves the checkpoint of a league season. found is false if the season has
+// no recorded progress.
+func (r *BackfillProgressRepository) Get(ctx context.Context, leagueID, season int) (*models.BackfillProgress, bool, error) {
+	query := `
+		SELECT league_id, season, teams_done, fixtures_done, last_round, fixtures_synced, updated_at
+		FROM backfill_progress
+		WHERE league_id = $1 AND season = $2
+	`
+
+	progress := &models.BackfillProgress{}
+	err := r.db.QueryRow(ctx, query, leagueID, season).Scan(
+		&progress.LeagueID,
+		&progress.Season,
+		&progress.TeamsDone,
+		&progress.FixturesDone,
+		&progress.LastRound,
+		&progress.FixturesSynced,
+		&progress.UpdatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get backfill progress: %w", err)
+	}
+
+	return progress, true, nil
+}
+
+// MarkTeamsDone records that a season's teams are synced
+func (r *BackfillProgressRepository) MarkTeamsDone(ctx context.Context, leagueID, season int) error {
+	query := `
+		INSERT INTO backfill_progress (league_id, season, teams_done, updated_at)
+		VALUES ($1, $2, TRUE, $3)
+		ON CONFLICT (league_id, season) DO UPDATE SET
+			teams_done = TRUE,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	if _, err := r.db.Exec(ctx, query, leagueID, season, time.Now().UTC()); err != nil {
+		return fmt.Errorf("failed to record teams progress: %w", err)
+	}
+
+	return nil
+}
+
+// RecordRound checkpoints a season's fixtures after a round completes, with the number
+// of fixtures stored so far
+func (r *BackfillProgressRepository) RecordRound(ctx context.Context, leagueID, season int, round string, synced int) error {
+	query := `
+		INSERT INTO backfill_progress (league_id, season, last_round, fixtures_synced, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (league_id, season) DO UPDATE SET
+			fixtures_done = FALSE,
+			last_round = EXCLUDED.last_round,
+			fixtures_synced = EXCLUDED.fixtures_synced,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	if _, err := r.db.Exec(ctx, query, leagueID, season, round, synced, time.Now().UTC()); err != nil {
+		return fmt.Errorf("failed to record round progress: %w", err)
+	}
+
+	return nil
+}
+
+// MarkFixturesDone records that all of a season's fixtures are synced
+func (r *BackfillProgressRepository) MarkFixturesDone(ctx context.Context, leagueID, season int) error {
+	query := `
+		INSERT INTO backfill_progress (league_id, season, fixtures_done, updated_at)
+		VALUES ($1, $2, TRUE, $3)
+		ON CONFLICT (league_id, season) DO UPDATE SET
+			fixtures_done = TRUE,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	if _, err := r.db.Exec(ctx, query, leagueID, season, time.Now().UTC()); err != nil {
+		return fmt.Errorf("failed to record fixtures progress: %w", err)
+	}
+
+	return nil
+}
+
+// Reset deletes a season's checkpoint so it is backfilled from scratch
+func (r *BackfillProgressRepository) Reset(ctx context.Context, leagueID, season int) error {
+	query := `DELETE FROM backfill_progress WHERE league_id = $1 AND season = $2`
+
+	if _, err := r.db.Exec(ctx, query, leagueID, season); err != nil {
+		return fmt.Errorf("failed to reset backfill progress: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+)
+
+// BankrollRepository handles bankroll snapshot database operations
+type BankrollRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewBankrollRepository creates a new bankroll repository
+func NewBankrollRepository(db *pgxpool.Pool) *BankrollRepository {
+	return &BankrollRepository{db: db}
+}
+
+const bankrollColumns = `
+	id, balance, COALESCE(total_staked, 0), COALESCE(total_returned, 0), COALESCE(total_profit_loss, 0),
+	COALESCE(roi_percentage, 0), COALESCE(num_bets, 0), COALESCE(num_wins, 0), COALESCE(num_losses, 0),
+	COALESCE(win_rate, 0), recorded_at, created_at
+`
+
+// Create appends a bankroll snapshot
+func (r *BankrollRepository) Create(ctx context.Context, snapshot *models.Bankroll) error {
+	query := `
+		INSERT INTO bankroll (
+			balance, total_staked, total_returned, total_profit_loss, roi_percentage,
+			num_bets, num_wins, num_losses, win_rate, recorded_at, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id
+	`
+
+	now := time.Now().UTC()
+	if snapshot.RecordedAt.IsZero() {
+		snapshot.RecordedAt = now
+	}
+
+	err := r.db.QueryRow(ctx, query,
+		snapshot.Balance,
+		snapshot.TotalStaked,
+		snapshot.TotalReturned,
+		snapshot.TotalProfitLoss,
+		snapshot.ROIPercentage,
+		snapshot.NumBets,
+		snapshot.NumWins,
+		snapshot.NumLosses,
+		snapshot.WinRate,
+		snapshot.RecordedAt.UTC(),
+		now,
+	).Scan(&snapshot.ID)
+
+	if err != nil {
+		return fmt.Errorf("failed to create bankroll snapshot: %w", err)
+	}
+
+	snapshot.CreatedAt = now
+
+	return nil
+}
+
+// GetLatest retrieves the most recent bankroll snapshot.
+// found is false if no snapshot has been recorded yet.
+func (r *BankrollRepository) GetLatest(ctx context.Context) (snapshot *models.Bankroll, found bool, err error) {
+	query := `SELECT ` + bankrollColumns + ` FROM bankroll ORDER BY recorded_at DESC, id DESC LIMIT 1`
+
+	snapshot, err = scanBankroll(r.db.QueryRow(ctx, query))
+	if err == pgx.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get latest bankroll snapshot: %w", err)
+	}
+
+	return snapshot, true, nil
+}
+
+// GetHistory retrieves bankroll snapshots recorded between from and to, oldest first.
+// A zero from or to leaves that end of the range open.
+func (r *BankrollRepository) GetHistory(ctx context.Context, from, to time.Time) ([]models.Bankroll, error) {
+	query := `
+		SELECT ` + bankrollColumns + `
+		FROM bankroll
+		WHERE ($1::timestamp IS NULL OR recorded_at >= $1)
+		  AND ($2::timestamp IS NULL OR recorded_at <= $2)
+		ORDER BY recorded_at, id
+	`
+
+	var fromArg, toArg *time.Time
+	if !from.IsZero() {
+		from = from.UTC()
+		fromArg = &from
+	}
+	if !to.IsZero() {
+		to = to.UTC()
+		toArg = &to
+	}
+
+	rows, err := r.db.Query(ctx, query, fromArg, toArg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query bankroll history: %w", err)
+	}
+	defer rows.Close()
+
+	var history []models.Bankroll
+	for rows.Next() {
+		snapshot, err := scanBankroll(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan bankroll snapshot: %w", err)
+		}
+		history = append(history, *snapshot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating bankroll history: %w", err)
+	}
+
+	return history, nil
+}
+
+// scanBankroll scans a single bankroll row
+func scanBankroll(row pgx.Row) (*models.Bankroll, error) {
+	snapshot := &models.Bankroll{}
+	err := row.Scan(
+		&snapshot.ID,
+		&snapshot.Balance,
+		&snapshot.TotalStaked,
+		&snapshot.TotalReturned,
+		&snapshot.TotalProfitLoss,
+		&snapshot.ROIPercentage,
+		&snapshot.NumBets,
+		&snapshot.NumWins,
+		&snapshot.NumLosses,
+		&snapshot.WinRate,
+		&snapshot.RecordedAt,
+		&snapshot.CreatedAt,
+	)
+	return snapshot, err
+}
@@ -0,0 +1,352 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+)
+
+// ErrBetNotFound is returned when no bet exists with the requested ID
+var ErrBetNotFound = errors.New("bet not found")
+
+// ErrBetNotPending is returned when settling a bet that has already been settled
+var ErrBetNotPending = errors.New("bet is not pending")
+
+// BetsRepository handles bet database operations
+type BetsRepository struct {
+	db dbtx
+}
+
+// NewBetsRepository creates a new bets repository
+func NewBetsRepository(db *pgxpool.Pool) *BetsRepository {
+	return &BetsRepository{db: db}
+}
+
+// BetFilter holds optional filters for listing bets
+type BetFilter struct {
+	Status    string
+	FixtureID int
+	Limit     int
+	Offset    int
+}
+
+const betColumns = `
+	id, fixture_id, prediction_id, bet_type, stake, odds, expected_value,
+	COALESCE(bookmaker, ''), COALESCE(placed_at, created_at), status,
+	payout, profit_loss, settled_at, closing_line_value, COALESCE(notes, ''), created_at, updated_at
+`
+
+// Create inserts a new bet
+func (r *BetsRepository) Create(ctx context.Context, bet *models.Bet) error {
+	query := `
+		INSERT INTO bets (
+			fixture_id, prediction_id, bet_type, stake, odds, expected_value,
+			bookmaker, placed_at, status, notes, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING id
+	`
+
+	now := time.Now().UTC()
+	if bet.PlacedAt.IsZero() {
+		bet.PlacedAt = now
+	}
+	if bet.Status == "" {
+		bet.Status = "pending"
+	}
+
+	err := r.db.QueryRow(ctx, query,
+		bet.FixtureID,
+		bet.PredictionID,
+		bet.BetType,
+		bet.Stake,
+		bet.Odds,
+		bet.ExpectedValue,
+		bet.Bookmaker,
+		bet.PlacedAt.UTC(),
+		bet.Status,
+		bet.Notes,
+		now,
+		now,
+	).Scan(&bet.ID)
+
+	if err != nil {
+		return fmt.Errorf("failed to create bet: %w", err)
+	}
+
+	bet.CreatedAt = now
+	bet.UpdatedAt = now
+
+	return nil
+}
+
+// GetByID retrieves a bet by ID
+func (r *BetsRepository) GetByID(ctx context.Context, id int) (*models.Bet, error) {
+	query := `SELECT ` + betColumns + ` FROM bets WHERE id = $1`
+
+	bet := &models.Bet{}
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&bet.ID,
+		&bet.FixtureID,
+		&bet.PredictionID,
+		&bet.BetType,
+		&bet.Stake,
+		&bet.Odds,
+		&bet.ExpectedValue,
+		&bet.Bookmaker,
+		&bet.PlacedAt,
+		&bet.Status,
+		&bet.Payout,
+		&bet.ProfitLoss,
+		&bet.SettledAt,
+		&bet.ClosingLineValue,
+		&bet.Notes,
+		&bet.CreatedAt,
+		&bet.UpdatedAt,
+	)
+
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("%w with id %d", ErrBetNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bet: %w", err)
+	}
+
+	return bet, nil
+}
+
+// where builds the WHERE clause and arguments for the filter's conditions, qualifying
+// columns with prefix (e.g. "b.") when the bets table is aliased
+func (filter BetFilter) where(prefix string) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("%sstatus = $%d", prefix, len(args)))
+	}
+	if filter.FixtureID > 0 {
+		args = append(args, filter.FixtureID)
+		conditions = append(conditions, fmt.Sprintf("%sfixture_id = $%d", prefix, len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// List retrieves bets matching the filter, along with the total count before pagination
+func (r *BetsRepository) List(ctx context.Context, filter BetFilter) ([]models.Bet, int, error) {
+	where, args := filter.where("")
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM bets ` + where
+	if err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count bets: %w", err)
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := fmt.Sprintf(`SELECT %s FROM bets %s ORDER BY placed_at DESC, id DESC LIMIT $%d OFFSET $%d`,
+		betColumns, where, len(args)-1, len(args))
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query bets: %w", err)
+	}
+	defer rows.Close()
+
+	bets, err := r.scanBets(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return bets, total, nil
+}
+
+// BetExport is a bet with its fixture's kickoff and team names, as exported to CSV
+type BetExport struct {
+	models.Bet
+	MatchDate time.Time
+	HomeTeam  string
+	AwayTeam  string
+}
+
+// StreamExport calls fn for every bet matching the filter, newest first, ignoring
+// pagination. Rows are scanned one at a time so large exports aren't held in memory;
+// an error from fn stops the export and is returned.
+func (r *BetsRepository) StreamExport(ctx context.Context, filter BetFilter, fn func(*BetExport) error) error {
+	where, args := filter.where("b.")
+	query := `
+		SELECT
+			b.id, b.fixture_id, b.prediction_id, b.bet_type, b.stake, b.odds, b.expected_value,
+			COALESCE(b.bookmaker, ''), COALESCE(b.placed_at, b.created_at), b.status,
+			b.payout, b.profit_loss, b.settled_at, b.closing_line_value, COALESCE(b.notes, ''), b.created_at, b.updated_at,
+			f.match_date, COALESCE(ht.name, ''), COALESCE(at.name, '')
+		FROM bets b
+		JOIN fixtures f ON f.id = b.fixture_id
+		LEFT JOIN teams ht ON ht.id = f.home_team_id
+		LEFT JOIN teams at ON at.id = f.away_team_id
+		` + where + `
+		ORDER BY COALESCE(b.placed_at, b.created_at) DESC, b.id DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query bets for export: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var export BetExport
+		bet := &export.Bet
+		err := rows.Scan(
+			&bet.ID,
+			&bet.FixtureID,
+			&bet.PredictionID,
+			&bet.BetType,
+			&bet.Stake,
+			&bet.Odds,
+			&bet.ExpectedValue,
+			&bet.Bookmaker,
+			&bet.PlacedAt,
+			&bet.Status,
+			&bet.Payout,
+			&bet.ProfitLoss,
+			&bet.SettledAt,
+			&bet.ClosingLineValue,
+			&bet.Notes,
+			&bet.CreatedAt,
+			&bet.UpdatedAt,
+			&export.MatchDate,
+			&export.HomeTeam,
+			&export.AwayTeam,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan bet: %w", err)
+		}
+		if err := fn(&export); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	return nil
+}
+
+// GetPendingByFixture retrieves all pending bets on a fixture
+func (r *BetsRepository) GetPendingByFixture(ctx context.Context, fixtureID int) ([]models.Bet, error) {
+	query := `SELECT ` + betColumns + ` FROM bets WHERE fixture_id = $1 AND status = 'pending' ORDER BY id`
+
+	rows, err := r.db.Query(ctx, query, fixtureID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending bets: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanBets(rows)
+}
+
+// HasSettledByFixture reports whether any bet on the fixture has been settled
+func (r *BetsRepository) HasSettledByFixture(ctx context.Context, fixtureID int) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM bets WHERE fixture_id = $1 AND status <> 'pending')`
+
+	var exists bool
+	if err := r.db.QueryRow(ctx, query, fixtureID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check settled bets: %w", err)
+	}
+
+	return exists, nil
+}
+
+// GetSettled retrieves all settled bets ordered by settlement time
+func (r *BetsRepository) GetSettled(ctx context.Context) ([]models.Bet, error) {
+	query := `SELECT ` + betColumns + ` FROM bets WHERE status <> 'pending' ORDER BY settled_at, id`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query settled bets: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanBets(rows)
+}
+
+// Settle records the result of a pending bet. Returns ErrBetNotPending if the bet is
+// not pending, e.g. because a concurrent call settled it first.
+func (r *BetsRepository) Settle(ctx context.Context, bet *models.Bet) error {
+	query := `
+		UPDATE bets
+		SET status = $1, payout = $2, profit_loss = $3, settled_at = $4, closing_line_value = $5, updated_at = $6
+		WHERE id = $7 AND status = 'pending'
+	`
+
+	now := time.Now().UTC()
+	result, err := r.db.Exec(ctx, query,
+		bet.Status,
+		bet.Payout,
+		bet.ProfitLoss,
+		bet.SettledAt,
+		bet.ClosingLineValue,
+		now,
+		bet.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to settle bet: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%w with id %d", ErrBetNotPending, bet.ID)
+	}
+
+	bet.UpdatedAt = now
+
+	return nil
+}
+
+// Helper function to scan bets from rows
+func (r *BetsRepository) scanBets(rows pgx.Rows) ([]models.Bet, error) {
+	var bets []models.Bet
+	for rows.Next() {
+		var bet models.Bet
+		err := rows.Scan(
+			&bet.ID,
+			&bet.FixtureID,
+			&bet.PredictionID,
+			&bet.BetType,
+			&bet.Stake,
+			&bet.Odds,
+			&bet.ExpectedValue,
+			&bet.Bookmaker,
+			&bet.PlacedAt,
+			&bet.Status,
+			&bet.Payout,
+			&bet.ProfitLoss,
+			&bet.SettledAt,
+			&bet.ClosingLineValue,
+			&bet.Notes,
+			&bet.CreatedAt,
+			&bet.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan bet: %w", err)
+		}
+		bets = append(bets, bet)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return bets, nil
+}
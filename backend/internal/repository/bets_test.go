@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+)
+
+func newSettledBet() *models.Bet {
+	payout, profitLoss, settledAt := 21.0, 11.0, time.Now()
+	return &models.Bet{ID: 3, Status: "won", Payout: &payout, ProfitLoss: &profitLoss, SettledAt: &settledAt}
+}
+
+func TestBetsRepository_Settle_PendingBet_Succeeds(t *testing.T) {
+	db := newFakeDB(func(sql string, args []any) fakeResult {
+		return fakeResult{tag: "UPDATE 1"}
+	})
+	repo := &BetsRepository{db: db}
+
+	if err := repo.Settle(context.Background(), newSettledBet()); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+// Two concurrent settle calls both read the bet as pending; the second update
+// matches no rows and must be told apart from a database failure
+func TestBetsRepository_Settle_AlreadySettled_ReturnsErrBetNotPending(t *testing.T) {
+	db := newFakeDB(func(sql string, args []any) fakeResult {
+		return fakeResult{tag: "UPDATE 0"}
+	})
+	repo := &BetsRepository{db: db}
+
+	err := repo.Settle(context.Background(), newSettledBet())
+
+	if !errors.Is(err, ErrBetNotPending) {
+		t.Errorf("Expected ErrBetNotPending, got %v", err)
+	}
+}
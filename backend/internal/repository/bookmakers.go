@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+)
+
+// BookmakersRepository handles bookmaker reference list database operations
+type BookmakersRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewBookmakersRepository creates a new bookmakers repository
+func NewBookmakersRepository(db *pgxpool.Pool) *BookmakersRepository {
+	return &BookmakersRepository{db: db}
+}
+
+const bookmakerColumns = `
+	id, key, name, source, is_sharp, COALESCE(region, ''), created_at, updated_at
+`
+
+// GetAll retrieves the reference list ordered by name. A non-empty source restricts
+// it to that provider.
+func (r *BookmakersRepository) GetAll(ctx context.Context, source string) ([]models.Bookmaker, error) {
+	query := `SELECT ` + bookmakerColumns + ` FROM bookmakers
+		WHERE ($1 = '' OR source = $1)
+		ORDER BY name, source`
+
+	rows, err := r.db.Query(ctx, query, source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query bookmakers: %w", err)
+	}
+	defer rows.Close()
+
+	var bookmakers []models.Bookmaker
+	for rows.Next() {
+		bookmaker, err := scanBookmaker(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan bookmaker: %w", err)
+		}
+		bookmakers = append(bookmakers, *bookmaker)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return bookmakers, nil
+}
+
+// Upsert inserts or updates a bookmaker based on its key and source
+func (r *BookmakersRepository) Upsert(ctx context.Context, bookmaker *models.Bookmaker) error {
+	query := `
+		INSERT INTO bookmakers (key, name, source, is_sharp, region, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7)
+		ON CONFLICT (key, source)
+		DO UPDATE SET
+			name = EXCLUDED.name,
+			is_sharp = EXCLUDED.is_sharp,
+			region = EXCLUDED.region,
+			updated_at = EXCLUDED.updated_at
+		RETURNING id, created_at
+	`
+
+	now := time.Now().UTC()
+	err := r.db.QueryRow(ctx, query,
+		bookmaker.Key,
+		bookmaker.Name,
+		bookmaker.Source,
+		bookmaker.IsSharp,
+		bookmaker.Region,
+		now,
+		now,
+	).Scan(&bookmaker.ID, &bookmaker.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to upsert bookmaker: %w", err)
+	}
+
+	bookmaker.UpdatedAt = now
+
+	return nil
+}
+
+// Helper function to scan a bookmaker from a row
+func scanBookmaker(row pgx.Row) (*models.Bookmaker, error) {
+	bookmaker := &models.Bookmaker{}
+	err := row.Scan(
+		&bookmaker.ID,
+		&bookmaker.Key,
+		&bookmaker.Name,
+		&bookmaker.Source,
+		&bookmaker.IsSharp,
+		&bookmaker.Region,
+		&bookmaker.CreatedAt,
+		&bookmaker.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return bookmaker, nil
+}
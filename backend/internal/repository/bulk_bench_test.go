@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+	"github.com/dEnchanter/OddsIQ/backend/pkg/database"
+)
+
+// Benchmarks comparing the COPY-based bulk writes with the row-by-row paths. They need
+// a migrated PostgreSQL database and are skipped unless TEST_DATABASE_URL is set:
+//
+//	TEST_DATABASE_URL=postgres://localhost:5432/oddsiq_test?sslmode=disable \
+//		go test ./internal/repository -run '^$' -bench Bulk -benchtime 10x
+//
+// Rows are written under API-Football IDs from benchAPIFootballID up and removed
+// afterwards, so an existing database is left as it was.
+
+const (
+	benchAPIFootballID    = 900_000_000
+	benchSeasonFixtures   = 380 // One Premier League season
+	benchOddsPerFixture   = 40
+	benchOddsSyncFixtures = 10
+)
+
+// benchDB connects to TEST_DATABASE_URL, applying migrations, or skips the benchmark
+func benchDB(b *testing.B) *database.DB {
+	b.Helper()
+
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		b.Skip("TEST_DATABASE_URL not set")
+	}
+
+	db, err := database.New(url)
+	if err != nil {
+		b.Fatalf("failed to connect: %v", err)
+	}
+	b.Cleanup(db.Close)
+
+	if err := database.ApplyMigrations(context.Background(), db.Pool); err != nil {
+		b.Fatalf("failed to apply migrations: %v", err)
+	}
+
+	b.Cleanup(func() {
+		ctx := context.Background()
+		db.Pool.Exec(ctx, `DELETE FROM odds WHERE fixture_id IN (SELECT id FROM fixtures WHERE api_football_id >= $1)`, benchAPIFootballID)
+		db.Pool.Exec(ctx, `DELETE FROM fixtures WHERE api_football_id >= $1`, benchAPIFootballID)
+		db.Pool.Exec(ctx, `DELETE FROM teams WHERE api_football_id >= $1`, benchAPIFootballID)
+	})
+
+	return db
+}
+
+// benchFixtures creates two teams and returns a season of fixtures between them
+func benchFixtures(b *testing.B, db *database.DB) []models.Fixture {
+	b.Helper()
+	ctx := context.Background()
+
+	teams := NewTeamsRepository(db.Pool)
+	var teamIDs [2]int
+	for i := range teamIDs {
+		team := &models.Team{APIFootballID: benchAPIFootballID + i, Name: fmt.Sprintf("Bench Team %d", i)}
+		if err := teams.Create(ctx, team); err != nil {
+			b.Fatalf("failed to create team: %v", err)
+		}
+		teamIDs[i] = team.ID
+	}
+
+	kickoff := time.Date(2025, 8, 16, 14, 0, 0, 0, time.UTC)
+	fixtures := make([]models.Fixture, benchSeasonFixtures)
+	for i := range fixtures {
+		fixtures[i] = models.Fixture{
+			APIFootballID: benchAPIFootballID + i,
+			Season:        2025,
+			MatchDate:     kickoff.Add(time.Duration(i/10) * 7 * 24 * time.Hour),
+			Round:         fmt.Sprintf("Regular Season - %d", i/10+1),
+			HomeTeamID:    teamIDs[i%2],
+			AwayTeamID:    teamIDs[(i+1)%2],
+			Status:        "NS",
+		}
+	}
+	return fixtures
+}
+
+func BenchmarkFixturesUpsert_RowByRow(b *testing.B) {
+	db := benchDB(b)
+	repo := NewFixturesRepository(db.Pool)
+	fixtures := benchFixtures(b, db)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for i := range fixtures {
+			if err := repo.Upsert(ctx, &fixtures[i]); err != nil {
+				b.Fatalf("Upsert returned error: %v", err)
+			}
+		}
+	}
+	b.ReportMetric(float64(b.Elapsed().Microseconds())/float64(b.N*len(fixtures)), "µs/fixture")
+}
+
+func BenchmarkFixturesBulkUpsert(b *testing.B) {
+	db := benchDB(b)
+	repo := NewFixturesRepository(db.Pool)
+	fixtures := benchFixtures(b, db)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if _, err := repo.BulkUpsert(ctx, fixtures); err != nil {
+			b.Fatalf("BulkUpsert returned error: %v", err)
+		}
+	}
+	b.ReportMetric(float64(b.Elapsed().Microseconds())/float64(b.N*len(fixtures)), "µs/fixture")
+}
+
+// benchOdds returns a sync's worth of odds for the first fixtures, priced so that
+// each iteration moves every quote and none are skipped as unchanged
+func benchOdds(b *testing.B, db *database.DB) func(iteration int) []models.Odds {
+	b.Helper()
+
+	fixtures := benchFixtures(b, db)[:benchOddsSyncFixtures]
+	if _, err := NewFixturesRepository(db.Pool).BulkUpsert(context.Background(), fixtures); err != nil {
+		b.Fatalf("failed to create fixtures: %v", err)
+	}
+
+	return func(iteration int) []models.Odds {
+		ts := time.Now()
+		price := 1.50 + float64(iteration%2)*0.25
+		odds := make([]models.Odds, 0, len(fixtures)*benchOddsPerFixture)
+		for _, f := range fixtures {
+			for j := 0; j < benchOddsPerFixture; j++ {
+				odds = append(odds, models.Odds{
+					FixtureID:  f.ID,
+					Bookmaker:  fmt.Sprintf("bench%d", j/4),
+					Source:     models.OddsSourceOddsAPI,
+					MarketType: "totals",
+					Outcome:    []string{"Over", "Under"}[j%2],
+					Line:       0.5 + float64(j%4/2),
+					OddsValue:  price,
+					Timestamp:  ts,
+				})
+			}
+		}
+		return odds
+	}
+}
+
+func BenchmarkOddsCreateBatch_RowByRow(b *testing.B) {
+	db := benchDB(b)
+	repo := NewOddsRepository(db.Pool)
+	nextOdds := benchOdds(b, db)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		odds := nextOdds(n)
+		if _, _, err := repo.CreateBatch(ctx, odds); err != nil {
+			b.Fatalf("CreateBatch returned error: %v", err)
+		}
+	}
+	b.ReportMetric(float64(b.Elapsed().Microseconds())/float64(b.N*benchOddsSyncFixtures*benchOddsPerFixture), "µs/row")
+}
+
+func BenchmarkOddsBulkInsert(b *testing.B) {
+	db := benchDB(b)
+	repo := NewOddsRepository(db.Pool)
+	nextOdds := benchOdds(b, db)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		odds := nextOdds(n)
+		if _, _, err := repo.BulkInsert(ctx, odds); err != nil {
+			b.Fatalf("BulkInsert returned error: %v", err)
+		}
+	}
+	b.ReportMetric(float64(b.Elapsed().Microseconds())/float64(b.N*benchOddsSyncFixtures*benchOddsPerFixture), "µs/row")
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// fakeResult is what fakeDB returns for one statement
+type fakeResult struct {
+	rows [][]any // Rows for Query/QueryRow, each scanned in column order
+	tag  string  // Command tag for Exec, e.g. "DELETE 1"
+	err  error
+}
+
+// fakeDB is an in-memory dbtx that records every statement and answers with respond
+type fakeDB struct {
+	mu         sync.Mutex
+	statements []string
+	respond    func(sql string, args []any) fakeResult
+}
+
+func newFakeDB(respond func(sql string, args []any) fakeResult) *fakeDB {
+	return &fakeDB{respond: respond}
+}
+
+// queryCount returns how many statements have been run
+func (db *fakeDB) queryCount() int {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return len(db.statements)
+}
+
+func (db *fakeDB) run(sql string, args []any) fakeResult {
+	db.mu.Lock()
+	db.statements = append(db.statements, sql)
+	db.mu.Unlock()
+
+	if db.respond == nil {
+		return fakeResult{}
+	}
+	return db.respond(sql, args)
+}
+
+func (db *fakeDB) Begin(ctx context.Context) (pgx.Tx, error) {
+	return &fakeTx{db: db}, nil
+}
+
+func (db *fakeDB) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	res := db.run(sql, args)
+	return pgconn.NewCommandTag(res.tag), res.err
+}
+
+func (db *fakeDB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	res := db.run(sql, args)
+	if res.err != nil {
+		return nil, res.err
+	}
+	return &fakeRows{rows: res.rows, pos: -1}, nil
+}
+
+func (db *fakeDB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	res := db.run(sql, args)
+	return &fakeRow{rows: res.rows, err: res.err}
+}
+
+// fakeTx runs statements against its fakeDB; Commit and Rollback are no-ops
+type fakeTx struct {
+	pgx.Tx
+	db *fakeDB
+}
+
+func (tx *fakeTx) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return tx.db.Exec(ctx, sql, args...)
+}
+
+func (tx *fakeTx) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return tx.db.Query(ctx, sql, args...)
+}
+
+func (tx *fakeTx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return tx.db.QueryRow(ctx, sql, args...)
+}
+
+func (tx *fakeTx) Commit(ctx context.Context) error   { return nil }
+func (tx *fakeTx) Rollback(ctx context.Context) error { return nil }
+
+type fakeRows struct {
+	pgx.Rows
+	rows [][]any
+	pos  int
+}
+
+func (r *fakeRows) Next() bool {
+	r.pos++
+	return r.pos < len(r.rows)
+}
+
+func (r *fakeRows) Scan(dest ...any) error { return scanFakeRow(r.rows[r.pos], dest) }
+func (r *fakeRows) Err() error             { return nil }
+func (r *fakeRows) Close()                 {}
+
+type fakeRow struct {
+	rows [][]any
+	err  error
+}
+
+func (r *fakeRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	if len(r.rows) == 0 {
+		return pgx.ErrNoRows
+	}
+	return scanFakeRow(r.rows[0], dest)
+}
+
+// scanFakeRow assigns values to dest pointers, allocating for pointer fields
+// and leaving nil values as the zero value
+func scanFakeRow(values []any, dest []any) error {
+	if len(values) != len(dest) {
+		return fmt.Errorf("fake row has %d columns, scanned into %d", len(values), len(dest))
+	}
+
+	for i, value := range values {
+		target := reflect.ValueOf(dest[i]).Elem()
+		if value == nil {
+			target.Set(reflect.Zero(target.Type()))
+			continue
+		}
+
+		v := reflect.ValueOf(value)
+		switch {
+		case v.Type().AssignableTo(target.Type()):
+			target.Set(v)
+		case target.Kind() == reflect.Pointer && v.Type().AssignableTo(target.Type().Elem()):
+			ptr := reflect.New(target.Type().Elem())
+			ptr.Elem().Set(v)
+			target.Set(ptr)
+		case v.Type().ConvertibleTo(target.Type()):
+			target.Set(v.Convert(target.Type()))
+		default:
+			return fmt.Errorf("column %d: cannot scan %T into %s", i, value, target.Type())
+		}
+	}
+	return nil
+}
@@ -2,7 +2,9 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -10,9 +12,13 @@ import (
 	"github.com/dEnchanter/OddsIQ/backend/internal/models"
 )
 
+// ErrFixtureNotFound is returned when no fixture exists with the requested ID
+var ErrFixtureNotFound = errors.New("fixture not found")
+
 // FixturesRepository handles fixture database operations
 type FixturesRepository struct {
-	db *pgxpool.Pool
+	db       dbtx
+	oddsRepo *OddsRepository // Its odds cache is invalidated when fixtures are purged (optional)
 }
 
 // NewFixturesRepository creates a new fixtures repository
@@ -20,22 +26,28 @@ func NewFixturesRepository(db *pgxpool.Pool) *FixturesRepository {
 	return &FixturesRepository{db: db}
 }
 
+// InvalidateOddsOf makes HardDelete drop purged fixtures from oddsRepo's cache,
+// so their deleted odds aren't served until the entries expire
+func (r *FixturesRepository) InvalidateOddsOf(oddsRepo *OddsRepository) {
+	r.oddsRepo = oddsRepo
+}
+
 // Create inserts a new fixture
 func (r *FixturesRepository) Create(ctx context.Context, fixture *models.Fixture) error {
 	query := `
 		INSERT INTO fixtures (
 			api_football_id, season, match_date, round, home_team_id, away_team_id,
-			status, home_score, away_score, venue_name, referee, created_at, updated_at
+			status, home_score, away_score, venue_name, referee, created_at, updated_at, league_id
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 		RETURNING id
 	`
 
-	now := time.Now()
+	now := time.Now().UTC()
 	err := r.db.QueryRow(ctx, query,
 		fixture.APIFootballID,
 		fixture.Season,
-		fixture.MatchDate,
+		fixture.MatchDate.UTC(),
 		fixture.Round,
 		fixture.HomeTeamID,
 		fixture.AwayTeamID,
@@ -46,6 +58,7 @@ func (r *FixturesRepository) Create(ctx context.Context, fixture *models.Fixture
 		fixture.Referee,
 		now,
 		now,
+		fixture.LeagueID,
 	).Scan(&fixture.ID)
 
 	if err != nil {
@@ -62,9 +75,9 @@ func (r *FixturesRepository) Create(ctx context.Context, fixture *models.Fixture
 func (r *FixturesRepository) GetByID(ctx context.Context, id int) (*models.Fixture, error) {
 	query := `
 		SELECT id, api_football_id, season, match_date, round, home_team_id, away_team_id,
-			status, home_score, away_score, venue_name, referee, created_at, updated_at
+			status, home_score, away_score, venue_name, referee, created_at, updated_at, league_id, deleted_at
 		FROM fixtures
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 
 	fixture := &models.Fixture{}
@@ -83,10 +96,12 @@ func (r *FixturesRepository) GetByID(ctx context.Context, id int) (*models.Fixtu
 		&fixture.Referee,
 		&fixture.CreatedAt,
 		&fixture.UpdatedAt,
+		&fixture.LeagueID,
+		&fixture.DeletedAt,
 	)
 
 	if err == pgx.ErrNoRows {
-		return nil, fmt.Errorf("fixture not found with id %d", id)
+		return nil, fmt.Errorf("%w with id %d", ErrFixtureNotFound, id)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get fixture: %w", err)
@@ -99,9 +114,9 @@ func (r *FixturesRepository) GetByID(ctx context.Context, id int) (*models.Fixtu
 func (r *FixturesRepository) GetByAPIFootballID(ctx context.Context, apiFootballID int) (*models.Fixture, error) {
 	query := `
 		SELECT id, api_football_id, season, match_date, round, home_team_id, away_team_id,
-			status, home_score, away_score, venue_name, referee, created_at, updated_at
+			status, home_score, away_score, venue_name, referee, created_at, updated_at, league_id, deleted_at
 		FROM fixtures
-		WHERE api_football_id = $1
+		WHERE api_football_id = $1 AND deleted_at IS NULL
 	`
 
 	fixture := &models.Fixture{}
@@ -120,6 +135,8 @@ func (r *FixturesRepository) GetByAPIFootballID(ctx context.Context, apiFootball
 		&fixture.Referee,
 		&fixture.CreatedAt,
 		&fixture.UpdatedAt,
+		&fixture.LeagueID,
+		&fixture.DeletedAt,
 	)
 
 	if err == pgx.ErrNoRows {
@@ -132,17 +149,18 @@ func (r *FixturesRepository) GetByAPIFootballID(ctx context.Context, apiFootball
 	return fixture, nil
 }
 
-// GetBySeason retrieves all fixtures for a specific season
-func (r *FixturesRepository) GetBySeason(ctx context.Context, season int) ([]models.Fixture, error) {
+// GetBySeason retrieves all fixtures for a specific season.
+// leagueID is the internal league ID; pass 0 to include all leagues.
+func (r *FixturesRepository) GetBySeason(ctx context.Context, season, leagueID int) ([]models.Fixture, error) {
 	query := `
 		SELECT id, api_football_id, season, match_date, round, home_team_id, away_team_id,
-			status, home_score, away_score, venue_name, referee, created_at, updated_at
+			status, home_score, away_score, venue_name, referee, created_at, updated_at, league_id, deleted_at
 		FROM fixtures
-		WHERE season = $1
+		WHERE season = $1 AND ($2::int = 0 OR league_id = $2) AND deleted_at IS NULL
 		ORDER BY match_date
 	`
 
-	rows, err := r.db.Query(ctx, query, season)
+	rows, err := r.db.Query(ctx, query, season, leagueID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query fixtures: %w", err)
 	}
@@ -155,13 +173,13 @@ func (r *FixturesRepository) GetBySeason(ctx context.Context, season int) ([]mod
 func (r *FixturesRepository) GetByDateRange(ctx context.Context, from, to time.Time) ([]models.Fixture, error) {
 	query := `
 		SELECT id, api_football_id, season, match_date, round, home_team_id, away_team_id,
-			status, home_score, away_score, venue_name, referee, created_at, updated_at
+			status, home_score, away_score, venue_name, referee, created_at, updated_at, league_id, deleted_at
 		FROM fixtures
-		WHERE match_date >= $1 AND match_date <= $2
+		WHERE match_date >= $1 AND match_date <= $2 AND deleted_at IS NULL
 		ORDER BY match_date
 	`
 
-	rows, err := r.db.Query(ctx, query, from, to)
+	rows, err := r.db.Query(ctx, query, from.UTC(), to.UTC())
 	if err != nil {
 		return nil, fmt.Errorf("failed to query fixtures: %w", err)
 	}
@@ -170,18 +188,137 @@ func (r *FixturesRepository) GetByDateRange(ctx context.Context, from, to time.T
 	return r.scanFixtures(rows)
 }
 
-// GetUpcoming retrieves upcoming fixtures (not yet played)
-func (r *FixturesRepository) GetUpcoming(ctx context.Context, limit int) ([]models.Fixture, error) {
+// GetLastUpdatedAt returns when any fixture was last written, or nil if there are none
+func (r *FixturesRepository) GetLastUpdatedAt(ctx context.Context) (*time.Time, error) {
+	var updatedAt *time.Time
+	if err := r.db.QueryRow(ctx, `SELECT MAX(updated_at) FROM fixtures`).Scan(&updatedAt); err != nil {
+		return nil, fmt.Errorf("failed to get last fixture update: %w", err)
+	}
+	return updatedAt, nil
+}
+
+// Fixture list sort orders
+const (
+	FixtureSortDate   = "date"
+	FixtureSortStatus = "status"
+)
+
+// FixtureFilter holds optional filters, ordering and paging for listing fixtures
+type FixtureFilter struct {
+	Season     int
+	LeagueID   int    // Internal league ID
+	Status     string
+	Upcoming   bool   // Only fixtures not yet played
+	From       time.Time
+	To         time.Time
+	Sort       string // FixtureSortDate (default) or FixtureSortStatus
+	Descending bool
+	Limit      int
+	Offset     int
+}
+
+// List retrieves fixtures matching the filter, along with the total count before pagination
+func (r *FixturesRepository) List(ctx context.Context, filter FixtureFilter) ([]models.Fixture, int, error) {
+	conditions := []string{"deleted_at IS NULL"}
+	var args []interface{}
+
+	if filter.Season > 0 {
+		args = append(args, filter.Season)
+		conditions = append(conditions, fmt.Sprintf("season = $%d", len(args)))
+	}
+	if filter.LeagueID > 0 {
+		args = append(args, filter.LeagueID)
+		conditions = append(conditions, fmt.Sprintf("league_id = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.Upcoming {
+		conditions = append(conditions, "status = 'NS' AND match_date > NOW()")
+	}
+	if !filter.From.IsZero() {
+		args = append(args, filter.From.UTC())
+		conditions = append(conditions, fmt.Sprintf("match_date >= $%d", len(args)))
+	}
+	if !filter.To.IsZero() {
+		args = append(args, filter.To.UTC())
+		conditions = append(conditions, fmt.Sprintf("match_date <= $%d", len(args)))
+	}
+
+	where := "WHERE " + strings.Join(conditions, " AND ")
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM fixtures ` + where
+	if err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count fixtures: %w", err)
+	}
+
+	direction := "ASC"
+	if filter.Descending {
+		direction = "DESC"
+	}
+	orderBy := fmt.Sprintf("match_date %s, id %s", direction, direction)
+	if filter.Sort == FixtureSortStatus {
+		orderBy = fmt.Sprintf("status %s, match_date %s, id %s", direction, direction, direction)
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := fmt.Sprintf(`
+		SELECT id, api_football_id, season, match_date, round, home_team_id, away_team_id,
+			status, home_score, away_score, venue_name, referee, created_at, updated_at, league_id, deleted_at
+		FROM fixtures %s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d
+	`, where, orderBy, len(args)-1, len(args))
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query fixtures: %w", err)
+	}
+	defer rows.Close()
+
+	fixtures, err := r.scanFixtures(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return fixtures, total, nil
+}
+
+// GetUpcoming retrieves upcoming fixtures (not yet played).
+// leagueID is the internal league ID; pass 0 to include all leagues.
+func (r *FixturesRepository) GetUpcoming(ctx context.Context, limit, leagueID int) ([]models.Fixture, error) {
 	query := `
 		SELECT id, api_football_id, season, match_date, round, home_team_id, away_team_id,
-			status, home_score, away_score, venue_name, referee, created_at, updated_at
+			status, home_score, away_score, venue_name, referee, created_at, updated_at, league_id, deleted_at
 		FROM fixtures
-		WHERE status = 'NS' AND match_date > NOW()
+		WHERE status = 'NS' AND match_date > NOW() AND ($2::int = 0 OR league_id = $2) AND deleted_at IS NULL
 		ORDER BY match_date
 		LIMIT $1
 	`
 
-	rows, err := r.db.Query(ctx, query, limit)
+	rows, err := r.db.Query(ctx, query, limit, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query upcoming fixtures: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanFixtures(rows)
+}
+
+// GetUpcomingInWindow retrieves fixtures not yet played that kick off between from and to
+func (r *FixturesRepository) GetUpcomingInWindow(ctx context.Context, from, to time.Time) ([]models.Fixture, error) {
+	query := `
+		SELECT id, api_football_id, season, match_date, round, home_team_id, away_team_id,
+			status, home_score, away_score, venue_name, referee, created_at, updated_at, league_id, deleted_at
+		FROM fixtures
+		WHERE status = 'NS' AND match_date > NOW() AND match_date >= $1 AND match_date <= $2
+			AND deleted_at IS NULL
+		ORDER BY match_date
+	`
+
+	rows, err := r.db.Query(ctx, query, from.UTC(), to.UTC())
 	if err != nil {
 		return nil, fmt.Errorf("failed to query upcoming fixtures: %w", err)
 	}
@@ -194,9 +331,9 @@ func (r *FixturesRepository) GetUpcoming(ctx context.Context, limit int) ([]mode
 func (r *FixturesRepository) GetByStatus(ctx context.Context, status string) ([]models.Fixture, error) {
 	query := `
 		SELECT id, api_football_id, season, match_date, round, home_team_id, away_team_id,
-			status, home_score, away_score, venue_name, referee, created_at, updated_at
+			status, home_score, away_score, venue_name, referee, created_at, updated_at, league_id, deleted_at
 		FROM fixtures
-		WHERE status = $1
+		WHERE status = $1 AND deleted_at IS NULL
 		ORDER BY match_date DESC
 	`
 
@@ -209,13 +346,32 @@ func (r *FixturesRepository) GetByStatus(ctx context.Context, status string) ([]
 	return r.scanFixtures(rows)
 }
 
+// GetByStatuses retrieves fixtures in any of the given statuses, earliest kickoff first
+func (r *FixturesRepository) GetByStatuses(ctx context.Context, statuses []string) ([]models.Fixture, error) {
+	query := `
+		SELECT id, api_football_id, season, match_date, round, home_team_id, away_team_id,
+			status, home_score, away_score, venue_name, referee, created_at, updated_at, league_id, deleted_at
+		FROM fixtures
+		WHERE status = ANY($1) AND deleted_at IS NULL
+		ORDER BY match_date
+	`
+
+	rows, err := r.db.Query(ctx, query, statuses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query fixtures by statuses: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanFixtures(rows)
+}
+
 // GetByTeam retrieves all fixtures for a specific team
 func (r *FixturesRepository) GetByTeam(ctx context.Context, teamID int) ([]models.Fixture, error) {
 	query := `
 		SELECT id, api_football_id, season, match_date, round, home_team_id, away_team_id,
-			status, home_score, away_score, venue_name, referee, created_at, updated_at
+			status, home_score, away_score, venue_name, referee, created_at, updated_at, league_id, deleted_at
 		FROM fixtures
-		WHERE home_team_id = $1 OR away_team_id = $1
+		WHERE (home_team_id = $1 OR away_team_id = $1) AND deleted_at IS NULL
 		ORDER BY match_date DESC
 	`
 
@@ -228,13 +384,14 @@ func (r *FixturesRepository) GetByTeam(ctx context.Context, teamID int) ([]model
 	return r.scanFixtures(rows)
 }
 
-// GetRecentByTeam retrieves recent fixtures for a team
+// GetRecentByTeam retrieves a team's most recent completed fixtures with scores, newest first
 func (r *FixturesRepository) GetRecentByTeam(ctx context.Context, teamID int, limit int) ([]models.Fixture, error) {
 	query := `
 		SELECT id, api_football_id, season, match_date, round, home_team_id, away_team_id,
-			status, home_score, away_score, venue_name, referee, created_at, updated_at
+			status, home_score, away_score, venue_name, referee, created_at, updated_at, league_id, deleted_at
 		FROM fixtures
-		WHERE (home_team_id = $1 OR away_team_id = $1) AND status = 'FT'
+		WHERE (home_team_id = $1 OR away_team_id = $1) AND status IN ('FT', 'AET', 'PEN')
+			AND home_score IS NOT NULL AND away_score IS NOT NULL AND deleted_at IS NULL
 		ORDER BY match_date DESC
 		LIMIT $2
 	`
@@ -248,19 +405,41 @@ func (r *FixturesRepository) GetRecentByTeam(ctx context.Context, teamID int, li
 	return r.scanFixtures(rows)
 }
 
+// GetCompletedByTeamAndSeason retrieves finished fixtures for a team in a league and
+// season, oldest first. leagueID is the internal league ID.
+func (r *FixturesRepository) GetCompletedByTeamAndSeason(ctx context.Context, teamID, leagueID, season int) ([]models.Fixture, error) {
+	query := `
+		SELECT id, api_football_id, season, match_date, round, home_team_id, away_team_id,
+			status, home_score, away_score, venue_name, referee, created_at, updated_at, league_id, deleted_at
+		FROM fixtures
+		WHERE (home_team_id = $1 OR away_team_id = $1) AND season = $2 AND league_id = $3
+			AND status IN ('FT', 'AET', 'PEN') AND deleted_at IS NULL
+		ORDER BY match_date
+	`
+
+	rows, err := r.db.Query(ctx, query, teamID, season, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query completed fixtures: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanFixtures(rows)
+}
+
 // Update updates an existing fixture
 func (r *FixturesRepository) Update(ctx context.Context, fixture *models.Fixture) error {
 	query := `
 		UPDATE fixtures
 		SET season = $1, match_date = $2, round = $3, home_team_id = $4, away_team_id = $5,
-			status = $6, home_score = $7, away_score = $8, venue_name = $9, referee = $10, updated_at = $11
-		WHERE id = $12
+			status = $6, home_score = $7, away_score = $8, venue_name = $9, referee = $10, updated_at = $11,
+			league_id = $12
+		WHERE id = $13
 	`
 
-	now := time.Now()
+	now := time.Now().UTC()
 	result, err := r.db.Exec(ctx, query,
 		fixture.Season,
-		fixture.MatchDate,
+		fixture.MatchDate.UTC(),
 		fixture.Round,
 		fixture.HomeTeamID,
 		fixture.AwayTeamID,
@@ -270,6 +449,7 @@ func (r *FixturesRepository) Update(ctx context.Context, fixture *models.Fixture
 		fixture.VenueName,
 		fixture.Referee,
 		now,
+		fixture.LeagueID,
 		fixture.ID,
 	)
 
@@ -278,7 +458,7 @@ func (r *FixturesRepository) Update(ctx context.Context, fixture *models.Fixture
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("fixture not found with id %d", fixture.ID)
+		return fmt.Errorf("%w with id %d", ErrFixtureNotFound, fixture.ID)
 	}
 
 	fixture.UpdatedAt = now
@@ -294,13 +474,13 @@ func (r *FixturesRepository) UpdateScore(ctx context.Context, id int, homeScore,
 		WHERE id = $5
 	`
 
-	result, err := r.db.Exec(ctx, query, homeScore, awayScore, status, time.Now(), id)
+	result, err := r.db.Exec(ctx, query, homeScore, awayScore, status, time.Now().UTC(), id)
 	if err != nil {
 		return fmt.Errorf("failed to update fixture score: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("fixture not found with id %d", id)
+		return fmt.Errorf("%w with id %d", ErrFixtureNotFound, id)
 	}
 
 	return nil
@@ -311,9 +491,9 @@ func (r *FixturesRepository) Upsert(ctx context.Context, fixture *models.Fixture
 	query := `
 		INSERT INTO fixtures (
 			api_football_id, season, match_date, round, home_team_id, away_team_id,
-			status, home_score, away_score, venue_name, referee, created_at, updated_at
+			status, home_score, away_score, venue_name, referee, created_at, updated_at, league_id
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 		ON CONFLICT (api_football_id)
 		DO UPDATE SET
 			season = EXCLUDED.season,
@@ -326,15 +506,16 @@ func (r *FixturesRepository) Upsert(ctx context.Context, fixture *models.Fixture
 			away_score = EXCLUDED.away_score,
 			venue_name = EXCLUDED.venue_name,
 			referee = EXCLUDED.referee,
-			updated_at = EXCLUDED.updated_at
+			updated_at = EXCLUDED.updated_at,
+			league_id = COALESCE(EXCLUDED.league_id, fixtures.league_id)
 		RETURNING id
 	`
 
-	now := time.Now()
+	now := time.Now().UTC()
 	err := r.db.QueryRow(ctx, query,
 		fixture.APIFootballID,
 		fixture.Season,
-		fixture.MatchDate,
+		fixture.MatchDate.UTC(),
 		fixture.Round,
 		fixture.HomeTeamID,
 		fixture.AwayTeamID,
@@ -345,6 +526,7 @@ func (r *FixturesRepository) Upsert(ctx context.Context, fixture *models.Fixture
 		fixture.Referee,
 		now,
 		now,
+		fixture.LeagueID,
 	).Scan(&fixture.ID)
 
 	if err != nil {
@@ -356,22 +538,447 @@ func (r *FixturesRepository) Upsert(ctx context.Context, fixture *models.Fixture
 	return nil
 }
 
-// Delete deletes a fixture
+// BulkUpsert inserts or updates many fixtures by API-Football ID in one transaction,
+// setting their IDs. Rows are streamed into a staging table with COPY and merged with a
+// single statement, rather than a round trip per fixture as with Upsert, so it suits
+// backfills. Later duplicates of an API-Football ID in the batch are ignored.
+func (r *FixturesRepository) BulkUpsert(ctx context.Context, fixtures []models.Fixture) (int, error) {
+	if len(fixtures) == 0 {
+		return 0, nil
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		CREATE TEMP TABLE fixtures_staging ON COMMIT DROP AS
+		SELECT api_football_id, season, match_date, round, home_team_id, away_team_id,
+			status, home_score, away_score, venue_name, referee, league_id
+		FROM fixtures WITH NO DATA
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create fixtures staging table: %w", err)
+	}
+
+	columns := []string{
+		"api_football_id", "season", "match_date", "round", "home_team_id", "away_team_id",
+		"status", "home_score", "away_score", "venue_name", "referee", "league_id",
+	}
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"fixtures_staging"}, columns,
+		pgx.CopyFromSlice(len(fixtures), func(i int) ([]any, error) {
+			f := &fixtures[i]
+			return []any{
+				f.APIFootballID, f.Season, f.MatchDate.UTC(), f.Round, f.HomeTeamID, f.AwayTeamID,
+				f.Status, f.HomeScore, f.AwayScore, f.VenueName, f.Referee, f.LeagueID,
+			}, nil
+		}),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to copy fixtures: %w", err)
+	}
+
+	query := `
+		INSERT INTO fixtures (
+			api_football_id, season, match_date, round, home_team_id, away_team_id,
+			status, home_score, away_score, venue_name, referee, created_at, updated_at, league_id
+		)
+		SELECT DISTINCT ON (api_football_id)
+			api_football_id, season, match_date, round, home_team_id, away_team_id,
+			status, home_score, away_score, venue_name, referee, $1, $1, league_id
+		FROM fixtures_staging
+		ORDER BY api_football_id
+		ON CONFLICT (api_football_id)
+		DO UPDATE SET
+			season = EXCLUDED.season,
+			match_date = EXCLUDED.match_date,
+			round = EXCLUDED.round,
+			home_team_id = EXCLUDED.home_team_id,
+			away_team_id = EXCLUDED.away_team_id,
+			status = EXCLUDED.status,
+			home_score = EXCLUDED.home_score,
+			away_score = EXCLUDED.away_score,
+			venue_name = EXCLUDED.venue_name,
+			referee = EXCLUDED.referee,
+			updated_at = EXCLUDED.updated_at,
+			league_id = COALESCE(EXCLUDED.league_id, fixtures.league_id)
+		RETURNING api_football_id, id
+	`
+
+	now := time.Now().UTC()
+	rows, err := tx.Query(ctx, query, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to upsert fixtures: %w", err)
+	}
+
+	ids := make(map[int]int, len(fixtures))
+	for rows.Next() {
+		var apiFootballID, id int
+		if err := rows.Scan(&apiFootballID, &id); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan fixture id: %w", err)
+		}
+		ids[apiFootballID] = id
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to upsert fixtures: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	for i := range fixtures {
+		if id, ok := ids[fixtures[i].APIFootballID]; ok {
+			fixtures[i].ID = id
+			fixtures[i].UpdatedAt = now
+		}
+	}
+
+	return len(ids), nil
+}
+
+// BlockingBet is a settled bet that prevents its fixture from being deleted
+type BlockingBet struct {
+	ID        int        `json:"id"`
+	BetType   string     `json:"bet_type"`
+	Status    string     `json:"status"`
+	Stake     float64    `json:"stake"`
+	SettledAt *time.Time `json:"settled_at"`
+}
+
+// SettledBetsError is returned when deleting a fixture that has settled bets, which
+// would drop them from the bankroll and performance history
+type SettledBetsError struct {
+	FixtureID int
+	Bets      []BlockingBet
+}
+
+func (e *SettledBetsError) Error() string {
+	ids := make([]string, len(e.Bets))
+	for i, bet := range e.Bets {
+		ids[i] = fmt.Sprintf("#%d (%s)", bet.ID, bet.Status)
+	}
+	return fmt.Sprintf("fixture %d has %d settled bets: %s", e.FixtureID, len(e.Bets), strings.Join(ids, ", "))
+}
+
+// FixturePurge counts the rows removed along with a hard-deleted fixture
+type FixturePurge struct {
+	Odds        int64 `json:"odds"`
+	PendingBets int64 `json:"pending_bets"`
+}
+
+// lockForDelete locks a fixture and its bets for the rest of tx, so a bet can't be
+// settled while the fixture is being deleted. It returns a *SettledBetsError if any
+// bet is settled. found is false if no fixture matching deleted has the ID.
+func lockForDelete(ctx context.Context, tx pgx.Tx, id int, deleted bool) (found bool, err error) {
+	query := `SELECT id FROM fixtures WHERE id = $1 AND (deleted_at IS NOT NULL) = $2 FOR UPDATE`
+	if err := tx.QueryRow(ctx, query, id, deleted).Scan(&id); err == pgx.ErrNoRows {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to lock fixture: %w", err)
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT id, bet_type, status, stake, settled_at
+		FROM bets
+		WHERE fixture_id = $1
+		ORDER BY id
+		FOR UPDATE
+	`, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to lock bets: %w", err)
+	}
+	defer rows.Close()
+
+	var settled []BlockingBet
+	for rows.Next() {
+		var bet BlockingBet
+		if err := rows.Scan(&bet.ID, &bet.BetType, &bet.Status, &bet.Stake, &bet.SettledAt); err != nil {
+			return false, fmt.Errorf("failed to scan bet: %w", err)
+		}
+		if bet.Status != "pending" {
+			settled = append(settled, bet)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return false, fmt.Errorf("rows error: %w", err)
+	}
+
+	if len(settled) > 0 {
+		return true, &SettledBetsError{FixtureID: id, Bets: settled}
+	}
+	return true, nil
+}
+
+// Delete soft-deletes a fixture: it and its odds are hidden from reads but kept, with
+// any pending bets, so the fixture can be brought back with Restore. Returns a
+// *SettledBetsError, deleting nothing, if the fixture has settled bets, and
+// ErrFixtureNotFound if no live fixture has the ID.
 func (r *FixturesRepository) Delete(ctx context.Context, id int) error {
-	query := `DELETE FROM fixtures WHERE id = $1`
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
 
-	result, err := r.db.Exec(ctx, query, id)
+	found, err := lockForDelete(ctx, tx, id, false)
 	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("%w with id %d", ErrFixtureNotFound, id)
+	}
+
+	query := `UPDATE fixtures SET deleted_at = $2, updated_at = $2 WHERE id = $1`
+	if _, err := tx.Exec(ctx, query, id, time.Now().UTC()); err != nil {
 		return fmt.Errorf("failed to delete fixture: %w", err)
 	}
 
-	if result.RowsAffected() == 0 {
-		return fmt.Errorf("fixture not found with id %d", id)
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	return nil
 }
 
+// Restore undoes a soft delete. found is false if no deleted fixture has the ID.
+func (r *FixturesRepository) Restore(ctx context.Context, id int) (bool, error) {
+	query := `UPDATE fixtures SET deleted_at = NULL, updated_at = $2 WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	result, err := r.db.Exec(ctx, query, id, time.Now().UTC())
+	if err != nil {
+		return false, fmt.Errorf("failed to restore fixture: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// HardDelete permanently deletes a soft-deleted fixture in one transaction, removing its
+// odds and pending bets first (predictions, watchlist entries and value alerts cascade).
+// Fixtures must be soft-deleted first, so a live fixture can't be purged by mistake.
+// Returns a *SettledBetsError, deleting nothing, if the fixture has settled bets, and
+// ErrFixtureNotFound if no deleted fixture has the ID.
+func (r *FixturesRepository) HardDelete(ctx context.Context, id int) (*FixturePurge, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	found, err := lockForDelete(ctx, tx, id, true)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("%w among deleted fixtures with id %d", ErrFixtureNotFound, id)
+	}
+
+	purge := &FixturePurge{}
+
+	result, err := tx.Exec(ctx, `DELETE FROM odds WHERE fixture_id = $1`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete odds: %w", err)
+	}
+	purge.Odds = result.RowsAffected()
+
+	// Only pending bets remain, which don't count towards the bankroll
+	result, err = tx.Exec(ctx, `DELETE FROM bets WHERE fixture_id = $1`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete bets: %w", err)
+	}
+	purge.PendingBets = result.RowsAffected()
+
+	if _, err := tx.Exec(ctx, `DELETE FROM fixtures WHERE id = $1`, id); err != nil {
+		return nil, fmt.Errorf("failed to hard delete fixture: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if r.oddsRepo != nil {
+		r.oddsRepo.cache.invalidate(id)
+	}
+
+	return purge, nil
+}
+
+// ListDeleted retrieves soft-deleted fixtures with their teams, most recently deleted
+// first, along with the total count before pagination
+func (r *FixturesRepository) ListDeleted(ctx context.Context, limit, offset int) ([]models.Fixture, int, error) {
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM fixtures WHERE deleted_at IS NOT NULL`).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count deleted fixtures: %w", err)
+	}
+
+	query := fixtureWithTeamsQuery + `
+		WHERE f.deleted_at IS NOT NULL
+		ORDER BY f.deleted_at DESC, f.id DESC
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query deleted fixtures: %w", err)
+	}
+	defer rows.Close()
+
+	var fixtures []models.Fixture
+	for rows.Next() {
+		fixture, err := scanFixtureWithTeams(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan fixture: %w", err)
+		}
+		fixtures = append(fixtures, *fixture)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("rows error: %w", err)
+	}
+
+	return fixtures, total, nil
+}
+
+// fixtureWithTeamsQuery selects fixtures with their home and away teams joined in
+const fixtureWithTeamsQuery = `
+	SELECT f.id, f.api_football_id, f.season, f.match_date, f.round, f.home_team_id, f.away_team_id,
+		f.status, f.home_score, f.away_score, f.venue_name, f.referee, f.created_at, f.updated_at, f.league_id, f.deleted_at,
+		ht.id, ht.api_football_id, ht.name, COALESCE(ht.code, ''), COALESCE(ht.logo_url, ''), COALESCE(ht.founded, 0),
+		COALESCE(ht.venue_name, ''), COALESCE(ht.venue_city, ''), COALESCE(ht.venue_capacity, 0), ht.created_at, ht.updated_at,
+		at.id, at.api_football_id, at.name, COALESCE(at.code, ''), COALESCE(at.logo_url, ''), COALESCE(at.founded, 0),
+		COALESCE(at.venue_name, ''), COALESCE(at.venue_city, ''), COALESCE(at.venue_capacity, 0), at.created_at, at.updated_at
+	FROM fixtures f
+	LEFT JOIN teams ht ON ht.id = f.home_team_id
+	LEFT JOIN teams at ON at.id = f.away_team_id
+`
+
+// GetByIDWithTeams retrieves a fixture by ID with HomeTeam and AwayTeam populated
+func (r *FixturesRepository) GetByIDWithTeams(ctx context.Context, id int) (*models.Fixture, error) {
+	query := fixtureWithTeamsQuery + ` WHERE f.id = $1 AND f.deleted_at IS NULL`
+
+	fixture, err := scanFixtureWithTeams(r.db.QueryRow(ctx, query, id))
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("%w with id %d", ErrFixtureNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fixture: %w", err)
+	}
+
+	return fixture, nil
+}
+
+// GetUpcomingWithTeams retrieves upcoming fixtures with HomeTeam and AwayTeam populated.
+// leagueID is the internal league ID; pass 0 to include all leagues.
+func (r *FixturesRepository) GetUpcomingWithTeams(ctx context.Context, limit, leagueID int) ([]models.Fixture, error) {
+	query := fixtureWithTeamsQuery + `
+		WHERE f.status = 'NS' AND f.match_date > NOW() AND ($2::int = 0 OR f.league_id = $2) AND f.deleted_at IS NULL
+		ORDER BY f.match_date
+		LIMIT $1
+	`
+
+	rows, err := r.db.Query(ctx, query, limit, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query upcoming fixtures: %w", err)
+	}
+	defer rows.Close()
+
+	var fixtures []models.Fixture
+	for rows.Next() {
+		fixture, err := scanFixtureWithTeams(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan fixture: %w", err)
+		}
+		fixtures = append(fixtures, *fixture)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return fixtures, nil
+}
+
+// joinedTeam holds the columns of a LEFT JOINed team, whose key columns are NULL when missing
+type joinedTeam struct {
+	id            *int
+	apiFootballID *int
+	name          *string
+	team          models.Team
+	createdAt     *time.Time
+	updatedAt     *time.Time
+}
+
+// dest returns scan destinations in fixtureWithTeamsQuery column order
+func (j *joinedTeam) dest() []interface{} {
+	return []interface{}{
+		&j.id, &j.apiFootballID, &j.name, &j.team.Code, &j.team.LogoURL, &j.team.Founded,
+		&j.team.VenueName, &j.team.VenueCity, &j.team.VenueCapacity, &j.createdAt, &j.updatedAt,
+	}
+}
+
+// result returns the team, or nil if the join found no row
+func (j *joinedTeam) result() *models.Team {
+	if j.id == nil {
+		return nil
+	}
+
+	team := j.team
+	team.ID = *j.id
+	if j.apiFootballID != nil {
+		team.APIFootballID = *j.apiFootballID
+	}
+	if j.name != nil {
+		team.Name = *j.name
+	}
+	if j.createdAt != nil {
+		team.CreatedAt = *j.createdAt
+	}
+	if j.updatedAt != nil {
+		team.UpdatedAt = *j.updatedAt
+	}
+	return &team
+}
+
+// scanFixtureWithTeams scans a fixtureWithTeamsQuery row
+func scanFixtureWithTeams(row pgx.Row) (*models.Fixture, error) {
+	fixture := &models.Fixture{}
+	var home, away joinedTeam
+
+	dest := []interface{}{
+		&fixture.ID,
+		&fixture.APIFootballID,
+		&fixture.Season,
+		&fixture.MatchDate,
+		&fixture.Round,
+		&fixture.HomeTeamID,
+		&fixture.AwayTeamID,
+		&fixture.Status,
+		&fixture.HomeScore,
+		&fixture.AwayScore,
+		&fixture.VenueName,
+		&fixture.Referee,
+		&fixture.CreatedAt,
+		&fixture.UpdatedAt,
+		&fixture.LeagueID,
+		&fixture.DeletedAt,
+	}
+	dest = append(dest, home.dest()...)
+	dest = append(dest, away.dest()...)
+
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	fixture.HomeTeam = home.result()
+	fixture.AwayTeam = away.result()
+
+	return fixture, nil
+}
+
 // Helper function to scan fixtures from rows
 func (r *FixturesRepository) scanFixtures(rows pgx.Rows) ([]models.Fixture, error) {
 	var fixtures []models.Fixture
@@ -392,6 +999,8 @@ func (r *FixturesRepository) scanFixtures(rows pgx.Rows) ([]models.Fixture, erro
 			&fixture.Referee,
 			&fixture.CreatedAt,
 			&fixture.UpdatedAt,
+			&fixture.LeagueID,
+		&fixture.DeletedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan fixture: %w", err)
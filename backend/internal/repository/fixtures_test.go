@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newDeleteTestDB answers the statements of Delete and HardDelete: the fixture lock
+// finds the fixture if it exists, the bet lock returns bets and each DELETE affects
+// deleted[table] rows
+func newDeleteTestDB(exists bool, bets [][]any, deleted map[string]string) *fakeDB {
+	return newFakeDB(func(sql string, args []any) fakeResult {
+		switch {
+		case strings.Contains(sql, "SELECT id FROM fixtures"):
+			if !exists {
+				return fakeResult{}
+			}
+			return fakeResult{rows: [][]any{{args[0]}}}
+		case strings.HasPrefix(sql, "DELETE FROM "):
+			table := strings.Fields(sql)[2]
+			return fakeResult{tag: "DELETE " + deleted[table]}
+		case strings.Contains(sql, "FROM bets"):
+			return fakeResult{rows: bets}
+		}
+		return fakeResult{tag: "UPDATE 1"}
+	})
+}
+
+// writes returns the UPDATE and DELETE statements run against db
+func (db *fakeDB) writes() []string {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	var writes []string
+	for _, sql := range db.statements {
+		if s := strings.TrimSpace(sql); strings.HasPrefix(s, "UPDATE") || strings.HasPrefix(s, "DELETE") {
+			writes = append(writes, s)
+		}
+	}
+	return writes
+}
+
+func TestFixturesRepository_HardDelete_FixtureWithOdds_ReturnsPurgeCounts(t *testing.T) {
+	db := newDeleteTestDB(true, [][]any{{1, "1x2", "pending", 10.0, nil}}, map[string]string{
+		"odds":     "3",
+		"bets":     "1",
+		"fixtures": "1",
+	})
+	repo := &FixturesRepository{db: db}
+
+	purge, err := repo.HardDelete(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("HardDelete returned error: %v", err)
+	}
+
+	if purge.Odds != 3 {
+		t.Errorf("Expected 3 odds purged, got %d", purge.Odds)
+	}
+	if purge.PendingBets != 1 {
+		t.Errorf("Expected 1 pending bet purged, got %d", purge.PendingBets)
+	}
+	if got := len(db.writes()); got != 3 {
+		t.Errorf("Expected odds, bets and fixture deletes, got %d writes", got)
+	}
+}
+
+func TestFixturesRepository_Delete_SettledBet_ReturnsSettledBetsError(t *testing.T) {
+	settledAt := time.Now()
+	bets := [][]any{
+		{1, "1x2", "pending", 10.0, nil},
+		{2, "btts", "won", 25.0, settledAt},
+	}
+
+	for name, del := range map[string]func(*FixturesRepository) error{
+		"Delete": func(r *FixturesRepository) error { return r.Delete(context.Background(), 7) },
+		"HardDelete": func(r *FixturesRepository) error {
+			_, err := r.HardDelete(context.Background(), 7)
+			return err
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			db := newDeleteTestDB(true, bets, nil)
+			repo := &FixturesRepository{db: db}
+
+			err := del(repo)
+
+			var settledErr *SettledBetsError
+			if !errors.As(err, &settledErr) {
+				t.Fatalf("Expected *SettledBetsError, got %v", err)
+			}
+			if settledErr.FixtureID != 7 || len(settledErr.Bets) != 1 || settledErr.Bets[0].ID != 2 {
+				t.Errorf("Expected settled bet 2 on fixture 7, got %+v", settledErr)
+			}
+			if writes := db.writes(); len(writes) != 0 {
+				t.Errorf("Expected nothing deleted, got %v", writes)
+			}
+		})
+	}
+}
+
+func TestFixturesRepository_Delete_MissingFixture_ReturnsErrFixtureNotFound(t *testing.T) {
+	db := newDeleteTestDB(false, nil, nil)
+	repo := &FixturesRepository{db: db}
+
+	if err := repo.Delete(context.Background(), 7); !errors.Is(err, ErrFixtureNotFound) {
+		t.Errorf("Delete: expected ErrFixtureNotFound, got %v", err)
+	}
+	if _, err := repo.HardDelete(context.Background(), 7); !errors.Is(err, ErrFixtureNotFound) {
+		t.Errorf("HardDelete: expected ErrFixtureNotFound, got %v", err)
+	}
+	if writes := db.writes(); len(writes) != 0 {
+		t.Errorf("Expected nothing deleted, got %v", writes)
+	}
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+)
+
+// LeaguesRepository handles league database operations
+type LeaguesRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewLeaguesRepository creates a new leagues repository
+func NewLeaguesRepository(db *pgxpool.Pool) *LeaguesRepository {
+	return &LeaguesRepository{db: db}
+}
+
+const leagueColumns = `
+	id, api_football_id, name, COALESCE(country, ''), COALESCE(odds_api_sport_key, ''),
+	created_at, updated_at
+`
+
+// GetByID retrieves a league by ID
+func (r *LeaguesRepository) GetByID(ctx context.Context, id int) (*models.League, error) {
+	query := `SELECT ` + leagueColumns + ` FROM leagues WHERE id = $1`
+
+	league, err := scanLeague(r.db.QueryRow(ctx, query, id))
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("league not found with id %d", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league: %w", err)
+	}
+
+	return league, nil
+}
+
+// GetByAPIFootballID retrieves a league by API-Football ID
+func (r *LeaguesRepository) GetByAPIFootballID(ctx context.Context, apiFootballID int) (*models.League, error) {
+	query := `SELECT ` + leagueColumns + ` FROM leagues WHERE api_football_id = $1`
+
+	league, err := scanLeague(r.db.QueryRow(ctx, query, apiFootballID))
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("league not found with api_football_id %d", apiFootballID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league: %w", err)
+	}
+
+	return league, nil
+}
+
+// GetAll retrieves all leagues
+func (r *LeaguesRepository) GetAll(ctx context.Context) ([]models.League, error) {
+	query := `SELECT ` + leagueColumns + ` FROM leagues ORDER BY name`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query leagues: %w", err)
+	}
+	defer rows.Close()
+
+	var leagues []models.League
+	for rows.Next() {
+		league, err := scanLeague(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan league: %w", err)
+		}
+		leagues = append(leagues, *league)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return leagues, nil
+}
+
+// Upsert inserts or updates a league based on API-Football ID
+func (r *LeaguesRepository) Upsert(ctx context.Context, league *models.League) error {
+	query := `
+		INSERT INTO leagues (api_football_id, name, country, odds_api_sport_key, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (api_football_id)
+		DO UPDATE SET
+			name = EXCLUDED.name,
+			country = EXCLUDED.country,
+			odds_api_sport_key = EXCLUDED.odds_api_sport_key,
+			updated_at = EXCLUDED.updated_at
+		RETURNING id
+	`
+
+	now := time.Now().UTC()
+	err := r.db.QueryRow(ctx, query,
+		league.APIFootballID,
+		league.Name,
+		league.Country,
+		league.OddsAPISportKey,
+		now,
+		now,
+	).Scan(&league.ID)
+
+	if err != nil {
+		return fmt.Errorf("failed to upsert league: %w", err)
+	}
+
+	league.UpdatedAt = now
+
+	return nil
+}
+
+// AddTeam records that a team played in a league during a season
+func (r *LeaguesRepository) AddTeam(ctx context.Context, leagueID, teamID, season int) error {
+	query := `
+		INSERT INTO team_leagues (team_id, league_id, season)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (team_id, league_id, season) DO NOTHING
+	`
+
+	if _, err := r.db.Exec(ctx, query, teamID, leagueID, season); err != nil {
+		return fmt.Errorf("failed to add team to league: %w", err)
+	}
+
+	return nil
+}
+
+// Helper function to scan a league from a row
+func scanLeague(row pgx.Row) (*models.League, error) {
+	league := &models.League{}
+	err := row.Scan(
+		&league.ID,
+		&league.APIFootballID,
+		&league.Name,
+		&league.Country,
+		&league.OddsAPISportKey,
+		&league.CreatedAt,
+		&league.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return league, nil
+}
@@ -2,17 +2,25 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+	"github.com/dEnchanter/OddsIQ/backend/internal/oddsstream"
 )
 
+// ErrOddsNotFound is returned when no stored odds match a lookup
+var ErrOddsNotFound = errors.New("odds not found")
+
 // OddsRepository handles odds database operations
 type OddsRepository struct {
-	db *pgxpool.Pool
+	db    dbtx
+	cache *oddsCache      // Latest odds per fixture (nil when caching is disabled)
+	hub   *oddsstream.Hub // Receives newly inserted odds (optional)
 }
 
 // NewOddsRepository creates a new odds repository
@@ -20,25 +28,39 @@ func NewOddsRepository(db *pgxpool.Pool) *OddsRepository {
 	return &OddsRepository{db: db}
 }
 
+// EnableCache caches GetLatestByFixture results per fixture for ttl; a ttl <= 0
+// leaves caching disabled. Call it before the repository is shared.
+func (r *OddsRepository) EnableCache(ttl time.Duration) {
+	if ttl > 0 {
+		r.cache = newOddsCache(ttl)
+	}
+}
+
 // Create inserts new odds
 func (r *OddsRepository) Create(ctx context.Context, odds *models.Odds) error {
 	query := `
 		INSERT INTO odds (
-			fixture_id, bookmaker, market_type, outcome, odds_value, timestamp, created_at
+			fixture_id, bookmaker, market_type, outcome, odds_value, timestamp, created_at, line, is_live, source
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		RETURNING id
 	`
 
-	now := time.Now()
+	now := time.Now().UTC()
+	if odds.Source == "" {
+		odds.Source = models.OddsSourceOddsAPI
+	}
 	err := r.db.QueryRow(ctx, query,
 		odds.FixtureID,
 		odds.Bookmaker,
 		odds.MarketType,
 		odds.Outcome,
 		odds.OddsValue,
-		odds.Timestamp,
+		odds.Timestamp.UTC(),
 		now,
+		odds.Line,
+		odds.IsLive,
+		odds.Source,
 	).Scan(&odds.ID)
 
 	if err != nil {
@@ -46,52 +68,250 @@ func (r *OddsRepository) Create(ctx context.Context, odds *models.Odds) error {
 	}
 
 	odds.CreatedAt = now
+	r.cache.invalidate(odds.FixtureID)
+	r.hub.Publish(odds.FixtureID, []models.Odds{*odds})
 
 	return nil
 }
 
-// CreateBatch inserts multiple odds in a single transaction
-func (r *OddsRepository) CreateBatch(ctx context.Context, oddsList []models.Odds) error {
+// oddsDedupEpsilon is the largest price change treated as "unchanged" when deduplicating quotes
+const oddsDedupEpsilon = 0.001
+
+// insertIfChangedQuery inserts a quote unless the latest stored quote for the same
+// fixture/source/bookmaker/market/outcome/line, pre-match or live, has the same price
+// (within oddsDedupEpsilon)
+const insertIfChangedQuery = `
+	INSERT INTO odds (
+		fixture_id, bookmaker, market_type, outcome, odds_value, timestamp, created_at, line, is_live, source
+	)
+	SELECT $1::int, $2::varchar, $3::varchar, $4::varchar, $5::numeric, $6::timestamp, $7::timestamp, $8::numeric, $10::boolean, $11::varchar
+	WHERE NOT EXISTS (
+		SELECT 1 FROM (
+			SELECT odds_value
+			FROM odds
+			WHERE fixture_id = $1 AND source = $11 AND bookmaker = $2 AND market_type = $3 AND outcome = $4 AND line = $8
+				AND is_live = $10
+			ORDER BY timestamp DESC, id DESC
+			LIMIT 1
+		) latest
+		WHERE ABS(latest.odds_value - $5::numeric) < $9
+	)
+	RETURNING id
+`
+
+// CreateBatch inserts multiple odds in a single transaction, skipping quotes whose
+// price hasn't moved since the latest stored quote. It returns how many rows were
+// inserted and how many were skipped as duplicates.
+func (r *OddsRepository) CreateBatch(ctx context.Context, oddsList []models.Odds) (inserted, skipped int, err error) {
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return 0, 0, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback(ctx)
 
-	query := `
-		INSERT INTO odds (
-			fixture_id, bookmaker, market_type, outcome, odds_value, timestamp, created_at
-		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-	`
-
-	now := time.Now()
+	now := time.Now().UTC()
+	insertedByFixture := make(map[int][]models.Odds)
 	for _, odds := range oddsList {
-		_, err := tx.Exec(ctx, query,
+		if odds.Source == "" {
+			odds.Source = models.OddsSourceOddsAPI
+		}
+		err := tx.QueryRow(ctx, insertIfChangedQuery,
 			odds.FixtureID,
 			odds.Bookmaker,
 			odds.MarketType,
 			odds.Outcome,
 			odds.OddsValue,
-			odds.Timestamp,
+			odds.Timestamp.UTC(),
 			now,
-		)
+			odds.Line,
+			oddsDedupEpsilon,
+			odds.IsLive,
+			odds.Source,
+		).Scan(&odds.ID)
+		if err == pgx.ErrNoRows {
+			skipped++
+			continue
+		}
 		if err != nil {
-			return fmt.Errorf("failed to insert odds: %w", err)
+			return 0, 0, fmt.Errorf("failed to insert odds: %w", err)
 		}
+
+		odds.CreatedAt = now
+		insertedByFixture[odds.FixtureID] = append(insertedByFixture[odds.FixtureID], odds)
+		inserted++
 	}
 
 	if err := tx.Commit(ctx); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		return 0, 0, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
+	for fixtureID, fixtureOdds := range insertedByFixture {
+		r.cache.invalidate(fixtureID)
+		r.hub.Publish(fixtureID, fixtureOdds)
+	}
+
+	return inserted, skipped, nil
+}
+
+// BulkInsert is CreateBatch for large syncs and backfills: rows are streamed into a
+// staging table with COPY and inserted with one statement instead of a round trip per
+// row. Quotes are compared with the odds stored before the call, not with each other.
+func (r *OddsRepository) BulkInsert(ctx context.Context, oddsList []models.Odds) (inserted, skipped int, err error) {
+	if len(oddsList) == 0 {
+		return 0, 0, nil
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		CREATE TEMP TABLE odds_staging ON COMMIT DROP AS
+		SELECT fixture_id, bookmaker, market_type, outcome, odds_value, timestamp, line, is_live, source
+		FROM odds WITH NO DATA
+	`)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to create odds staging table: %w", err)
+	}
+
+	columns := []string{"fixture_id", "bookmaker", "market_type", "outcome", "odds_value", "timestamp", "line", "is_live", "source"}
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"odds_staging"}, columns,
+		pgx.CopyFromSlice(len(oddsList), func(i int) ([]any, error) {
+			o := &oddsList[i]
+			source := o.Source
+			if source == "" {
+				source = models.OddsSourceOddsAPI
+			}
+			return []any{o.FixtureID, o.Bookmaker, o.MarketType, o.Outcome, o.OddsValue, o.Timestamp.UTC(), o.Line, o.IsLive, source}, nil
+		}),
+	)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to copy odds: %w", err)
+	}
+
+	query := `
+		INSERT INTO odds (
+			fixture_id, bookmaker, market_type, outcome, odds_value, timestamp, created_at, line, is_live, source
+		)
+		SELECT s.fixture_id, s.bookmaker, s.market_type, s.outcome, s.odds_value, s.timestamp, $1, s.line, s.is_live, s.source
+		FROM odds_staging s
+		WHERE NOT EXISTS (
+			SELECT 1 FROM (
+				SELECT o.odds_value
+				FROM odds o
+				WHERE o.fixture_id = s.fixture_id AND o.source = s.source AND o.bookmaker = s.bookmaker
+					AND o.market_type = s.market_type AND o.outcome = s.outcome AND o.line = s.line
+					AND o.is_live = s.is_live
+				ORDER BY o.timestamp DESC, o.id DESC
+				LIMIT 1
+			) latest
+			WHERE ABS(latest.odds_value - s.odds_value) < $2
+		)
+		RETURNING id, fixture_id, bookmaker, market_type, outcome, odds_value, timestamp, created_at, line, is_live, source
+	`
+
+	rows, err := tx.Query(ctx, query, time.Now().UTC(), oddsDedupEpsilon)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to insert odds: %w", err)
+	}
+	insertedOdds, err := r.scanOdds(rows)
+	rows.Close()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	insertedByFixture := make(map[int][]models.Odds)
+	for _, odds := range insertedOdds {
+		insertedByFixture[odds.FixtureID] = append(insertedByFixture[odds.FixtureID], odds)
+	}
+	for fixtureID, fixtureOdds := range insertedByFixture {
+		r.cache.invalidate(fixtureID)
+		r.hub.Publish(fixtureID, fixtureOdds)
+	}
+
+	return len(insertedOdds), len(oddsList) - len(insertedOdds), nil
+}
+
+// PublishTo publishes newly inserted odds to hub, per fixture.
+// Call it before the repository is shared.
+func (r *OddsRepository) PublishTo(hub *oddsstream.Hub) {
+	r.hub = hub
+}
+
+// GetByID retrieves a single odds row. found is false if no row has the ID.
+func (r *OddsRepository) GetByID(ctx context.Context, id int) (odds *models.Odds, found bool, err error) {
+	query := `
+		SELECT id, fixture_id, bookmaker, market_type, outcome, odds_value, timestamp, created_at, line, is_live, source
+		FROM odds
+		WHERE id = $1
+	`
+
+	odds = &models.Odds{}
+	err = r.db.QueryRow(ctx, query, id).Scan(
+		&odds.ID,
+		&odds.FixtureID,
+		&odds.Bookmaker,
+		&odds.MarketType,
+		&odds.Outcome,
+		&odds.OddsValue,
+		&odds.Timestamp,
+		&odds.CreatedAt,
+		&odds.Line,
+		&odds.IsLive,
+		&odds.Source,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get odds: %w", err)
+	}
+
+	return odds, true, nil
+}
+
+// UpdateValue corrects the price of a single odds row
+func (r *OddsRepository) UpdateValue(ctx context.Context, id int, value float64) error {
+	var fixtureID int
+	err := r.db.QueryRow(ctx, `UPDATE odds SET odds_value = $1 WHERE id = $2 RETURNING fixture_id`, value, id).Scan(&fixtureID)
+	if err == pgx.ErrNoRows {
+		return fmt.Errorf("odds not found with id %d", id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update odds: %w", err)
+	}
+
+	r.cache.invalidate(fixtureID)
+
+	return nil
+}
+
+// Delete removes a single odds row
+func (r *OddsRepository) Delete(ctx context.Context, id int) error {
+	var fixtureID int
+	err := r.db.QueryRow(ctx, `DELETE FROM odds WHERE id = $1 RETURNING fixture_id`, id).Scan(&fixtureID)
+	if err == pgx.ErrNoRows {
+		return fmt.Errorf("odds not found with id %d", id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete odds: %w", err)
+	}
+
+	r.cache.invalidate(fixtureID)
+
 	return nil
 }
 
 // GetByFixture retrieves all odds for a specific fixture
 func (r *OddsRepository) GetByFixture(ctx context.Context, fixtureID int) ([]models.Odds, error) {
 	query := `
-		SELECT id, fixture_id, bookmaker, market_type, outcome, odds_value, timestamp, created_at
+		SELECT id, fixture_id, bookmaker, market_type, outcome, odds_value, timestamp, created_at, line, is_live, source
 		FROM odds
 		WHERE fixture_id = $1
 		ORDER BY timestamp DESC, bookmaker, market_type, outcome
@@ -106,14 +326,21 @@ func (r *OddsRepository) GetByFixture(ctx context.Context, fixtureID int) ([]mod
 	return r.scanOdds(rows)
 }
 
-// GetLatestByFixture retrieves the latest odds for each market/outcome combination for a fixture
+// GetLatestByFixture retrieves the latest pre-match odds for each market/outcome combination for
+// a fixture, served from the cache when it is enabled and fresh. In-play odds are read with
+// GetLiveByFixture.
 func (r *OddsRepository) GetLatestByFixture(ctx context.Context, fixtureID int) ([]models.Odds, error) {
+	if odds, ok := r.cache.get(fixtureID); ok {
+		return odds, nil
+	}
+	generation := r.cache.currentGeneration()
+
 	query := `
-		SELECT DISTINCT ON (bookmaker, market_type, outcome)
-			id, fixture_id, bookmaker, market_type, outcome, odds_value, timestamp, created_at
+		SELECT DISTINCT ON (source, bookmaker, market_type, outcome, line)
+			id, fixture_id, bookmaker, market_type, outcome, odds_value, timestamp, created_at, line, is_live, source
 		FROM odds
-		WHERE fixture_id = $1
-		ORDER BY bookmaker, market_type, outcome, timestamp DESC
+		WHERE fixture_id = $1 AND is_live = false
+		ORDER BY source, bookmaker, market_type, outcome, line, timestamp DESC
 	`
 
 	rows, err := r.db.Query(ctx, query, fixtureID)
@@ -122,13 +349,137 @@ func (r *OddsRepository) GetLatestByFixture(ctx context.Context, fixtureID int)
 	}
 	defer rows.Close()
 
+	odds, err := r.scanOdds(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.set(fixtureID, odds, generation)
+
+	return odds, nil
+}
+
+// GetLiveByFixture retrieves the latest in-play odds for each market/outcome combination
+// for a fixture
+func (r *OddsRepository) GetLiveByFixture(ctx context.Context, fixtureID int) ([]models.Odds, error) {
+	query := `
+		SELECT DISTINCT ON (source, bookmaker, market_type, outcome, line)
+			id, fixture_id, bookmaker, market_type, outcome, odds_value, timestamp, created_at, line, is_live, source
+		FROM odds
+		WHERE fixture_id = $1 AND is_live = true
+		ORDER BY source, bookmaker, market_type, outcome, line, timestamp DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, fixtureID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query live odds: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanOdds(rows)
+}
+
+// GetLatestByFixtureForBookmakers retrieves the latest pre-match odds for each market/outcome
+// combination for a fixture, restricted to the given bookmaker keys (matched case-insensitively)
+func (r *OddsRepository) GetLatestByFixtureForBookmakers(ctx context.Context, fixtureID int, keys []string) ([]models.Odds, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT DISTINCT ON (source, bookmaker, market_type, outcome, line)
+			id, fixture_id, bookmaker, market_type, outcome, odds_value, timestamp, created_at, line, is_live, source
+		FROM odds
+		WHERE fixture_id = $1 AND LOWER(bookmaker) = ANY($2) AND is_live = false
+		ORDER BY source, bookmaker, market_type, outcome, line, timestamp DESC
+	`
+
+	lowered := make([]string, len(keys))
+	for i, key := range keys {
+		lowered[i] = strings.ToLower(key)
+	}
+
+	rows, err := r.db.Query(ctx, query, fixtureID, lowered)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query latest odds for bookmakers: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanOdds(rows)
+}
+
+// GetLatestTimestamp returns the timestamp of the most recent odds quote, or nil if there are none
+func (r *OddsRepository) GetLatestTimestamp(ctx context.Context) (*time.Time, error) {
+	var latest *time.Time
+	if err := r.db.QueryRow(ctx, `SELECT MAX(timestamp) FROM odds`).Scan(&latest); err != nil {
+		return nil, fmt.Errorf("failed to get latest odds timestamp: %w", err)
+	}
+	return latest, nil
+}
+
+// CountByFixtureIDs returns, per fixture, the number of distinct bookmaker/market/outcome/line
+// quotes (the size of GetLatestByFixture). Fixtures without odds are omitted.
+func (r *OddsRepository) CountByFixtureIDs(ctx context.Context, fixtureIDs []int) (map[int]int, error) {
+	counts := make(map[int]int, len(fixtureIDs))
+	if len(fixtureIDs) == 0 {
+		return counts, nil
+	}
+
+	query := `
+		SELECT fixture_id, COUNT(*)
+		FROM (
+			SELECT DISTINCT fixture_id, source, bookmaker, market_type, outcome, line
+			FROM odds
+			WHERE fixture_id = ANY($1) AND is_live = false
+		) quotes
+		GROUP BY fixture_id
+	`
+
+	rows, err := r.db.Query(ctx, query, fixtureIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count odds: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var fixtureID, count int
+		if err := rows.Scan(&fixtureID, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan odds count: %w", err)
+		}
+		counts[fixtureID] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating odds counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// GetLatestByFixtureBefore retrieves the latest pre-match odds per bookmaker/market/outcome/line
+// recorded at or before the given time (e.g. a cutoff before kickoff)
+func (r *OddsRepository) GetLatestByFixtureBefore(ctx context.Context, fixtureID int, before time.Time) ([]models.Odds, error) {
+	query := `
+		SELECT DISTINCT ON (source, bookmaker, market_type, outcome, line)
+			id, fixture_id, bookmaker, market_type, outcome, odds_value, timestamp, created_at, line, is_live, source
+		FROM odds
+		WHERE fixture_id = $1 AND timestamp <= $2 AND is_live = false
+		ORDER BY source, bookmaker, market_type, outcome, line, timestamp DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, fixtureID, before.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query latest odds before cutoff: %w", err)
+	}
+	defer rows.Close()
+
 	return r.scanOdds(rows)
 }
 
 // GetByFixtureAndMarket retrieves odds for a specific fixture and market type
 func (r *OddsRepository) GetByFixtureAndMarket(ctx context.Context, fixtureID int, marketType string) ([]models.Odds, error) {
 	query := `
-		SELECT id, fixture_id, bookmaker, market_type, outcome, odds_value, timestamp, created_at
+		SELECT id, fixture_id, bookmaker, market_type, outcome, odds_value, timestamp, created_at, line, is_live, source
 		FROM odds
 		WHERE fixture_id = $1 AND market_type = $2
 		ORDER BY timestamp DESC, bookmaker, outcome
@@ -143,14 +494,14 @@ func (r *OddsRepository) GetByFixtureAndMarket(ctx context.Context, fixtureID in
 	return r.scanOdds(rows)
 }
 
-// GetLatestByFixtureAndMarket retrieves the latest odds for a specific fixture and market
+// GetLatestByFixtureAndMarket retrieves the latest pre-match odds for a specific fixture and market
 func (r *OddsRepository) GetLatestByFixtureAndMarket(ctx context.Context, fixtureID int, marketType string) ([]models.Odds, error) {
 	query := `
-		SELECT DISTINCT ON (bookmaker, outcome)
-			id, fixture_id, bookmaker, market_type, outcome, odds_value, timestamp, created_at
+		SELECT DISTINCT ON (source, bookmaker, outcome, line)
+			id, fixture_id, bookmaker, market_type, outcome, odds_value, timestamp, created_at, line, is_live, source
 		FROM odds
-		WHERE fixture_id = $1 AND market_type = $2
-		ORDER BY bookmaker, outcome, timestamp DESC
+		WHERE fixture_id = $1 AND market_type = $2 AND is_live = false
+		ORDER BY source, bookmaker, outcome, line, timestamp DESC
 	`
 
 	rows, err := r.db.Query(ctx, query, fixtureID, marketType)
@@ -162,18 +513,45 @@ func (r *OddsRepository) GetLatestByFixtureAndMarket(ctx context.Context, fixtur
 	return r.scanOdds(rows)
 }
 
-// GetBestOdds retrieves the best (highest) odds for a specific fixture, market, and outcome
-func (r *OddsRepository) GetBestOdds(ctx context.Context, fixtureID int, marketType, outcome string) (*models.Odds, error) {
+// GetHistory retrieves the price history for a fixture, market and outcome, oldest first.
+// An empty bookmaker returns history for every bookmaker.
+func (r *OddsRepository) GetHistory(ctx context.Context, fixtureID int, marketType, outcome, bookmaker string) ([]models.Odds, error) {
 	query := `
-		SELECT id, fixture_id, bookmaker, market_type, outcome, odds_value, timestamp, created_at
+		SELECT id, fixture_id, bookmaker, market_type, outcome, odds_value, timestamp, created_at, line, is_live, source
 		FROM odds
 		WHERE fixture_id = $1 AND market_type = $2 AND outcome = $3
+			AND ($4 = '' OR bookmaker = $4)
+		ORDER BY timestamp ASC, id ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, fixtureID, marketType, outcome, bookmaker)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query odds history: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanOdds(rows)
+}
+
+// GetBestOdds retrieves the best (highest) of each bookmaker's latest pre-match odds for a
+// specific fixture, market, outcome, and line, so withdrawn prices are never returned.
+// A non-empty source restricts the search to that provider.
+func (r *OddsRepository) GetBestOdds(ctx context.Context, fixtureID int, marketType, outcome string, line float64, source string) (*models.Odds, error) {
+	query := `
+		SELECT id, fixture_id, bookmaker, market_type, outcome, odds_value, timestamp, created_at, line, is_live, source
+		FROM (
+			SELECT DISTINCT ON (source, bookmaker) *
+			FROM odds
+			WHERE fixture_id = $1 AND market_type = $2 AND outcome = $3 AND line = $4
+				AND is_live = false AND ($5 = '' OR source = $5)
+			ORDER BY source, bookmaker, timestamp DESC, id DESC
+		) latest_odds
 		ORDER BY odds_value DESC, timestamp DESC
 		LIMIT 1
 	`
 
 	odds := &models.Odds{}
-	err := r.db.QueryRow(ctx, query, fixtureID, marketType, outcome).Scan(
+	err := r.db.QueryRow(ctx, query, fixtureID, marketType, outcome, line, source).Scan(
 		&odds.ID,
 		&odds.FixtureID,
 		&odds.Bookmaker,
@@ -182,10 +560,13 @@ func (r *OddsRepository) GetBestOdds(ctx context.Context, fixtureID int, marketT
 		&odds.OddsValue,
 		&odds.Timestamp,
 		&odds.CreatedAt,
+		&odds.Line,
+		&odds.IsLive,
+		&odds.Source,
 	)
 
 	if err == pgx.ErrNoRows {
-		return nil, fmt.Errorf("odds not found")
+		return nil, ErrOddsNotFound
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get best odds: %w", err)
@@ -194,36 +575,158 @@ func (r *OddsRepository) GetBestOdds(ctx context.Context, fixtureID int, marketT
 	return odds, nil
 }
 
-// GetByBookmaker retrieves all odds from a specific bookmaker
-func (r *OddsRepository) GetByBookmaker(ctx context.Context, bookmaker string) ([]models.Odds, error) {
+// GetClosingOdds retrieves the closing odds for a fixture, market, outcome, and line.
+// Rows flagged as closing line are preferred, otherwise the most recent odds at or before kickoff are used.
+func (r *OddsRepository) GetClosingOdds(ctx context.Context, fixtureID int, marketType, outcome string, line float64) (*models.Odds, error) {
 	query := `
-		SELECT id, fixture_id, bookmaker, market_type, outcome, odds_value, timestamp, created_at
-		FROM odds
-		WHERE bookmaker = $1
-		ORDER BY timestamp DESC
-		LIMIT 1000
+		SELECT o.id, o.fixture_id, o.bookmaker, o.market_type, o.outcome, o.odds_value, o.timestamp, o.created_at, o.line
+		FROM odds o
+		JOIN fixtures f ON f.id = o.fixture_id
+		WHERE o.fixture_id = $1 AND o.market_type = $2 AND o.outcome = $3 AND o.line = $4
+			AND o.timestamp <= f.match_date AND o.is_live = false
+		ORDER BY o.is_closing_line DESC, o.timestamp DESC
+		LIMIT 1
 	`
 
-	rows, err := r.db.Query(ctx, query, bookmaker)
+	odds := &models.Odds{}
+	err := r.db.QueryRow(ctx, query, fixtureID, marketType, outcome, line).Scan(
+		&odds.ID,
+		&odds.FixtureID,
+		&odds.Bookmaker,
+		&odds.MarketType,
+		&odds.Outcome,
+		&odds.OddsValue,
+		&odds.Timestamp,
+		&odds.CreatedAt,
+		&odds.Line,
+	)
+
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("closing odds not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get closing odds: %w", err)
+	}
+
+	return odds, nil
+}
+
+// MarkClosingLines flags the newest pre-kickoff odds row per (source, bookmaker, market_type, outcome, line)
+// as the closing line for a fixture. Older rows for the same key are unflagged, so re-running is idempotent.
+func (r *OddsRepository) MarkClosingLines(ctx context.Context, fixtureID int) (int64, error) {
+	query := `
+		UPDATE odds o
+		SET is_closing_line = (o.id = latest.id)
+		FROM (
+			SELECT DISTINCT ON (od.source, od.bookmaker, od.market_type, od.outcome, od.line)
+				od.id, od.source, od.bookmaker, od.market_type, od.outcome, od.line
+			FROM odds od
+			JOIN fixtures f ON f.id = od.fixture_id
+			WHERE od.fixture_id = $1 AND od.timestamp <= f.match_date AND od.is_live = false
+			ORDER BY od.source, od.bookmaker, od.market_type, od.outcome, od.line, od.timestamp DESC, od.id DESC
+		) latest
+		WHERE o.fixture_id = $1
+			AND o.source = latest.source
+			AND o.bookmaker = latest.bookmaker
+			AND o.market_type = latest.market_type
+			AND o.outcome = latest.outcome
+			AND o.line = latest.line
+			AND o.is_closing_line IS DISTINCT FROM (o.id = latest.id)
+	`
+
+	result, err := r.db.Exec(ctx, query, fixtureID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark closing lines: %w", err)
+	}
+
+	if result.RowsAffected() > 0 {
+		r.cache.invalidate(fixtureID)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// OddsFilter holds optional filters for listing odds
+type OddsFilter struct {
+	Bookmaker string
+	Market    string
+	From      time.Time // Quoted at or after (zero for no lower bound)
+	To        time.Time // Quoted at or before (zero for no upper bound)
+	Limit     int
+	Offset    int
+}
+
+// where builds the WHERE clause and arguments for the filter's conditions
+func (filter OddsFilter) where() (string, []interface{}) {
+	// Odds of soft-deleted fixtures are kept for restoring them but not listed
+	conditions := []string{"NOT EXISTS (SELECT 1 FROM fixtures f WHERE f.id = fixture_id AND f.deleted_at IS NOT NULL)"}
+	var args []interface{}
+
+	if filter.Bookmaker != "" {
+		args = append(args, filter.Bookmaker)
+		conditions = append(conditions, fmt.Sprintf("bookmaker = $%d", len(args)))
+	}
+	if filter.Market != "" {
+		args = append(args, filter.Market)
+		conditions = append(conditions, fmt.Sprintf("market_type = $%d", len(args)))
+	}
+	if !filter.From.IsZero() {
+		args = append(args, filter.From.UTC())
+		conditions = append(conditions, fmt.Sprintf("timestamp >= $%d", len(args)))
+	}
+	if !filter.To.IsZero() {
+		args = append(args, filter.To.UTC())
+		conditions = append(conditions, fmt.Sprintf("timestamp <= $%d", len(args)))
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// List retrieves odds matching the filter, newest first, along with the total count
+// before pagination
+func (r *OddsRepository) List(ctx context.Context, filter OddsFilter) ([]models.Odds, int, error) {
+	where, args := filter.where()
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM odds ` + where
+	if err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count odds: %w", err)
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := fmt.Sprintf(`
+		SELECT id, fixture_id, bookmaker, market_type, outcome, odds_value, timestamp, created_at, line, is_live, source
+		FROM odds
+		%s
+		ORDER BY timestamp DESC, id DESC
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)-1, len(args))
+
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query odds by bookmaker: %w", err)
+		return nil, 0, fmt.Errorf("failed to query odds: %w", err)
 	}
 	defer rows.Close()
 
-	return r.scanOdds(rows)
+	oddsList, err := r.scanOdds(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return oddsList, total, nil
 }
 
 // GetByDateRange retrieves odds within a date range
 func (r *OddsRepository) GetByDateRange(ctx context.Context, from, to time.Time) ([]models.Odds, error) {
 	query := `
-		SELECT id, fixture_id, bookmaker, market_type, outcome, odds_value, timestamp, created_at
+		SELECT id, fixture_id, bookmaker, market_type, outcome, odds_value, timestamp, created_at, line, is_live, source
 		FROM odds
 		WHERE timestamp >= $1 AND timestamp <= $2
 		ORDER BY timestamp DESC
 		LIMIT 5000
 	`
 
-	rows, err := r.db.Query(ctx, query, from, to)
+	rows, err := r.db.Query(ctx, query, from.UTC(), to.UTC())
 	if err != nil {
 		return nil, fmt.Errorf("failed to query odds by date range: %w", err)
 	}
@@ -236,11 +739,15 @@ func (r *OddsRepository) GetByDateRange(ctx context.Context, from, to time.Time)
 func (r *OddsRepository) DeleteOldOdds(ctx context.Context, before time.Time) (int64, error) {
 	query := `DELETE FROM odds WHERE timestamp < $1`
 
-	result, err := r.db.Exec(ctx, query, before)
+	result, err := r.db.Exec(ctx, query, before.UTC())
 	if err != nil {
 		return 0, fmt.Errorf("failed to delete old odds: %w", err)
 	}
 
+	if result.RowsAffected() > 0 {
+		r.cache.clear()
+	}
+
 	return result.RowsAffected(), nil
 }
 
@@ -296,32 +803,43 @@ func (r *OddsRepository) GetBookmakers(ctx context.Context) ([]string, error) {
 	return bookmakers, nil
 }
 
-// GetAverageOdds calculates average odds for a specific fixture, market, and outcome
-func (r *OddsRepository) GetAverageOdds(ctx context.Context, fixtureID int, marketType, outcome string) (float64, error) {
+// GetAverageOdds calculates the average of each bookmaker's latest pre-match odds for a
+// specific fixture, market, outcome, and line. A non-empty source restricts the average
+// to that provider. Returns ErrOddsNotFound if no odds match.
+func (r *OddsRepository) GetAverageOdds(ctx context.Context, fixtureID int, marketType, outcome string, line float64, source string) (float64, error) {
 	query := `
 		SELECT AVG(odds_value)
 		FROM (
-			SELECT DISTINCT ON (bookmaker) odds_value
+			SELECT DISTINCT ON (source, bookmaker) odds_value
 			FROM odds
-			WHERE fixture_id = $1 AND market_type = $2 AND outcome = $3
-			ORDER BY bookmaker, timestamp DESC
+			WHERE fixture_id = $1 AND market_type = $2 AND outcome = $3 AND line = $4
+				AND is_live = false AND ($5 = '' OR source = $5)
+			ORDER BY source, bookmaker, timestamp DESC, id DESC
 		) latest_odds
 	`
 
-	var avgOdds float64
-	err := r.db.QueryRow(ctx, query, fixtureID, marketType, outcome).Scan(&avgOdds)
+	var avgOdds *float64
+	err := r.db.QueryRow(ctx, query, fixtureID, marketType, outcome, line, source).Scan(&avgOdds)
 	if err != nil {
 		return 0, fmt.Errorf("failed to calculate average odds: %w", err)
 	}
+	if avgOdds == nil {
+		return 0, ErrOddsNotFound
+	}
 
-	return avgOdds, nil
+	return *avgOdds, nil
 }
 
-// UpsertOdds inserts or updates odds (based on fixture, bookmaker, market, outcome, and recent timestamp)
-func (r *OddsRepository) UpsertOdds(ctx context.Context, odds *models.Odds) error {
-	// For odds, we generally want to insert new records to track changes over time
-	// rather than update existing ones, so we just insert
-	return r.Create(ctx, odds)
+// UpsertOdds inserts odds unless the price is unchanged from the latest stored quote.
+// It reports whether a row was inserted.
+func (r *OddsRepository) UpsertOdds(ctx context.Context, odds *models.Odds) (bool, error) {
+	// For odds, we insert new records to track changes over time rather than
+	// update existing ones, but skip quotes whose price hasn't moved
+	inserted, _, err := r.CreateBatch(ctx, []models.Odds{*odds})
+	if err != nil {
+		return false, err
+	}
+	return inserted > 0, nil
 }
 
 // Helper function to scan odds from rows
@@ -338,6 +856,9 @@ func (r *OddsRepository) scanOdds(rows pgx.Rows) ([]models.Odds, error) {
 			&odds.OddsValue,
 			&odds.Timestamp,
 			&odds.CreatedAt,
+			&odds.Line,
+			&odds.IsLive,
+			&odds.Source,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan odds: %w", err)
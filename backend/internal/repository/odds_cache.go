@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+)
+
+// oddsCacheEntry is a fixture's latest odds and when they were loaded
+type oddsCacheEntry struct {
+	odds     []models.Odds
+	cachedAt time.Time
+}
+
+// oddsCache holds the latest odds per fixture for a short TTL. Writes made through the
+// repository invalidate the affected fixtures; writes from other processes show up
+// once the entry expires.
+//
+// Every invalidation bumps a generation counter. Readers take the generation before
+// querying and pass it to set, so odds read before a concurrent write aren't cached
+// after that write has invalidated them.
+type oddsCache struct {
+	ttl        time.Duration
+	mu         sync.RWMutex
+	entries    map[int]oddsCacheEntry
+	generation uint64
+	now        func() time.Time
+}
+
+// newOddsCache creates a cache with the given TTL
+func newOddsCache(ttl time.Duration) *oddsCache {
+	return &oddsCache{
+		ttl:     ttl,
+		entries: make(map[int]oddsCacheEntry),
+		now:     time.Now,
+	}
+}
+
+// get returns a copy of the cached odds for a fixture if they haven't expired.
+// A nil cache never hits.
+func (c *oddsCache) get(fixtureID int) ([]models.Odds, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[fixtureID]
+	if !ok || c.now().Sub(entry.cachedAt) >= c.ttl {
+		return nil, false
+	}
+
+	odds := make([]models.Odds, len(entry.odds))
+	copy(odds, entry.odds)
+	return odds, true
+}
+
+// currentGeneration returns the generation to pass to set for odds about to be read
+func (c *oddsCache) currentGeneration() uint64 {
+	if c == nil {
+		return 0
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.generation
+}
+
+// set caches a copy of a fixture's latest odds, read at generation. They are dropped
+// if the cache has been invalidated since.
+func (c *oddsCache) set(fixtureID int, odds []models.Odds, generation uint64) {
+	if c == nil {
+		return
+	}
+
+	cached := make([]models.Odds, len(odds))
+	copy(cached, odds)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.generation {
+		return
+	}
+	c.entries[fixtureID] = oddsCacheEntry{odds: cached, cachedAt: c.now()}
+}
+
+// invalidate drops the cached odds for the given fixtures
+func (c *oddsCache) invalidate(fixtureIDs ...int) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	for _, id := range fixtureIDs {
+		delete(c.entries, id)
+	}
+}
+
+// clear drops every cached fixture
+func (c *oddsCache) clear() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	c.entries = make(map[int]oddsCacheEntry)
+}
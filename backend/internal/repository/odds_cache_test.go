@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newCachedOddsRepo returns an odds repository with a ttl cache on a fake clock,
+// whose database returns one odds row per query for the requested fixture
+func newCachedOddsRepo(ttl time.Duration, respond func(sql string, args []any) fakeResult) (*OddsRepository, *fakeDB, *time.Time) {
+	if respond == nil {
+		respond = func(sql string, args []any) fakeResult {
+			return fakeResult{rows: [][]any{oddsRow(args[0].(int), 2.10)}}
+		}
+	}
+	db := newFakeDB(respond)
+	repo := &OddsRepository{db: db}
+	repo.EnableCache(ttl)
+
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	repo.cache.now = func() time.Time { return now }
+	return repo, db, &now
+}
+
+// oddsRow is a latest-odds row as scanned by scanOdds
+func oddsRow(fixtureID int, price float64) []any {
+	ts := time.Date(2026, 1, 10, 11, 0, 0, 0, time.UTC)
+	return []any{1, fixtureID, "bet365", "h2h", "Home", price, ts, ts, 0.0, false, "odds_api"}
+}
+
+func TestOddsCache_GetLatestByFixture_ServesFromCacheUntilTTL(t *testing.T) {
+	ctx := context.Background()
+	repo, db, now := newCachedOddsRepo(10*time.Second, nil)
+
+	for i := 0; i < 3; i++ {
+		if _, err := repo.GetLatestByFixture(ctx, 7); err != nil {
+			t.Fatalf("GetLatestByFixture returned error: %v", err)
+		}
+	}
+	if got := db.queryCount(); got != 1 {
+		t.Errorf("Expected 1 query within the TTL, got %d", got)
+	}
+
+	*now = now.Add(10 * time.Second)
+	odds, err := repo.GetLatestByFixture(ctx, 7)
+	if err != nil {
+		t.Fatalf("GetLatestByFixture returned error: %v", err)
+	}
+	if got := db.queryCount(); got != 2 {
+		t.Errorf("Expected 2 queries once the TTL passed, got %d", got)
+	}
+	if len(odds) != 1 || odds[0].FixtureID != 7 {
+		t.Errorf("Expected one odds row for fixture 7, got %+v", odds)
+	}
+}
+
+func TestOddsCache_GetLatestByFixture_CachesPerFixture(t *testing.T) {
+	ctx := context.Background()
+	repo, db, _ := newCachedOddsRepo(10*time.Second, nil)
+
+	repo.GetLatestByFixture(ctx, 1)
+	repo.GetLatestByFixture(ctx, 2)
+	repo.GetLatestByFixture(ctx, 1)
+
+	if got := db.queryCount(); got != 2 {
+		t.Errorf("Expected 2 queries for 2 fixtures, got %d", got)
+	}
+}
+
+func TestOddsCache_GetLatestByFixture_ReturnsCopy(t *testing.T) {
+	ctx := context.Background()
+	repo, _, _ := newCachedOddsRepo(10*time.Second, nil)
+
+	first, _ := repo.GetLatestByFixture(ctx, 7)
+	first[0].OddsValue = 99
+
+	second, _ := repo.GetLatestByFixture(ctx, 7)
+	if second[0].OddsValue != 2.10 {
+		t.Errorf("Expected cached odds of 2.10, got %v", second[0].OddsValue)
+	}
+}
+
+func TestOddsCache_MarkClosingLines_InvalidatesFixture(t *testing.T) {
+	ctx := context.Background()
+	repo, db, _ := newCachedOddsRepo(10*time.Second, func(sql string, args []any) fakeResult {
+		if strings.Contains(sql, "UPDATE odds") {
+			return fakeResult{tag: "UPDATE 2"}
+		}
+		return fakeResult{rows: [][]any{oddsRow(args[0].(int), 2.10)}}
+	})
+
+	repo.GetLatestByFixture(ctx, 7)
+	if _, err := repo.MarkClosingLines(ctx, 7); err != nil {
+		t.Fatalf("MarkClosingLines returned error: %v", err)
+	}
+	repo.GetLatestByFixture(ctx, 7)
+
+	// Read, update, read again
+	if got := db.queryCount(); got != 3 {
+		t.Errorf("Expected the read after MarkClosingLines to query, got %d queries", got)
+	}
+}
+
+func TestOddsCache_HardDelete_InvalidatesFixture(t *testing.T) {
+	ctx := context.Background()
+	db := newFakeDB(func(sql string, args []any) fakeResult {
+		switch {
+		case strings.Contains(sql, "SELECT id FROM fixtures"):
+			return fakeResult{rows: [][]any{{args[0]}}}
+		case strings.Contains(sql, "FROM bets"):
+			return fakeResult{}
+		case strings.HasPrefix(strings.TrimSpace(sql), "DELETE"):
+			return fakeResult{tag: "DELETE 1"}
+		}
+		return fakeResult{rows: [][]any{oddsRow(args[0].(int), 2.10)}}
+	})
+	oddsRepo := &OddsRepository{db: db}
+	oddsRepo.EnableCache(10 * time.Second)
+	fixturesRepo := &FixturesRepository{db: db}
+	fixturesRepo.InvalidateOddsOf(oddsRepo)
+
+	oddsRepo.GetLatestByFixture(ctx, 7)
+	if _, err := fixturesRepo.HardDelete(ctx, 7); err != nil {
+		t.Fatalf("HardDelete returned error: %v", err)
+	}
+	before := db.queryCount()
+	oddsRepo.GetLatestByFixture(ctx, 7)
+
+	if got := db.queryCount(); got != before+1 {
+		t.Errorf("Expected the read after HardDelete to query, got %d new queries", got-before)
+	}
+}
+
+func TestOddsCache_InvalidatedDuringRead_DoesNotCacheStaleOdds(t *testing.T) {
+	ctx := context.Background()
+	var repo *OddsRepository
+	reads := 0
+	repo, db, _ := newCachedOddsRepo(10*time.Second, func(sql string, args []any) fakeResult {
+		reads++
+		if reads == 1 {
+			// A write lands after this read's snapshot but before it is cached
+			repo.cache.invalidate(7)
+			return fakeResult{rows: [][]any{oddsRow(7, 2.10)}}
+		}
+		return fakeResult{rows: [][]any{oddsRow(7, 2.30)}}
+	})
+
+	repo.GetLatestByFixture(ctx, 7)
+	odds, err := repo.GetLatestByFixture(ctx, 7)
+	if err != nil {
+		t.Fatalf("GetLatestByFixture returned error: %v", err)
+	}
+
+	if got := db.queryCount(); got != 2 {
+		t.Errorf("Expected the stale read not to be cached, got %d queries", got)
+	}
+	if odds[0].OddsValue != 2.30 {
+		t.Errorf("Expected fresh odds of 2.30, got %v", odds[0].OddsValue)
+	}
+}
@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+)
+
+func TestOddsRepository_CountByFixtureIDs_ManyFixtures_RunsOneQuery(t *testing.T) {
+	db := newFakeDB(func(sql string, args []any) fakeResult {
+		var rows [][]any
+		for _, id := range args[0].([]int) {
+			if id%2 == 0 { // Only even fixtures have odds
+				rows = append(rows, []any{id, id * 3})
+			}
+		}
+		return fakeResult{rows: rows}
+	})
+	repo := &OddsRepository{db: db}
+
+	fixtureIDs := make([]int, 20)
+	for i := range fixtureIDs {
+		fixtureIDs[i] = i + 1
+	}
+
+	counts, err := repo.CountByFixtureIDs(context.Background(), fixtureIDs)
+	if err != nil {
+		t.Fatalf("CountByFixtureIDs returned error: %v", err)
+	}
+
+	if got := db.queryCount(); got != 1 {
+		t.Errorf("Expected 1 query for %d fixtures, got %d", len(fixtureIDs), got)
+	}
+	if counts[4] != 12 {
+		t.Errorf("Expected 12 odds for fixture 4, got %d", counts[4])
+	}
+	if counts[5] != 0 {
+		t.Errorf("Expected 0 odds for fixture 5, got %d", counts[5])
+	}
+}
+
+func TestOddsRepository_CountByFixtureIDs_NoFixtures_RunsNoQuery(t *testing.T) {
+	db := newFakeDB(nil)
+	repo := &OddsRepository{db: db}
+
+	if _, err := repo.CountByFixtureIDs(context.Background(), nil); err != nil {
+		t.Fatalf("CountByFixtureIDs returned error: %v", err)
+	}
+
+	if got := db.queryCount(); got != 0 {
+		t.Errorf("Expected no queries, got %d", got)
+	}
+}
+
+func TestOddsRepository_GetAverageOdds_NoOdds_ReturnsErrOddsNotFound(t *testing.T) {
+	db := newFakeDB(func(sql string, args []any) fakeResult {
+		return fakeResult{rows: [][]any{{nil}}} // AVG over no rows is NULL
+	})
+	repo := &OddsRepository{db: db}
+
+	if _, err := repo.GetAverageOdds(context.Background(), 7, "totals", "Over", 2.5, ""); !errors.Is(err, ErrOddsNotFound) {
+		t.Errorf("Expected ErrOddsNotFound, got %v", err)
+	}
+}
+
+func TestOddsRepository_GetAverageOdds_FiltersLineAndLiveOdds(t *testing.T) {
+	var gotSQL string
+	var gotArgs []any
+	db := newFakeDB(func(sql string, args []any) fakeResult {
+		gotSQL, gotArgs = sql, args
+		return fakeResult{rows: [][]any{{1.95}}}
+	})
+	repo := &OddsRepository{db: db}
+
+	avg, err := repo.GetAverageOdds(context.Background(), 7, "totals", "Over", 2.5, "")
+	if err != nil {
+		t.Fatalf("GetAverageOdds returned error: %v", err)
+	}
+
+	if avg != 1.95 {
+		t.Errorf("Expected 1.95, got %v", avg)
+	}
+	if !strings.Contains(gotSQL, "is_live = false") {
+		t.Error("Expected live odds to be excluded")
+	}
+	if gotArgs[3] != 2.5 {
+		t.Errorf("Expected line 2.5 to be filtered, got %v", gotArgs[3])
+	}
+}
+
+func TestOddsRepository_GetBestOdds_ReadsLatestPreMatchOddsPerBookmaker(t *testing.T) {
+	var gotSQL string
+	var gotArgs []any
+	db := newFakeDB(func(sql string, args []any) fakeResult {
+		gotSQL, gotArgs = sql, args
+		return fakeResult{}
+	})
+	repo := &OddsRepository{db: db}
+
+	if _, err := repo.GetBestOdds(context.Background(), 7, "spreads", "Home", -0.5, ""); !errors.Is(err, ErrOddsNotFound) {
+		t.Errorf("Expected ErrOddsNotFound, got %v", err)
+	}
+
+	for _, clause := range []string{"DISTINCT ON (source, bookmaker)", "is_live = false", "line = $4"} {
+		if !strings.Contains(gotSQL, clause) {
+			t.Errorf("Expected query to contain %q", clause)
+		}
+	}
+	if gotArgs[3] != -0.5 {
+		t.Errorf("Expected line -0.5, got %v", gotArgs[3])
+	}
+}
+
+func TestOddsRepository_PreMatchReads_ExcludeLiveOdds(t *testing.T) {
+	ctx := context.Background()
+	var gotSQL string
+	db := newFakeDB(func(sql string, args []any) fakeResult {
+		gotSQL = sql
+		return fakeResult{}
+	})
+	repo := &OddsRepository{db: db}
+
+	reads := map[string]func() error{
+		"GetLatestByFixture": func() error {
+			_, err := repo.GetLatestByFixture(ctx, 7)
+			return err
+		},
+		"GetLatestByFixtureAndMarket": func() error {
+			_, err := repo.GetLatestByFixtureAndMarket(ctx, 7, "h2h")
+			return err
+		},
+		"GetLatestByFixtureForBookmakers": func() error {
+			_, err := repo.GetLatestByFixtureForBookmakers(ctx, 7, []string{"pinnacle"})
+			return err
+		},
+	}
+
+	for name, read := range reads {
+		if err := read(); err != nil {
+			t.Fatalf("%s returned error: %v", name, err)
+		}
+		if !strings.Contains(gotSQL, "is_live = false") {
+			t.Errorf("%s: expected live odds to be excluded", name)
+		}
+	}
+}
+
+func TestOddsRepository_GetLiveByFixture_ReadsOnlyLiveOdds(t *testing.T) {
+	now := time.Now()
+	var gotSQL string
+	db := newFakeDB(func(sql string, args []any) fakeResult {
+		gotSQL = sql
+		return fakeResult{rows: [][]any{{1, 7, "bet365", "h2h", "Home", 1.65, now, now, 0.0, true, "api_football"}}}
+	})
+	repo := &OddsRepository{db: db}
+
+	odds, err := repo.GetLiveByFixture(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("GetLiveByFixture returned error: %v", err)
+	}
+
+	if !strings.Contains(gotSQL, "is_live = true") {
+		t.Error("Expected only live odds to be read")
+	}
+	if len(odds) != 1 || !odds[0].IsLive {
+		t.Errorf("Expected one live quote, got %+v", odds)
+	}
+}
+
+// pgx writes the wall clock of a time.Time to a timestamp column, so every stored time
+// must be UTC to compare with match_date on a host that isn't
+func TestOddsRepository_CreateBatch_NonUTCHost_WritesUTCTimestamps(t *testing.T) {
+	local := time.Local
+	time.Local = time.FixedZone("UTC+3", 3*60*60)
+	defer func() { time.Local = local }()
+
+	var gotArgs []any
+	db := newFakeDB(func(sql string, args []any) fakeResult {
+		gotArgs = args
+		return fakeResult{rows: [][]any{{1}}}
+	})
+	repo := &OddsRepository{db: db}
+
+	quoted := time.Date(2026, 1, 10, 15, 0, 0, 0, time.Local)
+	odds := []models.Odds{{FixtureID: 7, Bookmaker: "bet365", MarketType: "h2h", Outcome: "Home", OddsValue: 2.1, Timestamp: quoted}}
+	if _, _, err := repo.CreateBatch(context.Background(), odds); err != nil {
+		t.Fatalf("CreateBatch returned error: %v", err)
+	}
+
+	timestamp, createdAt := gotArgs[5].(time.Time), gotArgs[6].(time.Time)
+	if timestamp.Location() != time.UTC || timestamp.Hour() != 12 {
+		t.Errorf("Expected timestamp 12:00 UTC, got %v", timestamp)
+	}
+	if createdAt.Location() != time.UTC {
+		t.Errorf("Expected created_at in UTC, got %v", createdAt)
+	}
+}
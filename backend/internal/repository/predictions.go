@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+)
+
+// PredictionsRepository handles prediction database operations
+type PredictionsRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPredictionsRepository creates a new predictions repository
+func NewPredictionsRepository(db *pgxpool.Pool) *PredictionsRepository {
+	return &PredictionsRepository{db: db}
+}
+
+// Create inserts a new prediction
+func (r *PredictionsRepository) Create(ctx context.Context, prediction *models.Prediction) error {
+	query := `
+		INSERT INTO predictions (
+			fixture_id, model_version, home_win_prob, draw_prob, away_win_prob,
+			predicted_outcome, confidence_score, features, predicted_at, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id
+	`
+
+	features, err := json.Marshal(prediction.Features)
+	if err != nil {
+		return fmt.Errorf("failed to marshal prediction features: %w", err)
+	}
+
+	if prediction.PredictedAt.IsZero() {
+		prediction.PredictedAt = time.Now().UTC()
+	}
+
+	now := time.Now().UTC()
+	err = r.db.QueryRow(ctx, query,
+		prediction.FixtureID,
+		prediction.ModelVersion,
+		prediction.HomeWinProb,
+		prediction.DrawProb,
+		prediction.AwayWinProb,
+		prediction.PredictedOutcome,
+		prediction.ConfidenceScore,
+		features,
+		prediction.PredictedAt.UTC(),
+		now,
+	).Scan(&prediction.ID)
+
+	if err != nil {
+		return fmt.Errorf("failed to create prediction: %w", err)
+	}
+
+	prediction.CreatedAt = now
+
+	return nil
+}
+
+// GetByFixtureID retrieves the most recent prediction for a fixture
+func (r *PredictionsRepository) GetByFixtureID(ctx context.Context, fixtureID int) (*models.Prediction, error) {
+	query := `
+		SELECT id, fixture_id, model_version, home_win_prob, draw_prob, away_win_prob,
+			predicted_outcome, confidence_score, features, predicted_at, created_at
+		FROM predictions
+		WHERE fixture_id = $1
+		ORDER BY predicted_at DESC
+		LIMIT 1
+	`
+
+	prediction, err := r.scanPrediction(r.db.QueryRow(ctx, query, fixtureID))
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("prediction not found for fixture %d", fixtureID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prediction: %w", err)
+	}
+
+	return prediction, nil
+}
+
+// GetLatestByFixtureAndModelVersion retrieves the most recent prediction for a fixture made by a specific model version
+func (r *PredictionsRepository) GetLatestByFixtureAndModelVersion(ctx context.Context, fixtureID int, modelVersion string) (*models.Prediction, error) {
+	query := `
+		SELECT id, fixture_id, model_version, home_win_prob, draw_prob, away_win_prob,
+			predicted_outcome, confidence_score, features, predicted_at, created_at
+		FROM predictions
+		WHERE fixture_id = $1 AND model_version = $2
+		ORDER BY predicted_at DESC
+		LIMIT 1
+	`
+
+	prediction, err := r.scanPrediction(r.db.QueryRow(ctx, query, fixtureID, modelVersion))
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("prediction not found for fixture %d model %s", fixtureID, modelVersion)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prediction: %w", err)
+	}
+
+	return prediction, nil
+}
+
+// GetResults returns the last prediction made before kick-off for every finished
+// fixture in a season (0 for all seasons), paired with its final score. Predicted
+// fixtures that never finished are excluded.
+func (r *PredictionsRepository) GetResults(ctx context.Context, season int) ([]models.PredictionResult, error) {
+	query := `
+		SELECT DISTINCT ON (p.fixture_id)
+			p.fixture_id, p.home_win_prob, p.draw_prob, p.away_win_prob, f.home_score, f.away_score
+		FROM predictions p
+		JOIN fixtures f ON f.id = p.fixture_id
+		WHERE f.status IN ('FT', 'AET', 'PEN')
+			AND f.home_score IS NOT NULL AND f.away_score IS NOT NULL
+			AND p.predicted_at <= f.match_date
+			AND f.deleted_at IS NULL
+			AND ($1::int = 0 OR f.season = $1)
+		ORDER BY p.fixture_id, p.predicted_at DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, season)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query prediction results: %w", err)
+	}
+	defer rows.Close()
+
+	var results []models.PredictionResult
+	for rows.Next() {
+		var result models.PredictionResult
+		if err := rows.Scan(
+			&result.FixtureID,
+			&result.HomeWinProb,
+			&result.DrawProb,
+			&result.AwayWinProb,
+			&result.HomeScore,
+			&result.AwayScore,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan prediction result: %w", err)
+		}
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating prediction results: %w", err)
+	}
+
+	return results, nil
+}
+
+// Helper function to scan a single prediction row
+func (r *PredictionsRepository) scanPrediction(row pgx.Row) (*models.Prediction, error) {
+	prediction := &models.Prediction{}
+	var predictedOutcome *string
+	var confidence *float64
+	var features []byte
+
+	err := row.Scan(
+		&prediction.ID,
+		&prediction.FixtureID,
+		&prediction.ModelVersion,
+		&prediction.HomeWinProb,
+		&prediction.DrawProb,
+		&prediction.AwayWinProb,
+		&predictedOutcome,
+		&confidence,
+		&features,
+		&prediction.PredictedAt,
+		&prediction.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if predictedOutcome != nil {
+		prediction.PredictedOutcome = *predictedOutcome
+	}
+	if confidence != nil {
+		prediction.ConfidenceScore = *confidence
+	}
+	if len(features) > 0 {
+		if err := json.Unmarshal(features, &prediction.Features); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal prediction features: %w", err)
+		}
+	}
+
+	return prediction, nil
+}
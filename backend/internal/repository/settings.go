@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SettingsRepository stores runtime settings as JSON documents keyed by name
+type SettingsRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewSettingsRepository creates a new settings repository
+func NewSettingsRepository(db *pgxpool.Pool) *SettingsRepository {
+	return &SettingsRepository{db: db}
+}
+
+// Get retrieves the JSON value of a setting. found is false if it has never been set.
+func (r *SettingsRepository) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	query := `SELECT value FROM app_settings WHERE key = $1`
+
+	var value []byte
+	err := r.db.QueryRow(ctx, query, key).Scan(&value)
+	if err == pgx.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get setting %s: %w", key, err)
+	}
+
+	return value, true, nil
+}
+
+// Set stores the JSON value of a setting, replacing any previous value
+func (r *SettingsRepository) Set(ctx context.Context, key string, value []byte) error {
+	query := `
+		INSERT INTO app_settings (key, value, updated_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (key) DO UPDATE SET
+			value = EXCLUDED.value,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	if _, err := r.db.Exec(ctx, query, key, value, time.Now().UTC()); err != nil {
+		return fmt.Errorf("failed to save setting %s: %w", key, err)
+	}
+
+	return nil
+}
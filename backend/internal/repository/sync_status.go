@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+)
+
+// SyncStatusRepository records the outcome of sync runs
+type SyncStatusRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewSyncStatusRepository creates a new sync status repository
+func NewSyncStatusRepository(db *pgxpool.Pool) *SyncStatusRepository {
+	return &SyncStatusRepository{db: db}
+}
+
+// RecordSuccess stores the time and row count of a successful sync run
+func (r *SyncStatusRepository) RecordSuccess(ctx context.Context, syncType string, rows int) error {
+	query := `
+		INSERT INTO sync_status (sync_type, last_success_at, last_success_rows, updated_at)
+		VALUES ($1, $2, $3, $2)
+		ON CONFLICT (sync_type) DO UPDATE SET
+			last_success_at = EXCLUDED.last_success_at,
+			last_success_rows = EXCLUDED.last_success_rows,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	if _, err := r.db.Exec(ctx, query, syncType, time.Now().UTC(), rows); err != nil {
+		return fmt.Errorf("failed to record %s sync success: %w", syncType, err)
+	}
+
+	return nil
+}
+
+// RecordFailure stores the error of a failed sync run, keeping the last success intact
+func (r *SyncStatusRepository) RecordFailure(ctx context.Context, syncType string, syncErr error) error {
+	query := `
+		INSERT INTO sync_status (sync_type, last_error, last_error_at, updated_at)
+		VALUES ($1, $2, $3, $3)
+		ON CONFLICT (sync_type) DO UPDATE SET
+			last_error = EXCLUDED.last_error,
+			last_error_at = EXCLUDED.last_error_at,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	if _, err := r.db.Exec(ctx, query, syncType, syncErr.Error(), time.Now().UTC()); err != nil {
+		return fmt.Errorf("failed to record %s sync failure: %w", syncType, err)
+	}
+
+	return nil
+}
+
+// GetAll retrieves the status of every sync type that has run at least once
+func (r *SyncStatusRepository) GetAll(ctx context.Context) ([]models.SyncStatus, error) {
+	query := `
+		SELECT sync_type, last_success_at, last_success_rows, last_error, last_error_at, updated_at
+		FROM sync_status
+		ORDER BY sync_type
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sync status: %w", err)
+	}
+	defer rows.Close()
+
+	var statuses []models.SyncStatus
+	for rows.Next() {
+		var status models.SyncStatus
+		err := rows.Scan(
+			&status.SyncType,
+			&status.LastSuccessAt,
+			&status.LastSuccessRows,
+			&status.LastError,
+			&status.LastErrorAt,
+			&status.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan sync status: %w", err)
+		}
+		statuses = append(statuses, status)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sync status: %w", err)
+	}
+
+	return statuses, nil
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+)
+
+// TeamAliasRepository handles team alias and unmatched name database operations
+type TeamAliasRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewTeamAliasRepository creates a new team alias repository
+func NewTeamAliasRepository(db *pgxpool.Pool) *TeamAliasRepository {
+	return &TeamAliasRepository{db: db}
+}
+
+// GetTeamIDByAlias returns the team ID for an alias (case-insensitive).
+// found is false if no alias matches.
+func (r *TeamAliasRepository) GetTeamIDByAlias(ctx context.Context, alias string) (teamID int, found bool, err error) {
+	query := `SELECT team_id FROM team_aliases WHERE LOWER(alias) = LOWER($1)`
+
+	err = r.db.QueryRow(ctx, query, alias).Scan(&teamID)
+	if err == pgx.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get team alias: %w", err)
+	}
+
+	return teamID, true, nil
+}
+
+// Create inserts a new alias and clears it from the unmatched names log
+func (r *TeamAliasRepository) Create(ctx context.Context, alias *models.TeamAlias) error {
+	if alias.Source == "" {
+		alias.Source = "odds_api"
+	}
+
+	query := `
+		INSERT INTO team_aliases (alias, team_id, source)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(ctx, query, alias.Alias, alias.TeamID, alias.Source).Scan(&alias.ID, &alias.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create team alias: %w", err)
+	}
+
+	_, err = r.db.Exec(ctx, `DELETE FROM unmatched_team_names WHERE LOWER(name) = LOWER($1)`, alias.Alias)
+	if err != nil {
+		return fmt.Errorf("failed to clear unmatched team name: %w", err)
+	}
+
+	return nil
+}
+
+// GetByTeam retrieves all aliases for a team
+func (r *TeamAliasRepository) GetByTeam(ctx context.Context, teamID int) ([]models.TeamAlias, error) {
+	query := `
+		SELECT id, alias, team_id, source, created_at
+		FROM team_aliases
+		WHERE team_id = $1
+		ORDER BY alias
+	`
+
+	rows, err := r.db.Query(ctx, query, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query team aliases: %w", err)
+	}
+	defer rows.Close()
+
+	var aliases []models.TeamAlias
+	for rows.Next() {
+		var alias models.TeamAlias
+		if err := rows.Scan(&alias.ID, &alias.Alias, &alias.TeamID, &alias.Source, &alias.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan team alias: %w", err)
+		}
+		aliases = append(aliases, alias)
+	}
+
+	return aliases, nil
+}
+
+// RecordUnmatched logs a team name that couldn't be resolved, counting repeat occurrences.
+// candidateTeamID and candidateScore record the best fuzzy match below the threshold, if any.
+func (r *TeamAliasRepository) RecordUnmatched(ctx context.Context, name, source string, candidateTeamID *int, candidateScore *float64) error {
+	query := `
+		INSERT INTO unmatched_team_names (name, source, candidate_team_id, candidate_score)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (name, source) DO UPDATE SET
+			occurrences = unmatched_team_names.occurrences + 1,
+			candidate_team_id = COALESCE(EXCLUDED.candidate_team_id, unmatched_team_names.candidate_team_id),
+			candidate_score = COALESCE(EXCLUDED.candidate_score, unmatched_team_names.candidate_score),
+			last_seen_at = CURRENT_TIMESTAMP
+	`
+
+	_, err := r.db.Exec(ctx, query, name, source, candidateTeamID, candidateScore)
+	if err != nil {
+		return fmt.Errorf("failed to record unmatched team name: %w", err)
+	}
+
+	return nil
+}
+
+// GetUnmatched retrieves logged unmatched team names, most frequent first
+func (r *TeamAliasRepository) GetUnmatched(ctx context.Context) ([]models.UnmatchedTeamName, error) {
+	query := `
+		SELECT id, name, source, occurrences, candidate_team_id, candidate_score, first_seen_at, last_seen_at
+		FROM unmatched_team_names
+		ORDER BY occurrences DESC, last_seen_at DESC
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unmatched team names: %w", err)
+	}
+	defer rows.Close()
+
+	var names []models.UnmatchedTeamName
+	for rows.Next() {
+		var n models.UnmatchedTeamName
+		if err := rows.Scan(&n.ID, &n.Name, &n.Source, &n.Occurrences, &n.CandidateTeamID, &n.CandidateScore, &n.FirstSeenAt, &n.LastSeenAt); err != nil {
+			return nil, fmt.Errorf("failed to scan unmatched team name: %w", err)
+		}
+		names = append(names, n)
+	}
+
+	return names, nil
+}
@@ -28,13 +28,14 @@ func (r *TeamStatsRepository) Create(ctx context.Context, stats *models.TeamStat
 			goals_for, goals_against, goal_difference, points,
 			home_wins, home_draws, home_losses, away_wins, away_draws, away_losses,
 			form, clean_sheets, failed_to_score,
-			avg_goals_scored, avg_goals_conceded, created_at, updated_at
+			avg_goals_scored, avg_goals_conceded, created_at, updated_at,
+			league_id, rank, description
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26)
 		RETURNING id
 	`
 
-	now := time.Now()
+	now := time.Now().UTC()
 	err := r.db.QueryRow(ctx, query,
 		stats.TeamID,
 		stats.Season,
@@ -59,6 +60,9 @@ func (r *TeamStatsRepository) Create(ctx context.Context, stats *models.TeamStat
 		stats.AvgGoalsConceded,
 		now,
 		now,
+		stats.LeagueID,
+		stats.Rank,
+		stats.Description,
 	).Scan(&stats.ID)
 
 	if err != nil {
@@ -78,7 +82,8 @@ func (r *TeamStatsRepository) GetByID(ctx context.Context, id int) (*models.Team
 			goals_for, goals_against, goal_difference, points,
 			home_wins, home_draws, home_losses, away_wins, away_draws, away_losses,
 			form, clean_sheets, failed_to_score,
-			avg_goals_scored, avg_goals_conceded, created_at, updated_at
+			avg_goals_scored, avg_goals_conceded, created_at, updated_at,
+			league_id, rank, COALESCE(description, '')
 		FROM team_stats
 		WHERE id = $1
 	`
@@ -109,6 +114,9 @@ func (r *TeamStatsRepository) GetByID(ctx context.Context, id int) (*models.Team
 		&stats.AvgGoalsConceded,
 		&stats.CreatedAt,
 		&stats.UpdatedAt,
+		&stats.LeagueID,
+		&stats.Rank,
+		&stats.Description,
 	)
 
 	if err == pgx.ErrNoRows {
@@ -121,20 +129,25 @@ func (r *TeamStatsRepository) GetByID(ctx context.Context, id int) (*models.Team
 	return stats, nil
 }
 
-// GetByTeamAndSeason retrieves team stats for a specific team and season
-func (r *TeamStatsRepository) GetByTeamAndSeason(ctx context.Context, teamID, season int) (*models.TeamStats, error) {
+// GetByTeamAndSeason retrieves team stats for a specific team, league and season.
+// leagueID is the internal league ID; pass 0 to take the league the team played most
+// matches in.
+func (r *TeamStatsRepository) GetByTeamAndSeason(ctx context.Context, teamID, leagueID, season int) (*models.TeamStats, error) {
 	query := `
 		SELECT id, team_id, season, matches_played, wins, draws, losses,
 			goals_for, goals_against, goal_difference, points,
 			home_wins, home_draws, home_losses, away_wins, away_draws, away_losses,
 			form, clean_sheets, failed_to_score,
-			avg_goals_scored, avg_goals_conceded, created_at, updated_at
+			avg_goals_scored, avg_goals_conceded, created_at, updated_at,
+			league_id, rank, COALESCE(description, '')
 		FROM team_stats
-		WHERE team_id = $1 AND season = $2
+		WHERE team_id = $1 AND season = $2 AND ($3::int = 0 OR league_id = $3)
+		ORDER BY matches_played DESC, id
+		LIMIT 1
 	`
 
 	stats := &models.TeamStats{}
-	err := r.db.QueryRow(ctx, query, teamID, season).Scan(
+	err := r.db.QueryRow(ctx, query, teamID, season, leagueID).Scan(
 		&stats.ID,
 		&stats.TeamID,
 		&stats.Season,
@@ -159,10 +172,13 @@ func (r *TeamStatsRepository) GetByTeamAndSeason(ctx context.Context, teamID, se
 		&stats.AvgGoalsConceded,
 		&stats.CreatedAt,
 		&stats.UpdatedAt,
+		&stats.LeagueID,
+		&stats.Rank,
+		&stats.Description,
 	)
 
 	if err == pgx.ErrNoRows {
-		return nil, fmt.Errorf("team stats not found for team %d season %d", teamID, season)
+		return nil, fmt.Errorf("team stats not found for team %d league %d season %d", teamID, leagueID, season)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get team stats: %w", err)
@@ -171,20 +187,22 @@ func (r *TeamStatsRepository) GetByTeamAndSeason(ctx context.Context, teamID, se
 	return stats, nil
 }
 
-// GetBySeason retrieves all team stats for a specific season
-func (r *TeamStatsRepository) GetBySeason(ctx context.Context, season int) ([]models.TeamStats, error) {
+// GetBySeason retrieves all team stats for a season in standings order, league by league.
+// leagueID is the internal league ID; pass 0 to include all leagues.
+func (r *TeamStatsRepository) GetBySeason(ctx context.Context, leagueID, season int) ([]models.TeamStats, error) {
 	query := `
 		SELECT id, team_id, season, matches_played, wins, draws, losses,
 			goals_for, goals_against, goal_difference, points,
 			home_wins, home_draws, home_losses, away_wins, away_draws, away_losses,
 			form, clean_sheets, failed_to_score,
-			avg_goals_scored, avg_goals_conceded, created_at, updated_at
+			avg_goals_scored, avg_goals_conceded, created_at, updated_at,
+			league_id, rank, COALESCE(description, '')
 		FROM team_stats
-		WHERE season = $1
-		ORDER BY points DESC, goal_difference DESC
+		WHERE season = $1 AND ($2::int = 0 OR league_id = $2)
+		ORDER BY league_id, rank NULLS LAST, points DESC, goal_difference DESC
 	`
 
-	rows, err := r.db.Query(ctx, query, season)
+	rows, err := r.db.Query(ctx, query, season, leagueID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query team stats: %w", err)
 	}
@@ -200,10 +218,11 @@ func (r *TeamStatsRepository) GetByTeam(ctx context.Context, teamID int) ([]mode
 			goals_for, goals_against, goal_difference, points,
 			home_wins, home_draws, home_losses, away_wins, away_draws, away_losses,
 			form, clean_sheets, failed_to_score,
-			avg_goals_scored, avg_goals_conceded, created_at, updated_at
+			avg_goals_scored, avg_goals_conceded, created_at, updated_at,
+			league_id, rank, COALESCE(description, '')
 		FROM team_stats
 		WHERE team_id = $1
-		ORDER BY season DESC
+		ORDER BY season DESC, league_id
 	`
 
 	rows, err := r.db.Query(ctx, query, teamID)
@@ -224,11 +243,12 @@ func (r *TeamStatsRepository) Update(ctx context.Context, stats *models.TeamStat
 			home_wins = $9, home_draws = $10, home_losses = $11,
 			away_wins = $12, away_draws = $13, away_losses = $14,
 			form = $15, clean_sheets = $16, failed_to_score = $17,
-			avg_goals_scored = $18, avg_goals_conceded = $19, updated_at = $20
-		WHERE id = $21
+			avg_goals_scored = $18, avg_goals_conceded = $19, updated_at = $20,
+			rank = $21, description = $22
+		WHERE id = $23
 	`
 
-	now := time.Now()
+	now := time.Now().UTC()
 	result, err := r.db.Exec(ctx, query,
 		stats.MatchesPlayed,
 		stats.Wins,
@@ -250,6 +270,8 @@ func (r *TeamStatsRepository) Update(ctx context.Context, stats *models.TeamStat
 		stats.AvgGoalsScored,
 		stats.AvgGoalsConceded,
 		now,
+		stats.Rank,
+		stats.Description,
 		stats.ID,
 	)
 
@@ -274,10 +296,11 @@ func (r *TeamStatsRepository) Upsert(ctx context.Context, stats *models.TeamStat
 			goals_for, goals_against, goal_difference, points,
 			home_wins, home_draws, home_losses, away_wins, away_draws, away_losses,
 			form, clean_sheets, failed_to_score,
-			avg_goals_scored, avg_goals_conceded, created_at, updated_at
+			avg_goals_scored, avg_goals_conceded, created_at, updated_at,
+			league_id, rank, description
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)
-		ON CONFLICT (team_id, season)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26)
+		ON CONFLICT (team_id, league_id, season)
 		DO UPDATE SET
 			matches_played = EXCLUDED.matches_played,
 			wins = EXCLUDED.wins,
@@ -298,11 +321,13 @@ func (r *TeamStatsRepository) Upsert(ctx context.Context, stats *models.TeamStat
 			failed_to_score = EXCLUDED.failed_to_score,
 			avg_goals_scored = EXCLUDED.avg_goals_scored,
 			avg_goals_conceded = EXCLUDED.avg_goals_conceded,
+			rank = EXCLUDED.rank,
+			description = EXCLUDED.description,
 			updated_at = EXCLUDED.updated_at
 		RETURNING id
 	`
 
-	now := time.Now()
+	now := time.Now().UTC()
 	err := r.db.QueryRow(ctx, query,
 		stats.TeamID,
 		stats.Season,
@@ -327,6 +352,9 @@ func (r *TeamStatsRepository) Upsert(ctx context.Context, stats *models.TeamStat
 		stats.AvgGoalsConceded,
 		now,
 		now,
+		stats.LeagueID,
+		stats.Rank,
+		stats.Description,
 	).Scan(&stats.ID)
 
 	if err != nil {
@@ -354,21 +382,23 @@ func (r *TeamStatsRepository) Delete(ctx context.Context, id int) error {
 	return nil
 }
 
-// GetTopTeams retrieves top N teams by points for a specific season
-func (r *TeamStatsRepository) GetTopTeams(ctx context.Context, season, limit int) ([]models.TeamStats, error) {
+// GetTopTeams retrieves the top N teams in a league's standings for a season, by rank
+// where standings were synced and otherwise by points
+func (r *TeamStatsRepository) GetTopTeams(ctx context.Context, leagueID, season, limit int) ([]models.TeamStats, error) {
 	query := `
 		SELECT id, team_id, season, matches_played, wins, draws, losses,
 			goals_for, goals_against, goal_difference, points,
 			home_wins, home_draws, home_losses, away_wins, away_draws, away_losses,
 			form, clean_sheets, failed_to_score,
-			avg_goals_scored, avg_goals_conceded, created_at, updated_at
+			avg_goals_scored, avg_goals_conceded, created_at, updated_at,
+			league_id, rank, COALESCE(description, '')
 		FROM team_stats
-		WHERE season = $1
-		ORDER BY points DESC, goal_difference DESC, goals_for DESC
-		LIMIT $2
+		WHERE season = $1 AND league_id = $2
+		ORDER BY rank NULLS LAST, points DESC, goal_difference DESC, goals_for DESC
+		LIMIT $3
 	`
 
-	rows, err := r.db.Query(ctx, query, season, limit)
+	rows, err := r.db.Query(ctx, query, season, leagueID, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query top teams: %w", err)
 	}
@@ -407,6 +437,9 @@ func (r *TeamStatsRepository) scanTeamStats(rows pgx.Rows) ([]models.TeamStats,
 			&stats.AvgGoalsConceded,
 			&stats.CreatedAt,
 			&stats.UpdatedAt,
+		&stats.LeagueID,
+		&stats.Rank,
+		&stats.Description,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan team stats: %w", err)
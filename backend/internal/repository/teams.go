@@ -12,7 +12,7 @@ import (
 
 // TeamsRepository handles team database operations
 type TeamsRepository struct {
-	db *pgxpool.Pool
+	db dbtx
 }
 
 // NewTeamsRepository creates a new teams repository
@@ -28,7 +28,7 @@ func (r *TeamsRepository) Create(ctx context.Context, team *models.Team) error {
 		RETURNING id
 	`
 
-	now := time.Now()
+	now := time.Now().UTC()
 	err := r.db.QueryRow(ctx, query,
 		team.APIFootballID,
 		team.Name,
@@ -85,6 +85,53 @@ func (r *TeamsRepository) GetByID(ctx context.Context, id int) (*models.Team, er
 	return team, nil
 }
 
+// GetByIDs retrieves teams by ID in a single query, keyed by ID. Unknown IDs are omitted.
+func (r *TeamsRepository) GetByIDs(ctx context.Context, ids []int) (map[int]models.Team, error) {
+	teams := make(map[int]models.Team, len(ids))
+	if len(ids) == 0 {
+		return teams, nil
+	}
+
+	query := `
+		SELECT id, api_football_id, name, code, logo_url, founded, venue_name, venue_city, venue_capacity, created_at, updated_at
+		FROM teams
+		WHERE id = ANY($1)
+	`
+
+	rows, err := r.db.Query(ctx, query, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query teams: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var team models.Team
+		err := rows.Scan(
+			&team.ID,
+			&team.APIFootballID,
+			&team.Name,
+			&team.Code,
+			&team.LogoURL,
+			&team.Founded,
+			&team.VenueName,
+			&team.VenueCity,
+			&team.VenueCapacity,
+			&team.CreatedAt,
+			&team.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan team: %w", err)
+		}
+		teams[team.ID] = team
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return teams, nil
+}
+
 // GetByAPIFootballID retrieves a team by API-Football ID
 func (r *TeamsRepository) GetByAPIFootballID(ctx context.Context, apiFootballID int) (*models.Team, error) {
 	query := `
@@ -118,6 +165,22 @@ func (r *TeamsRepository) GetByAPIFootballID(ctx context.Context, apiFootballID
 	return team, nil
 }
 
+// GetIDByName returns the ID of the team with the given name (case-insensitive).
+// found is false if no team matches.
+func (r *TeamsRepository) GetIDByName(ctx context.Context, name string) (id int, found bool, err error) {
+	query := `SELECT id FROM teams WHERE LOWER(name) = LOWER($1) LIMIT 1`
+
+	err = r.db.QueryRow(ctx, query, name).Scan(&id)
+	if err == pgx.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get team by name: %w", err)
+	}
+
+	return id, true, nil
+}
+
 // GetAll retrieves all teams
 func (r *TeamsRepository) GetAll(ctx context.Context) ([]models.Team, error) {
 	query := `
@@ -161,6 +224,52 @@ func (r *TeamsRepository) GetAll(ctx context.Context) ([]models.Team, error) {
 	return teams, nil
 }
 
+// GetByLeague retrieves teams that played in a league during a season
+func (r *TeamsRepository) GetByLeague(ctx context.Context, leagueID, season int) ([]models.Team, error) {
+	query := `
+		SELECT t.id, t.api_football_id, t.name, t.code, t.logo_url, t.founded, t.venue_name, t.venue_city,
+			t.venue_capacity, t.created_at, t.updated_at
+		FROM teams t
+		JOIN team_leagues tl ON tl.team_id = t.id
+		WHERE tl.league_id = $1 AND tl.season = $2
+		ORDER BY t.name
+	`
+
+	rows, err := r.db.Query(ctx, query, leagueID, season)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query teams by league: %w", err)
+	}
+	defer rows.Close()
+
+	var teams []models.Team
+	for rows.Next() {
+		var team models.Team
+		err := rows.Scan(
+			&team.ID,
+			&team.APIFootballID,
+			&team.Name,
+			&team.Code,
+			&team.LogoURL,
+			&team.Founded,
+			&team.VenueName,
+			&team.VenueCity,
+			&team.VenueCapacity,
+			&team.CreatedAt,
+			&team.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan team: %w", err)
+		}
+		teams = append(teams, team)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return teams, nil
+}
+
 // Update updates an existing team
 func (r *TeamsRepository) Update(ctx context.Context, team *models.Team) error {
 	query := `
@@ -169,7 +278,7 @@ func (r *TeamsRepository) Update(ctx context.Context, team *models.Team) error {
 		WHERE id = $9
 	`
 
-	now := time.Now()
+	now := time.Now().UTC()
 	result, err := r.db.Exec(ctx, query,
 		team.Name,
 		team.Code,
@@ -229,7 +338,7 @@ func (r *TeamsRepository) Upsert(ctx context.Context, team *models.Team) error {
 		RETURNING id
 	`
 
-	now := time.Now()
+	now := time.Now().UTC()
 	err := r.db.QueryRow(ctx, query,
 		team.APIFootballID,
 		team.Name,
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTeamsRepository_GetByIDs_ManyIDs_RunsOneQuery(t *testing.T) {
+	now := time.Now()
+	db := newFakeDB(func(sql string, args []any) fakeResult {
+		ids := args[0].([]int)
+		rows := make([][]any, 0, len(ids))
+		for _, id := range ids {
+			rows = append(rows, []any{id, id * 10, "Team", nil, nil, nil, nil, nil, nil, now, now})
+		}
+		return fakeResult{rows: rows}
+	})
+	repo := &TeamsRepository{db: db}
+
+	ids := make([]int, 40)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+
+	teams, err := repo.GetByIDs(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("GetByIDs returned error: %v", err)
+	}
+
+	if got := db.queryCount(); got != 1 {
+		t.Errorf("Expected 1 query for %d teams, got %d", len(ids), got)
+	}
+	if len(teams) != len(ids) {
+		t.Errorf("Expected %d teams, got %d", len(ids), len(teams))
+	}
+	if team := teams[7]; team.ID != 7 || team.APIFootballID != 70 {
+		t.Errorf("Expected team 7 with API-Football ID 70, got %+v", team)
+	}
+}
+
+func TestTeamsRepository_GetByIDs_NoIDs_RunsNoQuery(t *testing.T) {
+	db := newFakeDB(nil)
+	repo := &TeamsRepository{db: db}
+
+	teams, err := repo.GetByIDs(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("GetByIDs returned error: %v", err)
+	}
+
+	if got := db.queryCount(); got != 0 {
+		t.Errorf("Expected no queries, got %d", got)
+	}
+	if len(teams) != 0 {
+		t.Errorf("Expected no teams, got %d", len(teams))
+	}
+}
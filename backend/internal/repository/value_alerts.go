@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+)
+
+// ValueAlertsRepository records value alerts, one row per fixture outcome
+type ValueAlertsRepository struct {
+	db dbtx
+}
+
+// NewValueAlertsRepository creates a new value alerts repository
+func NewValueAlertsRepository(db *pgxpool.Pool) *ValueAlertsRepository {
+	return &ValueAlertsRepository{db: db}
+}
+
+// Record stores an alert, updating the latest figures of an outcome that was already
+// alerted. The alert's ID and first/last seen times are filled in.
+func (r *ValueAlertsRepository) Record(ctx context.Context, alert *models.ValueAlert) error {
+	query := `
+		INSERT INTO value_alerts (
+			fixture_id, market, outcome, model_probability, market_probability, edge,
+			best_odds, bookmaker, first_seen_at, last_seen_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9)
+		ON CONFLICT (fixture_id, market, outcome) DO UPDATE SET
+			model_probability = EXCLUDED.model_probability,
+			market_probability = EXCLUDED.market_probability,
+			edge = EXCLUDED.edge,
+			best_odds = EXCLUDED.best_odds,
+			bookmaker = EXCLUDED.bookmaker,
+			last_seen_at = EXCLUDED.last_seen_at
+		RETURNING id, first_seen_at, last_seen_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		alert.FixtureID,
+		alert.Market,
+		alert.Outcome,
+		alert.ModelProbability,
+		alert.MarketProbability,
+		alert.Edge,
+		alert.BestOdds,
+		alert.Bookmaker,
+		time.Now().UTC(),
+	).Scan(&alert.ID, &alert.FirstSeenAt, &alert.LastSeenAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to record value alert: %w", err)
+	}
+
+	return nil
+}
+
+// GetByFixtureIDs returns the recorded alerts of the given fixtures
+func (r *ValueAlertsRepository) GetByFixtureIDs(ctx context.Context, fixtureIDs []int) ([]models.ValueAlert, error) {
+	alerts := []models.ValueAlert{}
+	if len(fixtureIDs) == 0 {
+		return alerts, nil
+	}
+
+	query := `
+		SELECT id, fixture_id, market, outcome, model_probability, market_probability, edge,
+			best_odds, bookmaker, first_seen_at, last_seen_at
+		FROM value_alerts
+		WHERE fixture_id = ANY($1)
+	`
+
+	rows, err := r.db.Query(ctx, query, fixtureIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query value alerts: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var alert models.ValueAlert
+		err := rows.Scan(
+			&alert.ID,
+			&alert.FixtureID,
+			&alert.Market,
+			&alert.Outcome,
+			&alert.ModelProbability,
+			&alert.MarketProbability,
+			&alert.Edge,
+			&alert.BestOdds,
+			&alert.Bookmaker,
+			&alert.FirstSeenAt,
+			&alert.LastSeenAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan value alert: %w", err)
+		}
+		alerts = append(alerts, alert)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return alerts, nil
+}
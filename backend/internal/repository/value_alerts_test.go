@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestValueAlertsRepository_GetByFixtureIDs_ReturnsRecordedAlerts(t *testing.T) {
+	now := time.Now()
+	db := newFakeDB(func(sql string, args []any) fakeResult {
+		return fakeResult{rows: [][]any{
+			{1, 10, "1x2", "home_win", 0.55, 0.45, 0.10, 2.2, "bet365", now, now},
+			{2, 11, "btts", "yes", 0.62, 0.54, 0.08, 1.8, "pinnacle", now, now},
+		}}
+	})
+	repo := &ValueAlertsRepository{db: db}
+
+	alerts, err := repo.GetByFixtureIDs(context.Background(), []int{10, 11})
+	if err != nil {
+		t.Fatalf("GetByFixtureIDs returned error: %v", err)
+	}
+
+	if got := db.queryCount(); got != 1 {
+		t.Errorf("Expected 1 query, got %d", got)
+	}
+	if len(alerts) != 2 {
+		t.Fatalf("Expected 2 alerts, got %d", len(alerts))
+	}
+	if a := alerts[1]; a.FixtureID != 11 || a.Market != "btts" || a.Outcome != "yes" {
+		t.Errorf("Expected fixture 11 btts yes, got %+v", a)
+	}
+}
+
+func TestValueAlertsRepository_GetByFixtureIDs_NoIDs_RunsNoQuery(t *testing.T) {
+	db := newFakeDB(nil)
+	repo := &ValueAlertsRepository{db: db}
+
+	alerts, err := repo.GetByFixtureIDs(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("GetByFixtureIDs returned error: %v", err)
+	}
+
+	if got := db.queryCount(); got != 0 {
+		t.Errorf("Expected no queries, got %d", got)
+	}
+	if len(alerts) != 0 {
+		t.Errorf("Expected no alerts, got %d", len(alerts))
+	}
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// WatchlistRepository handles saved watchlist outcomes
+type WatchlistRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewWatchlistRepository creates a new watchlist repository
+func NewWatchlistRepository(db *pgxpool.Pool) *WatchlistRepository {
+	return &WatchlistRepository{db: db}
+}
+
+// Add saves an outcome to the watchlist. Saving an outcome that is already on the
+// watchlist returns the existing item.
+func (r *WatchlistRepository) Add(ctx context.Context, item *models.WatchlistItem) error {
+	query := `
+		INSERT INTO watchlist (fixture_id, market, outcome)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (fixture_id, market, outcome) DO UPDATE SET
+			market = EXCLUDED.market
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(ctx, query, item.FixtureID, item.Market, item.Outcome).Scan(&item.ID, &item.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to add watchlist item: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves every watchlist item, most recently saved first
+func (r *WatchlistRepository) List(ctx context.Context) ([]models.WatchlistItem, error) {
+	query := `
+		SELECT id, fixture_id, market, outcome, created_at
+		FROM watchlist
+		ORDER BY created_at DESC, id DESC
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query watchlist: %w", err)
+	}
+	defer rows.Close()
+
+	var items []models.WatchlistItem
+	for rows.Next() {
+		var item models.WatchlistItem
+		if err := rows.Scan(&item.ID, &item.FixtureID, &item.Market, &item.Outcome, &item.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan watchlist item: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating watchlist: %w", err)
+	}
+
+	return items, nil
+}
+
+// Delete removes a watchlist item. found is false if no item has the ID.
+func (r *WatchlistRepository) Delete(ctx context.Context, id int) (bool, error) {
+	result, err := r.db.Exec(ctx, `DELETE FROM watchlist WHERE id = $1`, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete watchlist item: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
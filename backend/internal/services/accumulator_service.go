@@ -2,14 +2,20 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"maps"
 	"math"
 	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/dEnchanter/OddsIQ/backend/config"
+	"github.com/dEnchanter/OddsIQ/backend/internal/logging"
 	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+	"github.com/dEnchanter/OddsIQ/backend/internal/repository"
 )
 
 // AccumulatorLeg represents a single leg in an accumulator
@@ -37,20 +43,100 @@ type Accumulator struct {
 	SuggestedStake    float64          `json:"suggested_stake"`
 	PotentialReturn   float64          `json:"potential_return"`
 	Confidence        string           `json:"confidence"`
+	StakeAdjusted     bool             `json:"stake_adjusted"` // Stake raised to the configured minimum
 	GeneratedAt       time.Time        `json:"generated_at"`
 }
 
 // AccumulatorConfig holds configuration for accumulator generation
 type AccumulatorConfig struct {
-	MinLegs              int     // Minimum legs (default 2)
-	MaxLegs              int     // Maximum legs (default 3)
-	MinEVThreshold       float64 // Minimum EV for accumulators (default 5%)
-	MinLegEV             float64 // Minimum EV per leg (default 0%)
-	MinLegProbability    float64 // Minimum probability per leg (default 40%)
-	KellyFraction        float64 // Kelly fraction for accumulators (default 1/8)
-	MaxStakePercent      float64 // Max % of bankroll on accumulators (default 20%)
-	AllowSameTeam        bool    // Allow same team in different fixtures
-	AllowSameFixture     bool    // Allow multiple markets from same fixture (default false)
+	MinLegs           int     `json:"min_legs"`            // Minimum legs (default 2)
+	MaxLegs           int     `json:"max_legs"`            // Maximum legs (default 3)
+	MinEVThreshold    float64 `json:"min_ev_threshold"`    // Minimum EV for accumulators (default 5%)
+	MinLegEV          float64 `json:"min_leg_ev"`          // Minimum EV per leg (default 0%)
+	MinLegProbability float64 `json:"min_leg_probability"` // Minimum probability per leg (default 40%)
+	KellyFraction     float64 `json:"kelly_fraction"`      // Kelly fraction for accumulators (default 1/8)
+	MaxStakePercent   float64 `json:"max_stake_percent"`   // Max % of bankroll on accumulators (default 20%)
+	AllowSameTeam     bool    `json:"allow_same_team"`     // Allow same team in different fixtures
+	AllowSameFixture  bool    `json:"allow_same_fixture"`  // Allow multiple markets from same fixture (default false)
+	MaxCombinations   int     `json:"max_combinations"`    // Max combinations evaluated per leg count (default 10000)
+	MaxLegPool        int     `json:"max_leg_pool"`        // Max legs, best EV first, considered for combining (default 20)
+	DedupeSubsets     bool    `json:"dedupe_subsets"`      // Drop accumulators whose legs all appear in a better one (default true)
+
+	// Correlation adjustment prices same-fixture legs as correlated instead of independent.
+	// Only has an effect with AllowSameFixture, since other legs are never combined.
+	CorrelationAdjustment bool               `json:"correlation_adjustment"` // Adjust combined probability for leg correlation (default false)
+	LegCorrelations       map[string]float64 `json:"leg_correlations"`       // Overrides keyed "market:outcome|market:outcome"; defaults to DefaultLegCorrelations
+
+	// Safe mode favours likely accumulators over high-EV ones
+	SafeMinLegProbability float64 `json:"safe_min_leg_probability"` // Minimum probability per leg (default 65%)
+	SafeMinCombinedOdds   float64 `json:"safe_min_combined_odds"`   // Minimum combined odds (default 2.0)
+	SafeKellyFraction     float64 `json:"safe_kelly_fraction"`      // Kelly fraction for safe accumulators (default 1/16)
+}
+
+// maxAccumulatorLegs is the largest MaxLegs a configuration may set
+const maxAccumulatorLegs = 8
+
+// Validate checks that the configuration is usable
+func (c AccumulatorConfig) Validate() error {
+	var errs []error
+
+	if c.MinLegs < 2 {
+		errs = append(errs, fmt.Errorf("min_legs must be at least 2, got %d", c.MinLegs))
+	}
+	if c.MaxLegs < c.MinLegs || c.MaxLegs > maxAccumulatorLegs {
+		errs = append(errs, fmt.Errorf("max_legs must be between min_legs and %d, got %d", maxAccumulatorLegs, c.MaxLegs))
+	}
+	if c.MinEVThreshold < 0 {
+		errs = append(errs, fmt.Errorf("min_ev_threshold must be >= 0, got %v", c.MinEVThreshold))
+	}
+	if c.MinLegEV < 0 {
+		errs = append(errs, fmt.Errorf("min_leg_ev must be >= 0, got %v", c.MinLegEV))
+	}
+	if c.MinLegProbability < 0 || c.MinLegProbability > 1 {
+		errs = append(errs, fmt.Errorf("min_leg_probability must be in [0, 1], got %v", c.MinLegProbability))
+	}
+	if c.KellyFraction <= 0 || c.KellyFraction > 1 {
+		errs = append(errs, fmt.Errorf("kelly_fraction must be in (0, 1], got %v", c.KellyFraction))
+	}
+	if c.MaxStakePercent <= 0 || c.MaxStakePercent > 1 {
+		errs = append(errs, fmt.Errorf("max_stake_percent must be in (0, 1], got %v", c.MaxStakePercent))
+	}
+	if c.MaxCombinations < 0 {
+		errs = append(errs, fmt.Errorf("max_combinations must be >= 0, got %d", c.MaxCombinations))
+	}
+	if c.MaxLegPool < 0 {
+		errs = append(errs, fmt.Errorf("max_leg_pool must be >= 0, got %d", c.MaxLegPool))
+	}
+	if c.SafeMinLegProbability < 0 || c.SafeMinLegProbability > 1 {
+		errs = append(errs, fmt.Errorf("safe_min_leg_probability must be in [0, 1], got %v", c.SafeMinLegProbability))
+	}
+	if c.SafeMinCombinedOdds < 0 {
+		errs = append(errs, fmt.Errorf("safe_min_combined_odds must be >= 0, got %v", c.SafeMinCombinedOdds))
+	}
+	if c.SafeKellyFraction <= 0 || c.SafeKellyFraction > 1 {
+		errs = append(errs, fmt.Errorf("safe_kelly_fraction must be in (0, 1], got %v", c.SafeKellyFraction))
+	}
+	for pair, rho := range c.LegCorrelations {
+		if rho < -1 || rho > 1 {
+			errs = append(errs, fmt.Errorf("leg_correlations[%s] must be in [-1, 1], got %v", pair, rho))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// DefaultLegCorrelations are heuristic correlation coefficients between outcomes of the
+// same fixture, keyed "market:outcome|market:outcome". Pairs not listed are treated as
+// independent.
+var DefaultLegCorrelations = map[string]float64{
+	"over_under:over_2_5|btts:yes":  0.45,
+	"over_under:under_2_5|btts:no":  0.45,
+	"over_under:over_2_5|btts:no":   -0.45,
+	"over_under:under_2_5|btts:yes": -0.45,
+	"1x2:draw|over_under:under_2_5": 0.25,
+	"1x2:draw|over_under:over_2_5":  -0.25,
+	"1x2:home_win|btts:no":          0.10,
+	"1x2:away_win|btts:no":          0.10,
 }
 
 // DefaultAccumulatorConfig returns default configuration
@@ -65,44 +151,126 @@ func DefaultAccumulatorConfig() AccumulatorConfig {
 		MaxStakePercent:   0.20,  // Max 20% of bankroll on accumulators
 		AllowSameTeam:     false, // Don't allow same team
 		AllowSameFixture:  false, // Don't allow same fixture
+		MaxCombinations:   10000, // Keeps 4- and 5-leg generation bounded
+		MaxLegPool:        20,    // Only combine the 20 best legs
+		DedupeSubsets:     true,  // Recommend distinct slips
+
+		SafeMinLegProbability: 0.65,   // Safe mode: only legs at 65%+
+		SafeMinCombinedOdds:   2.0,    // Safe mode: still at least double the stake
+		SafeKellyFraction:     0.0625, // Safe mode: 1/16 Kelly
 	}
 }
 
+// accumulatorConfigSetting is the app_settings key the accumulator configuration is stored under
+const accumulatorConfigSetting = "accumulator_config"
+
 // AccumulatorService handles accumulator generation and calculations
 type AccumulatorService struct {
 	bettingService *BettingService
 	config         *config.Config
-	accConfig      AccumulatorConfig
+	settingsRepo   *repository.SettingsRepository // optional, persists configuration changes
+
+	// accConfig can be replaced while requests are generating accumulators, so it's only
+	// read through currentConfig. Generation runs on a copy of the service holding its
+	// own config (see withConfig), so a run never sees a change part way through.
+	accConfig   AccumulatorConfig
+	accConfigMu sync.RWMutex
 }
 
-// NewAccumulatorService creates a new accumulator service
+// NewAccumulatorService creates a new accumulator service using the default
+// configuration until LoadConfig applies a stored one
 func NewAccumulatorService(
 	bettingService *BettingService,
 	cfg *config.Config,
+	settingsRepo *repository.SettingsRepository,
 ) *AccumulatorService {
 	return &AccumulatorService{
 		bettingService: bettingService,
 		config:         cfg,
+		settingsRepo:   settingsRepo,
 		accConfig:      DefaultAccumulatorConfig(),
 	}
 }
 
 // SetConfig updates the accumulator configuration
 func (s *AccumulatorService) SetConfig(cfg AccumulatorConfig) {
+	cfg.LegCorrelations = maps.Clone(cfg.LegCorrelations) // Not shared with the caller
+
+	s.accConfigMu.Lock()
 	s.accConfig = cfg
+	s.accConfigMu.Unlock()
+}
+
+// Config returns the accumulator configuration in effect
+func (s *AccumulatorService) Config() AccumulatorConfig {
+	return s.currentConfig()
+}
+
+// currentConfig returns a snapshot of the accumulator configuration. LegCorrelations
+// is never modified once set, so the snapshot may share it.
+func (s *AccumulatorService) currentConfig() AccumulatorConfig {
+	s.accConfigMu.RLock()
+	defer s.accConfigMu.RUnlock()
+	return s.accConfig
+}
+
+// LoadConfig applies the stored accumulator configuration, if one has been saved.
+// Settings missing from the stored document keep their defaults.
+func (s *AccumulatorService) LoadConfig(ctx context.Context) error {
+	if s.settingsRepo == nil {
+		return nil
+	}
+
+	value, found, err := s.settingsRepo.Get(ctx, accumulatorConfigSetting)
+	if err != nil || !found {
+		return err
+	}
+
+	cfg := DefaultAccumulatorConfig()
+	if err := json.Unmarshal(value, &cfg); err != nil {
+		return fmt.Errorf("failed to parse stored accumulator config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid stored accumulator config: %w", err)
+	}
+
+	s.SetConfig(cfg)
+	return nil
+}
+
+// UpdateConfig validates and stores a new accumulator configuration, then applies it
+func (s *AccumulatorService) UpdateConfig(ctx context.Context, cfg AccumulatorConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	if s.settingsRepo != nil {
+		value, err := json.Marshal(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to encode accumulator config: %w", err)
+		}
+		if err := s.settingsRepo.Set(ctx, accumulatorConfigSetting, value); err != nil {
+			return err
+		}
+	}
+
+	s.SetConfig(cfg)
+	return nil
 }
 
 // IsCorrelated checks if two legs are correlated and should not be combined
 func (s *AccumulatorService) IsCorrelated(leg1, leg2 AccumulatorLeg) bool {
+	cfg := s.currentConfig()
+
 	// Same fixture - always correlated
 	if leg1.FixtureID == leg2.FixtureID {
-		if !s.accConfig.AllowSameFixture {
+		if !cfg.AllowSameFixture {
 			return true
 		}
 	}
 
 	// Same team involved - potentially correlated
-	if !s.accConfig.AllowSameTeam {
+	if !cfg.AllowSameTeam {
 		// Check if same team appears in both fixtures
 		teams1 := []int{leg1.Fixture.HomeTeamID, leg1.Fixture.AwayTeamID}
 		teams2 := []int{leg2.Fixture.HomeTeamID, leg2.Fixture.AwayTeamID}
@@ -121,6 +289,8 @@ func (s *AccumulatorService) IsCorrelated(leg1, leg2 AccumulatorLeg) bool {
 
 // CalculateAccumulatorEV calculates EV for an accumulator
 // EV = (combined_probability × combined_odds) - 1
+// The combined probability is the product of leg probabilities, adjusted for leg
+// correlation when CorrelationAdjustment is enabled.
 func (s *AccumulatorService) CalculateAccumulatorEV(legs []AccumulatorLeg) (combinedProb, combinedOdds, ev float64) {
 	if len(legs) == 0 {
 		return 0, 0, -1
@@ -134,10 +304,64 @@ func (s *AccumulatorService) CalculateAccumulatorEV(legs []AccumulatorLeg) (comb
 		combinedOdds *= leg.Odds
 	}
 
+	if s.currentConfig().CorrelationAdjustment {
+		combinedProb = s.adjustForCorrelation(legs, combinedProb)
+	}
+
 	ev = (combinedProb * combinedOdds) - 1
 	return combinedProb, combinedOdds, ev
 }
 
+// adjustForCorrelation adjusts the independent combined probability of the legs for
+// pairwise correlation. For two legs with probabilities p1, p2 and correlation ρ, the
+// joint probability of two correlated Bernoulli events is
+//
+//	P(A and B) = p1·p2 + ρ·√(p1(1-p1)·p2(1-p2))
+//	           = p1·p2 · (1 + ρ·√((1-p1)(1-p2) / (p1·p2)))
+//
+// so each correlated pair scales the product by its factor in brackets. With more than
+// two legs the pair factors are multiplied together, which approximates the joint
+// probability. The result is clamped to [0, min p], since the accumulator can't be more
+// likely than its least likely leg.
+func (s *AccumulatorService) adjustForCorrelation(legs []AccumulatorLeg, independent float64) float64 {
+	combined := independent
+	minProb := 1.0
+	for i := range legs {
+		minProb = math.Min(minProb, legs[i].Probability)
+		for j := i + 1; j < len(legs); j++ {
+			rho := s.LegCorrelation(legs[i], legs[j])
+			p1, p2 := legs[i].Probability, legs[j].Probability
+			if rho == 0 || p1 <= 0 || p2 <= 0 {
+				continue
+			}
+			combined *= 1 + rho*math.Sqrt((1-p1)*(1-p2)/(p1*p2))
+		}
+	}
+
+	return math.Max(0, math.Min(combined, minProb))
+}
+
+// LegCorrelation returns the correlation coefficient between two legs. Legs from
+// different fixtures are independent; same-fixture pairs use LegCorrelations, falling
+// back to DefaultLegCorrelations.
+func (s *AccumulatorService) LegCorrelation(leg1, leg2 AccumulatorLeg) float64 {
+	if leg1.FixtureID != leg2.FixtureID {
+		return 0
+	}
+
+	correlations := s.currentConfig().LegCorrelations
+	if correlations == nil {
+		correlations = DefaultLegCorrelations
+	}
+
+	key1 := string(leg1.Market) + ":" + leg1.Outcome
+	key2 := string(leg2.Market) + ":" + leg2.Outcome
+	if rho, ok := correlations[key1+"|"+key2]; ok {
+		return rho
+	}
+	return correlations[key2+"|"+key1]
+}
+
 // CalculateAccumulatorKelly calculates Kelly stake for accumulator
 func (s *AccumulatorService) CalculateAccumulatorKelly(combinedProb, combinedOdds, bankroll float64) float64 {
 	b := combinedOdds - 1
@@ -149,12 +373,13 @@ func (s *AccumulatorService) CalculateAccumulatorKelly(combinedProb, combinedOdd
 	}
 
 	kellyFraction := (b*p - q) / b
+	cfg := s.currentConfig()
 
 	// Apply conservative Kelly fraction for accumulators
-	adjustedKelly := kellyFraction * s.accConfig.KellyFraction
+	adjustedKelly := kellyFraction * cfg.KellyFraction
 
 	// Cap at max accumulator stake percentage
-	maxStake := bankroll * s.accConfig.MaxStakePercent
+	maxStake := bankroll * cfg.MaxStakePercent
 	stake := adjustedKelly * bankroll
 
 	if stake > maxStake {
@@ -166,7 +391,7 @@ func (s *AccumulatorService) CalculateAccumulatorKelly(combinedProb, combinedOdd
 		return 0
 	}
 
-	return math.Round(stake*100) / 100
+	return stake
 }
 
 // GetConfidenceLevel returns confidence level based on EV
@@ -196,18 +421,25 @@ func (s *AccumulatorService) ConvertToLeg(outcome BetOutcome, fixture models.Fix
 
 // FilterLegsForAccumulator filters legs suitable for accumulator
 func (s *AccumulatorService) FilterLegsForAccumulator(picks []*MultiMarketPick) []AccumulatorLeg {
+	return s.filterLegs(picks, s.currentConfig().MinLegProbability)
+}
+
+// filterLegs returns value outcomes with at least minProbability and the minimum
+// leg EV as legs, sorted by EV (highest first)
+func (s *AccumulatorService) filterLegs(picks []*MultiMarketPick, minProbability float64) []AccumulatorLeg {
+	cfg := s.currentConfig()
 	var legs []AccumulatorLeg
 
 	for _, pick := range picks {
 		// Get all value outcomes from each pick
 		for _, outcome := range pick.ValueOutcomes {
 			// Filter by minimum probability
-			if outcome.Probability < s.accConfig.MinLegProbability {
+			if outcome.Probability < minProbability {
 				continue
 			}
 
 			// Filter by minimum leg EV
-			if outcome.EV < s.accConfig.MinLegEV {
+			if outcome.EV < cfg.MinLegEV {
 				continue
 			}
 
@@ -224,150 +456,402 @@ func (s *AccumulatorService) FilterLegsForAccumulator(picks []*MultiMarketPick)
 	return legs
 }
 
-// GenerateAccumulators generates optimal accumulators from available picks
+// AccumulatorMode selects what accumulator generation optimizes for
+type AccumulatorMode string
+
+const (
+	// AccumulatorModeValue maximizes expected value
+	AccumulatorModeValue AccumulatorMode = "value"
+	// AccumulatorModeSafe maximizes the chance of winning, among accumulators paying at
+	// least SafeMinCombinedOdds and built from legs of at least SafeMinLegProbability
+	AccumulatorModeSafe AccumulatorMode = "safe"
+)
+
+// ParseAccumulatorMode resolves a mode name, defaulting to value when empty
+func ParseAccumulatorMode(name string) (AccumulatorMode, bool) {
+	switch mode := AccumulatorMode(strings.ToLower(strings.TrimSpace(name))); mode {
+	case "":
+		return AccumulatorModeValue, true
+	case AccumulatorModeValue, AccumulatorModeSafe:
+		return mode, true
+	}
+	return "", false
+}
+
+// accumulatorObjective is what a generation mode ranks selections by. A selection's
+// score is the product of its legs' factors under independence.
+type accumulatorObjective struct {
+	legFactor func(leg AccumulatorLeg) float64
+	score     func(acc *Accumulator) float64
+	minScore  float64 // Accumulators scoring below this are dropped
+	minOdds   float64 // Combined odds floor (0 = none)
+}
+
+// objective returns the ranking used for a mode: combined return (1 + EV) for value,
+// combined probability for safe
+func (s *AccumulatorService) objective(mode AccumulatorMode) accumulatorObjective {
+	cfg := s.currentConfig()
+
+	if mode == AccumulatorModeSafe {
+		return accumulatorObjective{
+			legFactor: func(leg AccumulatorLeg) float64 { return leg.Probability },
+			score:     func(acc *Accumulator) float64 { return acc.CombinedProbability },
+			minOdds:   cfg.SafeMinCombinedOdds,
+		}
+	}
+
+	return accumulatorObjective{
+		legFactor: func(leg AccumulatorLeg) float64 { return leg.Probability * leg.Odds },
+		score:     func(acc *Accumulator) float64 { return 1 + acc.ExpectedValue },
+		minScore:  1 + cfg.MinEVThreshold,
+	}
+}
+
+// GenerateAccumulators generates optimal accumulators from available picks using cfg,
+// which may differ from the service's configuration for one-off runs
 func (s *AccumulatorService) GenerateAccumulators(
 	ctx context.Context,
 	bankroll float64,
 	maxAccumulators int,
+	window FixtureWindow,
+	cfg AccumulatorConfig,
+	mode AccumulatorMode,
 ) ([]*Accumulator, error) {
+	return s.withConfig(cfg).generateAccumulators(ctx, bankroll, maxAccumulators, window, mode)
+}
+
+// withConfig returns a copy of the service that uses cfg, leaving the service's own
+// configuration untouched. The copy is meant for a single run and is never reconfigured.
+func (s *AccumulatorService) withConfig(cfg AccumulatorConfig) *AccumulatorService {
+	return &AccumulatorService{
+		bettingService: s.bettingService,
+		config:         s.config,
+		settingsRepo:   s.settingsRepo,
+		accConfig:      cfg,
+	}
+}
+
+// generateAccumulators generates accumulators with the service's configuration
+func (s *AccumulatorService) generateAccumulators(
+	ctx context.Context,
+	bankroll float64,
+	maxAccumulators int,
+	window FixtureWindow,
+	mode AccumulatorMode,
+) ([]*Accumulator, error) {
+	cfg := s.currentConfig()
+
 	// Get multi-market picks
-	picks, err := s.bettingService.GetMultiMarketWeeklyPicks(ctx, bankroll)
+	picks, err := s.bettingService.GetMultiMarketWeeklyPicks(ctx, bankroll, window, s.bettingService.OddsRange())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get picks: %w", err)
 	}
 
-	if len(picks) < s.accConfig.MinLegs {
-		log.Printf("Not enough picks for accumulators: %d", len(picks))
+	if len(picks) < cfg.MinLegs {
+		logging.Printf(ctx, "Not enough picks for accumulators: %d", len(picks))
 		return []*Accumulator{}, nil
 	}
 
-	// Filter legs suitable for accumulator
-	allLegs := s.FilterLegsForAccumulator(picks)
+	// Filter legs suitable for accumulator. Safe accumulators only use likely legs,
+	// most likely first, and are staked with their own smaller Kelly fraction.
+	run := s
+	var allLegs []AccumulatorLeg
+	if mode == AccumulatorModeSafe {
+		allLegs = s.filterLegs(picks, math.Max(cfg.MinLegProbability, cfg.SafeMinLegProbability))
+		sort.SliceStable(allLegs, func(i, j int) bool {
+			return allLegs[i].Probability > allLegs[j].Probability
+		})
+
+		safeConfig := cfg
+		safeConfig.KellyFraction = cfg.SafeKellyFraction
+		run = s.withConfig(safeConfig)
+	} else {
+		allLegs = s.FilterLegsForAccumulator(picks)
+	}
 
-	if len(allLegs) < s.accConfig.MinLegs {
-		log.Printf("Not enough qualifying legs for accumulators: %d", len(allLegs))
+	if len(allLegs) < cfg.MinLegs {
+		logging.Printf(ctx, "Not enough qualifying legs for accumulators: %d", len(allLegs))
 		return []*Accumulator{}, nil
 	}
 
-	// Generate accumulators of different sizes
-	var accumulators []*Accumulator
-
-	// Generate 2-leg accumulators
-	if s.accConfig.MinLegs <= 2 && s.accConfig.MaxLegs >= 2 {
-		doubles := s.generateNLegAccumulators(allLegs, 2, bankroll)
-		accumulators = append(accumulators, doubles...)
+	// Legs are sorted best first, so weaker legs beyond the pool size are rarely worth combining
+	if cfg.MaxLegPool > 0 && len(allLegs) > cfg.MaxLegPool {
+		allLegs = allLegs[:cfg.MaxLegPool]
 	}
 
-	// Generate 3-leg accumulators
-	if s.accConfig.MinLegs <= 3 && s.accConfig.MaxLegs >= 3 && len(allLegs) >= 3 {
-		trebles := s.generateNLegAccumulators(allLegs, 3, bankroll)
-		accumulators = append(accumulators, trebles...)
+	return run.rankAccumulators(ctx, allLegs, bankroll, maxAccumulators, s.objective(mode)), nil
+}
+
+// rankAccumulators combines legs, sorted best first, into accumulators of each allowed
+// size and returns the best maxAccumulators by the objective
+func (s *AccumulatorService) rankAccumulators(ctx context.Context, allLegs []AccumulatorLeg, bankroll float64, maxAccumulators int, objective accumulatorObjective) []*Accumulator {
+	cfg := s.currentConfig()
+
+	// Generate accumulators of each size, combining only the best legs when the
+	// full pool would exceed the combination cap. The cut-off is shared across sizes
+	// so selections that can't make the final list are pruned early. Deduplication
+	// only drops subsets of accumulators it keeps, so it can't push the last one kept
+	// below maxAccumulators * (1 + subsets per accumulator) candidates.
+	var accumulators []*Accumulator
+	cutoff := &scoreCutoff{limit: maxAccumulators, min: objective.minScore}
+	if cfg.DedupeSubsets {
+		cutoff.limit = maxAccumulators * (1 + properSubsets(cfg.MinLegs, cfg.MaxLegs))
+	}
+	for n := cfg.MinLegs; n <= cfg.MaxLegs && n <= len(allLegs); n++ {
+		poolSize := s.legPoolSize(len(allLegs), n)
+		if poolSize < len(allLegs) {
+			logging.Printf(ctx, "Limiting %d-leg accumulators to the top %d of %d legs", n, poolSize, len(allLegs))
+		}
+		accumulators = append(accumulators, s.generateNLegAccumulators(allLegs[:poolSize], n, bankroll, objective, cutoff)...)
 	}
 
-	// Sort by EV
+	// Sort by score (EV, or probability for safe accumulators)
 	sort.Slice(accumulators, func(i, j int) bool {
-		return accumulators[i].ExpectedValue > accumulators[j].ExpectedValue
+		return objective.score(accumulators[i]) > objective.score(accumulators[j])
 	})
 
-	// Filter by minimum EV threshold
+	// Filter by minimum score and odds
 	var filtered []*Accumulator
 	for _, acc := range accumulators {
-		if acc.ExpectedValue >= s.accConfig.MinEVThreshold {
+		if objective.score(acc) >= objective.minScore && acc.CombinedOdds >= objective.minOdds {
 			filtered = append(filtered, acc)
 		}
 	}
 
+	if cfg.DedupeSubsets {
+		filtered = removeSubsetAccumulators(filtered)
+	}
+
 	// Limit to max accumulators
 	if len(filtered) > maxAccumulators {
 		filtered = filtered[:maxAccumulators]
 	}
 
-	return filtered, nil
+	return filtered
 }
 
-// generateNLegAccumulators generates all valid N-leg accumulators
-func (s *AccumulatorService) generateNLegAccumulators(legs []AccumulatorLeg, n int, bankroll float64) []*Accumulator {
+// generateNLegAccumulators generates valid N-leg accumulators by extending selections
+// one leg at a time. A selection is abandoned as soon as it contains correlated legs, or
+// when even the best remaining legs couldn't lift its score to the cutoff or its
+// combined odds to the objective's floor.
+func (s *AccumulatorService) generateNLegAccumulators(legs []AccumulatorLeg, n int, bankroll float64, objective accumulatorObjective, cutoff *scoreCutoff) []*Accumulator {
 	if len(legs) < n {
 		return nil
 	}
+	pruneByScore := !s.currentConfig().CorrelationAdjustment
+
+	// bestFactor[i] and bestOdds[i] are the highest leg factor and odds of any leg from
+	// i on, bounding how much the remaining legs can multiply a partial selection's
+	// score and odds
+	bestFactor := make([]float64, len(legs)+1)
+	bestOdds := make([]float64, len(legs)+1)
+	for i := len(legs) - 1; i >= 0; i-- {
+		bestFactor[i] = math.Max(bestFactor[i+1], objective.legFactor(legs[i]))
+		bestOdds[i] = math.Max(bestOdds[i+1], legs[i].Odds)
+	}
 
 	var accumulators []*Accumulator
+	selected := make([]AccumulatorLeg, 0, n)
+
+	var extend func(start int, factor, odds float64)
+	extend = func(start int, factor, odds float64) {
+		if len(selected) == n {
+			if acc := s.buildAccumulator(selected, bankroll, len(accumulators)+1); acc != nil {
+				accumulators = append(accumulators, acc)
+				if acc.CombinedOdds >= objective.minOdds {
+					cutoff.add(objective.score(acc))
+				}
+			}
+			return
+		}
 
-	// Generate combinations
-	combinations := s.generateCombinations(len(legs), n)
+		remaining := float64(n - len(selected))
+		for i := start; i <= len(legs)-(n-len(selected)); i++ {
+			// Both bounds only fall with i, so no later leg can do better either. The
+			// score bound assumes independent legs, so it isn't used when adjusting for
+			// correlation.
+			if pruneByScore && factor*math.Pow(bestFactor[i], remaining) < cutoff.threshold() {
+				return
+			}
+			if odds*math.Pow(bestOdds[i], remaining) < objective.minOdds {
+				return
+			}
+			if s.correlatesWith(selected, legs[i]) {
+				continue
+			}
 
-	for _, combo := range combinations {
-		selectedLegs := make([]AccumulatorLeg, n)
-		for i, idx := range combo {
-			selectedLegs[i] = legs[idx]
+			selected = append(selected, legs[i])
+			extend(i+1, factor*objective.legFactor(legs[i]), odds*legs[i].Odds)
+			selected = selected[:len(selected)-1]
 		}
+	}
+	extend(0, 1, 1)
 
-		// Check for correlations
-		if s.hasCorrelation(selectedLegs) {
-			continue
-		}
+	return accumulators
+}
 
-		// Calculate accumulator metrics
-		combinedProb, combinedOdds, ev := s.CalculateAccumulatorEV(selectedLegs)
-		stake := s.CalculateAccumulatorKelly(combinedProb, combinedOdds, bankroll)
+// buildAccumulator prices and stakes a selection of legs, returning nil when it
+// doesn't warrant a stake
+func (s *AccumulatorService) buildAccumulator(selected []AccumulatorLeg, bankroll float64, seq int) *Accumulator {
+	combinedProb, combinedOdds, ev := s.CalculateAccumulatorEV(selected)
+	stake := s.CalculateAccumulatorKelly(combinedProb, combinedOdds, bankroll)
 
-		if stake <= 0 {
-			continue
+	if stake <= 0 {
+		return nil
+	}
+
+	stake, stakeAdjusted, keep := normalizeStake(s.config, stake)
+	if !keep {
+		return nil
+	}
+
+	legs := make([]AccumulatorLeg, len(selected))
+	copy(legs, selected)
+
+	return &Accumulator{
+		ID:                  fmt.Sprintf("acc_%d_%d", len(legs), seq),
+		Legs:                legs,
+		NumLegs:             len(legs),
+		CombinedProbability: combinedProb,
+		CombinedOdds:        math.Round(combinedOdds*100) / 100,
+		ExpectedValue:       ev,
+		EVPercent:           ev * 100,
+		SuggestedStake:      stake,
+		PotentialReturn:     math.Round(stake*combinedOdds*100) / 100,
+		Confidence:          s.GetConfidenceLevel(ev),
+		StakeAdjusted:       stakeAdjusted,
+		GeneratedAt:         time.Now(),
+	}
+}
+
+// removeSubsetAccumulators drops accumulators whose legs are all part of a
+// higher-ranked accumulator kept before them. accumulators must be sorted best first.
+func removeSubsetAccumulators(accumulators []*Accumulator) []*Accumulator {
+	var kept []*Accumulator
+	var keptLegs []map[string]bool
+
+	for _, acc := range accumulators {
+		legs := make(map[string]bool, len(acc.Legs))
+		for _, leg := range acc.Legs {
+			legs[legKey(leg)] = true
 		}
 
-		acc := &Accumulator{
-			ID:                  fmt.Sprintf("acc_%d_%d", n, len(accumulators)+1),
-			Legs:                selectedLegs,
-			NumLegs:             n,
-			CombinedProbability: combinedProb,
-			CombinedOdds:        math.Round(combinedOdds*100) / 100,
-			ExpectedValue:       ev,
-			EVPercent:           ev * 100,
-			SuggestedStake:      stake,
-			PotentialReturn:     math.Round(stake*combinedOdds*100) / 100,
-			Confidence:          s.GetConfidenceLevel(ev),
-			GeneratedAt:         time.Now(),
+		subset := false
+		for _, other := range keptLegs {
+			if isSubset(legs, other) {
+				subset = true
+				break
+			}
+		}
+		if subset {
+			continue
 		}
 
-		accumulators = append(accumulators, acc)
+		kept = append(kept, acc)
+		keptLegs = append(keptLegs, legs)
 	}
 
-	return accumulators
+	return kept
 }
 
-// hasCorrelation checks if any pair of legs in the selection are correlated
-func (s *AccumulatorService) hasCorrelation(legs []AccumulatorLeg) bool {
-	for i := 0; i < len(legs); i++ {
-		for j := i + 1; j < len(legs); j++ {
-			if s.IsCorrelated(legs[i], legs[j]) {
-				return true
-			}
+// legKey identifies a leg by its fixture, market and outcome
+func legKey(leg AccumulatorLeg) string {
+	return fmt.Sprintf("%d:%s:%s", leg.FixtureID, leg.Market, leg.Outcome)
+}
+
+// isSubset reports whether every key in a is also in b
+func isSubset(a, b map[string]bool) bool {
+	if len(a) > len(b) {
+		return false
+	}
+	for key := range a {
+		if !b[key] {
+			return false
+		}
+	}
+	return true
+}
+
+// correlatesWith checks if a leg is correlated with any already selected leg
+func (s *AccumulatorService) correlatesWith(selected []AccumulatorLeg, leg AccumulatorLeg) bool {
+	for _, other := range selected {
+		if s.IsCorrelated(other, leg) {
+			return true
 		}
 	}
 	return false
 }
 
-// generateCombinations generates all combinations of n items from total
-func (s *AccumulatorService) generateCombinations(total, n int) [][]int {
-	var result [][]int
-	combo := make([]int, n)
+// scoreCutoff tracks the best accumulator scores found so far, giving the score a new
+// accumulator must reach to make the final selection
+type scoreCutoff struct {
+	limit int       // Number of accumulators returned (0 = no limit)
+	min   float64   // Minimum score
+	best  []float64 // Best scores found, highest first, at most limit
+}
+
+// threshold returns the score to beat: the limit-th best score once that many have
+// been found, or the minimum score if higher
+func (c *scoreCutoff) threshold() float64 {
+	if c.limit > 0 && len(c.best) == c.limit && c.best[c.limit-1] > c.min {
+		return c.best[c.limit-1]
+	}
+	return c.min
+}
 
-	var generate func(start, idx int)
-	generate = func(start, idx int) {
-		if idx == n {
-			// Make a copy
-			c := make([]int, n)
-			copy(c, combo)
-			result = append(result, c)
-			return
-		}
-		for i := start; i <= total-n+idx; i++ {
-			combo[idx] = i
-			generate(i+1, idx+1)
-		}
+// add records an accumulator's score
+func (c *scoreCutoff) add(score float64) {
+	if c.limit <= 0 {
+		return
+	}
+
+	i := sort.Search(len(c.best), func(i int) bool { return c.best[i] < score })
+	if i >= c.limit {
+		return
+	}
+	c.best = append(c.best, 0)
+	copy(c.best[i+1:], c.best[i:])
+	c.best[i] = score
+	if len(c.best) > c.limit {
+		c.best = c.best[:c.limit]
+	}
+}
+
+// legPoolSize returns how many of the EV-sorted legs can be combined n at a time
+// without exceeding MaxCombinations (0 means no cap)
+func (s *AccumulatorService) legPoolSize(total, n int) int {
+	maxCombinations := s.currentConfig().MaxCombinations
+	if maxCombinations <= 0 {
+		return total
+	}
+
+	size := n
+	for size < total && binomial(size+1, n) <= float64(maxCombinations) {
+		size++
+	}
+	return size
+}
+
+// properSubsets returns how many accumulators of minLegs or more legs can be made from
+// the legs of a maxLegs-leg accumulator, excluding the accumulator itself
+func properSubsets(minLegs, maxLegs int) int {
+	subsets := 0.0
+	for n := minLegs; n < maxLegs; n++ {
+		subsets += binomial(maxLegs, n)
+	}
+	return int(subsets)
+}
+
+// binomial returns C(total, n), the number of ways to choose n items from total
+func binomial(total, n int) float64 {
+	if n < 0 || n > total {
+		return 0
 	}
 
-	generate(0, 0)
+	result := 1.0
+	for i := 1; i <= n; i++ {
+		result = result * float64(total-n+i) / float64(i)
+	}
 	return result
 }
 
@@ -376,11 +860,13 @@ type AccumulatorSummary struct {
 	TotalAccumulators   int     `json:"total_accumulators"`
 	TotalDoubles        int     `json:"total_doubles"`
 	TotalTrebles        int     `json:"total_trebles"`
+	ByLegs              map[int]int `json:"by_legs"` // Accumulator count per number of legs
 	TotalSuggestedStake float64 `json:"total_suggested_stake"`
 	TotalPotentialReturn float64 `json:"total_potential_return"`
 	AverageEV           float64 `json:"average_ev"`
 	BestEV              float64 `json:"best_ev"`
 	Bankroll            float64 `json:"bankroll"`
+	Currency            string  `json:"currency"`
 	MaxStakeAllocation  float64 `json:"max_stake_allocation"`
 }
 
@@ -389,7 +875,9 @@ func (s *AccumulatorService) GetAccumulatorSummary(accumulators []*Accumulator,
 	summary := &AccumulatorSummary{
 		TotalAccumulators:  len(accumulators),
 		Bankroll:           bankroll,
-		MaxStakeAllocation: bankroll * s.accConfig.MaxStakePercent,
+		Currency:           s.config.StakeCurrency,
+		MaxStakeAllocation: bankroll * s.currentConfig().MaxStakePercent,
+		ByLegs:             make(map[int]int),
 	}
 
 	if len(accumulators) == 0 {
@@ -402,11 +890,7 @@ func (s *AccumulatorService) GetAccumulatorSummary(accumulators []*Accumulator,
 		summary.TotalPotentialReturn += acc.PotentialReturn
 		totalEV += acc.ExpectedValue
 
-		if acc.NumLegs == 2 {
-			summary.TotalDoubles++
-		} else if acc.NumLegs == 3 {
-			summary.TotalTrebles++
-		}
+		summary.ByLegs[acc.NumLegs]++
 
 		if acc.ExpectedValue > summary.BestEV {
 			summary.BestEV = acc.ExpectedValue
@@ -414,6 +898,8 @@ func (s *AccumulatorService) GetAccumulatorSummary(accumulators []*Accumulator,
 	}
 
 	summary.AverageEV = totalEV / float64(len(accumulators))
+	summary.TotalDoubles = summary.ByLegs[2]
+	summary.TotalTrebles = summary.ByLegs[3]
 
 	// Cap total stake at max allocation
 	if summary.TotalSuggestedStake > summary.MaxStakeAllocation {
@@ -431,23 +917,188 @@ type WeeklyAccumulatorPicks struct {
 	Accumulators []*Accumulator     `json:"accumulators"`
 	Summary      *AccumulatorSummary `json:"summary"`
 	Config       AccumulatorConfig   `json:"config"`
+	Mode         AccumulatorMode     `json:"mode"`
+	Window       FixtureWindow       `json:"window"`
 	GeneratedAt  time.Time          `json:"generated_at"`
 }
 
-// GetWeeklyAccumulators generates weekly accumulator recommendations
-func (s *AccumulatorService) GetWeeklyAccumulators(ctx context.Context, bankroll float64) (*WeeklyAccumulatorPicks, error) {
+// GetWeeklyAccumulators generates weekly accumulator recommendations using cfg and mode
+func (s *AccumulatorService) GetWeeklyAccumulators(ctx context.Context, bankroll float64, window FixtureWindow, cfg AccumulatorConfig, mode AccumulatorMode) (*WeeklyAccumulatorPicks, error) {
+	generator := s.withConfig(cfg)
+
 	// Generate up to 3 accumulators (2 doubles + 1 treble recommended)
-	accumulators, err := s.GenerateAccumulators(ctx, bankroll, 5)
+	accumulators, err := generator.generateAccumulators(ctx, bankroll, 5, window, mode)
 	if err != nil {
 		return nil, err
 	}
 
-	summary := s.GetAccumulatorSummary(accumulators, bankroll)
+	summary := generator.GetAccumulatorSummary(accumulators, bankroll)
 
 	return &WeeklyAccumulatorPicks{
 		Accumulators: accumulators,
 		Summary:      summary,
-		Config:       s.accConfig,
+		Config:       cfg,
+		Mode:         mode,
+		Window:       window,
 		GeneratedAt:  time.Now(),
 	}, nil
 }
+
+// AccumulatorSelection is a leg chosen by the user for an accumulator
+type AccumulatorSelection struct {
+	FixtureID int        `json:"fixture_id"`
+	Market    MarketType `json:"market"`
+	Outcome   string     `json:"outcome"` // ML outcome key, e.g. "home_win", "over_2_5"
+}
+
+// EvaluatedLeg is a user-selected leg priced with the current best odds and model probability
+type EvaluatedLeg struct {
+	AccumulatorLeg
+	Available      bool   `json:"available"`                 // Both odds and a model probability were found
+	Issue          string `json:"issue,omitempty"`           // Why the leg couldn't be priced
+	CorrelatedWith []int  `json:"correlated_with,omitempty"` // Indexes of legs this one is correlated with
+}
+
+// AccumulatorEvaluation prices a user-specified accumulator. Combined figures are only
+// set when every leg could be priced.
+type AccumulatorEvaluation struct {
+	Legs                []EvaluatedLeg `json:"legs"`
+	NumLegs             int            `json:"num_legs"`
+	Complete            bool           `json:"complete"`   // Every leg has odds and a model probability
+	Correlated          bool           `json:"correlated"` // Some legs are correlated under the accumulator rules
+	CombinedProbability float64        `json:"combined_probability"`
+	CombinedOdds        float64        `json:"combined_odds"`
+	ExpectedValue       float64        `json:"expected_value"`
+	EVPercent           float64        `json:"ev_percent"`
+	SuggestedStake      float64        `json:"suggested_stake"`
+	PotentialReturn     float64        `json:"potential_return"`
+	Confidence          string         `json:"confidence,omitempty"`
+	StakeAdjusted       bool           `json:"stake_adjusted"`
+	EvaluatedAt         time.Time      `json:"evaluated_at"`
+}
+
+// fixtureEvaluation is a fixture evaluated once for every selected leg on it
+type fixtureEvaluation struct {
+	fixture *models.Fixture
+	pick    *MultiMarketPick
+	issue   string
+}
+
+// EvaluateAccumulator prices a user-specified accumulator with each leg's current best
+// odds and model probability, flagging legs that can't be priced or are correlated
+func (s *AccumulatorService) EvaluateAccumulator(ctx context.Context, selections []AccumulatorSelection, bankroll float64) *AccumulatorEvaluation {
+	s = s.withConfig(s.currentConfig()) // Price every leg with the same configuration
+
+	result := &AccumulatorEvaluation{
+		Legs:        make([]EvaluatedLeg, len(selections)),
+		NumLegs:     len(selections),
+		Complete:    true,
+		EvaluatedAt: time.Now(),
+	}
+
+	evaluations := make(map[int]*fixtureEvaluation)
+	for i, selection := range selections {
+		eval, ok := evaluations[selection.FixtureID]
+		if !ok {
+			eval = s.evaluateSelectedFixture(ctx, selection.FixtureID, bankroll)
+			evaluations[selection.FixtureID] = eval
+		}
+
+		leg := EvaluatedLeg{AccumulatorLeg: AccumulatorLeg{
+			FixtureID:   selection.FixtureID,
+			Market:      selection.Market,
+			Outcome:     selection.Outcome,
+			Description: GetOutcomeDescription(selection.Market, selection.Outcome),
+		}}
+		if eval.fixture != nil {
+			leg.Fixture = *eval.fixture
+		}
+
+		switch outcome := findOutcome(eval.pick, selection.Market, selection.Outcome); {
+		case eval.issue != "":
+			leg.Issue = eval.issue
+		case outcome == nil:
+			leg.Issue = "no prediction available for this outcome"
+		case outcome.Bookmaker == "synthetic":
+			leg.Probability = outcome.Probability
+			leg.Issue = "no odds available"
+		default:
+			leg.AccumulatorLeg = s.ConvertToLeg(*outcome, *eval.fixture)
+			leg.Available = true
+		}
+
+		if !leg.Available {
+			result.Complete = false
+		}
+		result.Legs[i] = leg
+	}
+
+	// Flag correlated pairs among legs whose fixture is known
+	for i := range result.Legs {
+		for j := i + 1; j < len(result.Legs); j++ {
+			if result.Legs[i].Fixture.ID == 0 || result.Legs[j].Fixture.ID == 0 {
+				continue
+			}
+			if s.IsCorrelated(result.Legs[i].AccumulatorLeg, result.Legs[j].AccumulatorLeg) {
+				result.Legs[i].CorrelatedWith = append(result.Legs[i].CorrelatedWith, j)
+				result.Legs[j].CorrelatedWith = append(result.Legs[j].CorrelatedWith, i)
+				result.Correlated = true
+			}
+		}
+	}
+
+	if !result.Complete || len(result.Legs) == 0 {
+		return result
+	}
+
+	legs := make([]AccumulatorLeg, len(result.Legs))
+	for i, leg := range result.Legs {
+		legs[i] = leg.AccumulatorLeg
+	}
+
+	combinedProb, combinedOdds, ev := s.CalculateAccumulatorEV(legs)
+	stake, stakeAdjusted, keep := normalizeStake(s.config, s.CalculateAccumulatorKelly(combinedProb, combinedOdds, bankroll))
+	if !keep {
+		stake = 0
+	}
+
+	result.CombinedProbability = combinedProb
+	result.CombinedOdds = math.Round(combinedOdds*100) / 100
+	result.ExpectedValue = ev
+	result.EVPercent = ev * 100
+	result.SuggestedStake = stake
+	result.PotentialReturn = math.Round(stake*combinedOdds*100) / 100
+	result.Confidence = s.GetConfidenceLevel(ev)
+	result.StakeAdjusted = stakeAdjusted
+
+	return result
+}
+
+// evaluateSelectedFixture loads and evaluates a fixture, recording why it couldn't be
+func (s *AccumulatorService) evaluateSelectedFixture(ctx context.Context, fixtureID int, bankroll float64) *fixtureEvaluation {
+	fixture, err := s.bettingService.fixturesRepo.GetByID(ctx, fixtureID)
+	if err != nil {
+		return &fixtureEvaluation{issue: "fixture not found"}
+	}
+
+	pick, err := s.bettingService.EvaluateFixture(ctx, fixture, bankroll, false, nil)
+	if err != nil {
+		logging.Printf(ctx, "Failed to evaluate fixture %d for accumulator: %v", fixtureID, err)
+		return &fixtureEvaluation{fixture: fixture, issue: "no prediction available"}
+	}
+
+	return &fixtureEvaluation{fixture: fixture, pick: pick}
+}
+
+// findOutcome returns the evaluated outcome for a market and outcome key, or nil
+func findOutcome(pick *MultiMarketPick, market MarketType, outcome string) *BetOutcome {
+	if pick == nil {
+		return nil
+	}
+	for i := range pick.AllOutcomes {
+		if pick.AllOutcomes[i].Market == market && pick.AllOutcomes[i].Outcome == outcome {
+			return &pick.AllOutcomes[i]
+		}
+	}
+	return nil
+}
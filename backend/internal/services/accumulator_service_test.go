@@ -0,0 +1,256 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/dEnchanter/OddsIQ/backend/config"
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+)
+
+// newTestAccumulatorService returns an accumulator service with the default configuration
+func newTestAccumulatorService() *AccumulatorService {
+	cfg := &config.Config{KellyFraction: 0.25, MaxBetPercentage: 0.05, StakeRounding: 0.01}
+	return NewAccumulatorService(nil, cfg, nil)
+}
+
+// newTestLegs returns n +EV legs from different fixtures and teams, best first
+func newTestLegs(n int) []AccumulatorLeg {
+	legs := make([]AccumulatorLeg, n)
+	for i := range legs {
+		fixture := models.Fixture{ID: i + 1, HomeTeamID: 2*i + 1, AwayTeamID: 2*i + 2}
+		legs[i] = AccumulatorLeg{
+			FixtureID:   fixture.ID,
+			Fixture:     fixture,
+			Market:      MarketType1X2,
+			Outcome:     "home_win",
+			Probability: 0.60 - float64(i)*0.002,
+			Odds:        1.95,
+		}
+		legs[i].SingleEV = legs[i].Probability*legs[i].Odds - 1
+	}
+	return legs
+}
+
+func BenchmarkGenerateNLegAccumulators_40LegPool(b *testing.B) {
+	s := newTestAccumulatorService()
+	legs := newTestLegs(40)
+	objective := s.objective(AccumulatorModeValue)
+
+	// pruned_top_10 prunes against the 10 best found so far, min_score_only only below
+	// the minimum score, and unpruned never prunes by score, as before the cut-off
+	cutoffs := []struct {
+		name  string
+		limit int
+		min   float64
+	}{
+		{"pruned_top_10", 10, objective.minScore},
+		{"min_score_only", 0, objective.minScore},
+		{"unpruned", 0, math.Inf(-1)},
+	}
+
+	for _, c := range cutoffs {
+		for _, n := range []int{2, 3, 4} {
+			b.Run(fmt.Sprintf("%d_legs_%s", n, c.name), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					cutoff := &scoreCutoff{limit: c.limit, min: c.min}
+					s.generateNLegAccumulators(legs, n, 1000, objective, cutoff)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkRankAccumulators_40LegPool(b *testing.B) {
+	s := newTestAccumulatorService()
+	legs := newTestLegs(40)
+	objective := s.objective(AccumulatorModeValue)
+
+	for _, dedupe := range []bool{true, false} {
+		cfg := DefaultAccumulatorConfig()
+		cfg.DedupeSubsets = dedupe
+		run := s.withConfig(cfg)
+
+		b.Run(fmt.Sprintf("dedupe_%v", dedupe), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				run.rankAccumulators(context.Background(), legs, 1000, 10, objective)
+			}
+		})
+	}
+}
+
+// accumulatorLegKeys returns each accumulator's legs as a comparable string
+func accumulatorLegKeys(accumulators []*Accumulator) []string {
+	keys := make([]string, len(accumulators))
+	for i, acc := range accumulators {
+		for _, leg := range acc.Legs {
+			keys[i] += legKey(leg) + " "
+		}
+	}
+	return keys
+}
+
+func TestRankAccumulators_DedupeSubsets_PrunedMatchesUnpruned(t *testing.T) {
+	s := newTestAccumulatorService()
+
+	// Two strong legs and six barely +EV ones: accumulators with both strong legs
+	// outrank the rest, and the doubles and trebles among them are subsets of a kept
+	// four-fold, so deduplication drops candidates from the top of the ranking
+	legs := newTestLegs(8)
+	for i := range legs {
+		legs[i].Odds = 2
+		legs[i].Probability = 0.506 - float64(i)*0.0005
+	}
+	legs[0].Probability, legs[1].Probability = 0.75, 0.74
+	for i := range legs {
+		legs[i].SingleEV = legs[i].Probability*legs[i].Odds - 1
+	}
+	objective := s.objective(AccumulatorModeValue)
+
+	for _, max := range []int{1, 10, 20} {
+		got := s.rankAccumulators(context.Background(), legs, 1000, max, objective)
+
+		// Far more than can be generated, so nothing is pruned by rank
+		all := s.rankAccumulators(context.Background(), legs, 1000, 1_000_000, objective)
+		expected := all[:max]
+
+		if !slices.Equal(accumulatorLegKeys(got), accumulatorLegKeys(expected)) {
+			t.Errorf("max %d: expected %v, got %v", max, accumulatorLegKeys(expected), accumulatorLegKeys(got))
+		}
+	}
+}
+
+func TestProperSubsets_CountsSmallerAccumulators(t *testing.T) {
+	// A 4-leg accumulator contains six doubles and four trebles
+	if got := properSubsets(2, 4); got != 10 {
+		t.Errorf("Expected 10, got %d", got)
+	}
+	if got := properSubsets(2, 2); got != 0 {
+		t.Errorf("Expected 0, got %d", got)
+	}
+}
+
+// Run with -race: generation reads the configuration while SetConfig replaces it
+func TestAccumulatorService_SetConfigDuringGeneration_NoRace(t *testing.T) {
+	s := newTestAccumulatorService()
+	legs := newTestLegs(12)
+	objective := s.objective(AccumulatorModeValue)
+
+	stop := make(chan struct{})
+	writerDone := make(chan struct{})
+	go func() {
+		defer close(writerDone)
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			cfg := DefaultAccumulatorConfig()
+			cfg.AllowSameFixture = i%2 == 0
+			cfg.CorrelationAdjustment = i%3 == 0
+			cfg.LegCorrelations = map[string]float64{"1x2:home_win|1x2:home_win": float64(i%10) / 10}
+			s.SetConfig(cfg)
+		}
+	}()
+
+	var readers sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for i := 0; i < 50; i++ {
+				cutoff := &scoreCutoff{limit: 5, min: objective.minScore}
+				s.generateNLegAccumulators(legs, 3, 1000, objective, cutoff)
+				s.CalculateAccumulatorEV(legs[:3])
+				_ = s.Config()
+			}
+		}()
+	}
+
+	readers.Wait()
+	close(stop)
+	<-writerDone
+}
+
+func TestAccumulatorService_WithConfig_UnaffectedBySetConfig(t *testing.T) {
+	s := newTestAccumulatorService()
+	runConfig := DefaultAccumulatorConfig()
+	runConfig.MaxLegs = 4
+
+	run := s.withConfig(runConfig)
+	updated := DefaultAccumulatorConfig()
+	updated.MaxLegs = 6
+	s.SetConfig(updated)
+
+	if got := run.currentConfig().MaxLegs; got != 4 {
+		t.Errorf("Expected the run to keep MaxLegs 4, got %d", got)
+	}
+	if got := s.Config().MaxLegs; got != 6 {
+		t.Errorf("Expected the service to use MaxLegs 6, got %d", got)
+	}
+}
+
+func TestAccumulatorService_SetConfig_CopiesLegCorrelations(t *testing.T) {
+	s := newTestAccumulatorService()
+	cfg := DefaultAccumulatorConfig()
+	cfg.LegCorrelations = map[string]float64{"over_under:over_2_5|btts:yes": 0.5}
+
+	s.SetConfig(cfg)
+	cfg.LegCorrelations["over_under:over_2_5|btts:yes"] = 0.9
+
+	if got := s.Config().LegCorrelations["over_under:over_2_5|btts:yes"]; got != 0.5 {
+		t.Errorf("Expected the stored correlation to stay 0.5, got %v", got)
+	}
+}
+
+// testAccumulator builds an accumulator of home win legs on the given fixtures
+func testAccumulator(id string, fixtureIDs ...int) *Accumulator {
+	acc := &Accumulator{ID: id, NumLegs: len(fixtureIDs)}
+	for _, fixtureID := range fixtureIDs {
+		acc.Legs = append(acc.Legs, AccumulatorLeg{FixtureID: fixtureID, Market: MarketType1X2, Outcome: "home_win"})
+	}
+	return acc
+}
+
+func TestRemoveSubsetAccumulators_OverlappingCandidates_DropsOnlyLaterSubsets(t *testing.T) {
+	// Sorted best first
+	accumulators := []*Accumulator{
+		testAccumulator("abc", 1, 2, 3),
+		testAccumulator("ab", 1, 2),         // Subset of abc: dropped
+		testAccumulator("ad", 1, 4),         // Overlaps abc but adds a leg: kept
+		testAccumulator("bc", 2, 3),         // Subset of abc: dropped
+		testAccumulator("abcd", 1, 2, 3, 4), // Superset of a better one: kept
+		testAccumulator("ef", 5, 6),
+		testAccumulator("efg", 5, 6, 7), // Superset of a better one: kept
+		testAccumulator("f", 6),         // Subset of ef and efg: dropped
+	}
+
+	kept := removeSubsetAccumulators(accumulators)
+
+	var got []string
+	for _, acc := range kept {
+		got = append(got, acc.ID)
+	}
+	expected := []string{"abc", "ad", "abcd", "ef", "efg"}
+	if !slices.Equal(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestRemoveSubsetAccumulators_SameFixtureDifferentOutcome_NotASubset(t *testing.T) {
+	over := testAccumulator("over", 1, 2)
+	over.Legs[1].Market, over.Legs[1].Outcome = MarketTypeOverUnder, "over_2_5"
+	accumulators := []*Accumulator{
+		testAccumulator("home", 1, 2, 3),
+		over, // Fixture 2 is backed on a different market, so it isn't covered by "home"
+	}
+
+	if kept := removeSubsetAccumulators(accumulators); len(kept) != 2 {
+		t.Errorf("Expected both accumulators kept, got %d", len(kept))
+	}
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"math"
+
+	"github.com/dEnchanter/OddsIQ/backend/internal/markets"
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+)
+
+// minLogLossProb bounds probabilities away from zero so one confident miss can't make
+// log-loss infinite
+const minLogLossProb = 1e-15
+
+// AccuracyScores are the scores of a set of 1X2 predictions
+type AccuracyScores struct {
+	Count      int     `json:"count"`
+	Accuracy   float64 `json:"accuracy"`    // Share of fixtures whose most likely outcome happened
+	LogLoss    float64 `json:"log_loss"`    // Mean -ln(probability of the actual outcome)
+	BrierScore float64 `json:"brier_score"` // Mean squared error summed over the three outcomes (0-2)
+}
+
+// AccuracyBucket scores the predictions whose confidence (top outcome probability)
+// falls in [Lower, Upper)
+type AccuracyBucket struct {
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+	AccuracyScores
+}
+
+// AccuracyReport scores past predictions of a market against results
+type AccuracyReport struct {
+	Market  string           `json:"market"`
+	Season  int              `json:"season,omitempty"` // 0 means all seasons
+	Overall AccuracyScores   `json:"overall"`
+	Buckets []AccuracyBucket `json:"buckets"`
+}
+
+// accuracyTotals accumulates scores before averaging
+type accuracyTotals struct {
+	count, correct    int
+	logLoss, brierSum float64
+}
+
+// add scores one prediction given the index of the outcome that happened
+func (t *accuracyTotals) add(probs [3]float64, actual int) {
+	predicted := 0
+	for i := range probs {
+		if probs[i] > probs[predicted] {
+			predicted = i
+		}
+	}
+
+	t.count++
+	if predicted == actual {
+		t.correct++
+	}
+	t.logLoss -= math.Log(math.Max(probs[actual], minLogLossProb))
+	for i, p := range probs {
+		occurred := 0.0
+		if i == actual {
+			occurred = 1
+		}
+		t.brierSum += (p - occurred) * (p - occurred)
+	}
+}
+
+// scores averages the accumulated totals
+func (t *accuracyTotals) scores() AccuracyScores {
+	if t.count == 0 {
+		return AccuracyScores{}
+	}
+	n := float64(t.count)
+	return AccuracyScores{
+		Count:      t.count,
+		Accuracy:   math.Round(float64(t.correct)/n*10000) / 10000,
+		LogLoss:    math.Round(t.logLoss/n*10000) / 10000,
+		BrierScore: math.Round(t.brierSum/n*10000) / 10000,
+	}
+}
+
+// CalculateAccuracy scores 1X2 predictions against final scores overall and in
+// equal-width confidence buckets (empty buckets are omitted)
+func CalculateAccuracy(results []models.PredictionResult, numBuckets int) *AccuracyReport {
+	if numBuckets <= 0 {
+		numBuckets = DefaultCalibrationBuckets
+	}
+
+	var overall accuracyTotals
+	buckets := make([]accuracyTotals, numBuckets)
+
+	for _, r := range results {
+		probs := [3]float64{r.HomeWinProb, r.DrawProb, r.AwayWinProb}
+
+		// Outcome index: 0 home win, 1 draw, 2 away win
+		actual := 1
+		switch {
+		case r.HomeScore > r.AwayScore:
+			actual = 0
+		case r.AwayScore > r.HomeScore:
+			actual = 2
+		}
+
+		confidence := math.Max(probs[0], math.Max(probs[1], probs[2]))
+		i := int(confidence * float64(numBuckets))
+		if i >= numBuckets {
+			i = numBuckets - 1
+		}
+
+		overall.add(probs, actual)
+		buckets[i].add(probs, actual)
+	}
+
+	report := &AccuracyReport{
+		Market:  markets.Model1X2,
+		Overall: overall.scores(),
+		Buckets: []AccuracyBucket{},
+	}
+
+	width := 1.0 / float64(numBuckets)
+	for i := range buckets {
+		if buckets[i].count == 0 {
+			continue
+		}
+		report.Buckets = append(report.Buckets, AccuracyBucket{
+			Lower:          math.Round(float64(i)*width*10000) / 10000,
+			Upper:          math.Round(float64(i+1)*width*10000) / 10000,
+			AccuracyScores: buckets[i].scores(),
+		})
+	}
+
+	return report
+}
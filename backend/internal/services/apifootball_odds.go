@@ -0,0 +1,222 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dEnchanter/OddsIQ/backend/internal/markets"
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+	"github.com/dEnchanter/OddsIQ/backend/internal/repository"
+	"github.com/dEnchanter/OddsIQ/backend/pkg/apifootball"
+)
+
+// defaultAPIFootballOddsLookahead is how far ahead pre-match odds are synced when no
+// lookahead is configured
+const defaultAPIFootballOddsLookahead = 7 * 24 * time.Hour
+
+// APIFootballOddsSyncService stores pre-match odds from API-Football, a second odds
+// source alongside The Odds API
+type APIFootballOddsSyncService struct {
+	apiClient    *apifootball.Client
+	fixturesRepo *repository.FixturesRepository
+	oddsRepo     *repository.OddsRepository
+	statusRepo   *repository.SyncStatusRepository // optional, records sync outcomes
+	lookahead    time.Duration                    // Fixtures kicking off within this of now are synced
+}
+
+// NewAPIFootballOddsSyncService creates a new API-Football odds sync service
+func NewAPIFootballOddsSyncService(
+	apiClient *apifootball.Client,
+	fixturesRepo *repository.FixturesRepository,
+	oddsRepo *repository.OddsRepository,
+	statusRepo *repository.SyncStatusRepository,
+	lookahead time.Duration,
+) *APIFootballOddsSyncService {
+	if lookahead <= 0 {
+		lookahead = defaultAPIFootballOddsLookahead
+	}
+
+	return &APIFootballOddsSyncService{
+		apiClient:    apiClient,
+		fixturesRepo: fixturesRepo,
+		oddsRepo:     oddsRepo,
+		statusRepo:   statusRepo,
+		lookahead:    lookahead,
+	}
+}
+
+// SyncUpcomingOdds syncs pre-match odds for every upcoming API-Football fixture within
+// the lookahead and records the outcome in sync_status as odds_apifootball
+func (s *APIFootballOddsSyncService) SyncUpcomingOdds(ctx context.Context) error {
+	log.Println("Syncing API-Football odds for upcoming fixtures...")
+
+	total, err := s.syncUpcoming(ctx)
+	recordSyncRun(ctx, s.statusRepo, models.SyncTypeOdds+"_apifootball", total, err)
+	return err
+}
+
+// syncUpcoming syncs each upcoming fixture and returns the number of rows inserted
+func (s *APIFootballOddsSyncService) syncUpcoming(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	fixtures, err := s.fixturesRepo.GetUpcomingInWindow(ctx, now, now.Add(s.lookahead))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get upcoming fixtures: %w", err)
+	}
+
+	total, synced := 0, 0
+	for _, fixture := range fixtures {
+		if fixture.APIFootballID <= 0 {
+			continue // Manual fixture
+		}
+
+		inserted, err := s.SyncFixtureOdds(ctx, fixture.ID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return total, ctx.Err()
+			}
+			log.Printf("Failed to sync API-Football odds for fixture %d: %v", fixture.ID, err)
+			continue
+		}
+		total += inserted
+		synced++
+	}
+
+	log.Printf("Synced API-Football odds for %d fixtures (%d rows stored)", synced, total)
+	return total, nil
+}
+
+// SyncFixtureOdds fetches pre-match odds for a fixture and stores those that moved since
+// the last quote, returning the number of rows inserted
+func (s *APIFootballOddsSyncService) SyncFixtureOdds(ctx context.Context, fixtureID int) (int, error) {
+	fixture, err := s.fixturesRepo.GetByID(ctx, fixtureID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get fixture: %w", err)
+	}
+	if fixture.APIFootballID <= 0 {
+		return 0, fmt.Errorf("fixture %d is not an API-Football fixture", fixtureID)
+	}
+
+	responses, err := s.apiClient.GetOddsByFixture(ctx, fixture.APIFootballID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch odds: %w", err)
+	}
+
+	return storeAPIFootballOdds(ctx, s.oddsRepo, responses, fixture.ID, false)
+}
+
+// storeAPIFootballOdds converts API-Football odds responses for a fixture and inserts
+// the rows whose price moved, returning how many were inserted
+func storeAPIFootballOdds(ctx context.Context, oddsRepo *repository.OddsRepository, responses []apifootball.OddsResponse, fixtureID int, live bool) (int, error) {
+	total := 0
+	for _, resp := range responses {
+		oddsList := ConvertAPIFootballOdds(resp, fixtureID, live)
+		if len(oddsList) == 0 {
+			continue
+		}
+
+		inserted, _, err := oddsRepo.BulkInsert(ctx, oddsList)
+		if err != nil {
+			return total, fmt.Errorf("failed to store odds: %w", err)
+		}
+		total += inserted
+	}
+
+	return total, nil
+}
+
+// ConvertAPIFootballOdds maps an API-Football odds response (bookmakers -> bets -> values)
+// into stored odds for a fixture. Bets for unregistered markets and values that can't be
+// parsed are skipped.
+func ConvertAPIFootballOdds(resp apifootball.OddsResponse, fixtureID int, live bool) []models.Odds {
+	timestamp := parseAPIFootballUpdate(resp.Update)
+
+	var oddsList []models.Odds
+	for _, bookmaker := range resp.Bookmakers {
+		bookmakerKey := APIFootballBookmakerKey(bookmaker.Name)
+
+		for _, bet := range bookmaker.Bets {
+			market, ok := markets.ForAPIFootballBet(bet.ID)
+			if !ok {
+				continue
+			}
+
+			for _, value := range bet.Values {
+				price, err := strconv.ParseFloat(strings.TrimSpace(value.Odd), 64)
+				if err != nil || price <= 1 {
+					continue
+				}
+
+				outcome, line, ok := parseAPIFootballValue(market, value.Value)
+				if !ok {
+					continue
+				}
+
+				oddsList = append(oddsList, models.Odds{
+					FixtureID:  fixtureID,
+					Bookmaker:  bookmakerKey,
+					Source:     models.OddsSourceAPIFootball,
+					MarketType: market.Key,
+					Outcome:    outcome,
+					OddsValue:  price,
+					Line:       line,
+					Timestamp:  timestamp,
+					IsLive:     live,
+				})
+			}
+		}
+	}
+
+	return oddsList
+}
+
+// APIFootballBookmakerKey turns an API-Football bookmaker name into a stored bookmaker key
+// ("Bet365" -> "bet365", "William Hill" -> "william_hill")
+func APIFootballBookmakerKey(name string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), " ", "_")
+}
+
+// parseAPIFootballValue parses a bet value label into the market's canonical outcome
+// and line: "Home" -> Home, "Over 2.5" -> Over 2.5, "Away +0.5" -> Away +0.5
+func parseAPIFootballValue(market *markets.Market, label string) (string, float64, bool) {
+	fields := strings.Fields(label)
+	if len(fields) == 0 {
+		return "", 0, false
+	}
+
+	outcome, ok := market.NormalizeOutcome(fields[0])
+	if !ok {
+		return "", 0, false
+	}
+
+	if !market.HasLine() {
+		if len(fields) != 1 {
+			return "", 0, false
+		}
+		return outcome, 0, true
+	}
+
+	if len(fields) != 2 {
+		return "", 0, false
+	}
+	line, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return "", 0, false
+	}
+	if _, err := market.ValidateLine(&line); err != nil {
+		return "", 0, false
+	}
+
+	return outcome, line, true
+}
+
+// parseAPIFootballUpdate parses the response's last update time, defaulting to now
+func parseAPIFootballUpdate(update string) time.Time {
+	if t, err := time.Parse(time.RFC3339, update); err == nil {
+		return t.UTC()
+	}
+	return time.Now().UTC()
+}
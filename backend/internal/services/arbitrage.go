@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/dEnchanter/OddsIQ/backend/internal/markets"
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+)
+
+// ArbLeg is one bet within an arbitrage
+type ArbLeg struct {
+	Outcome   string  `json:"outcome"`
+	Odds      float64 `json:"odds"`
+	Bookmaker string  `json:"bookmaker"`
+	Stake     float64 `json:"stake"`
+	Return    float64 `json:"return"`
+}
+
+// Arbitrage is a set of bets across bookmakers that returns a profit whatever the result
+type Arbitrage struct {
+	Market           string   `json:"market"`
+	Line             float64  `json:"line"`
+	ImpliedProbSum   float64  `json:"implied_prob_sum"`
+	Margin           float64  `json:"margin"` // Guaranteed profit as a fraction of total stake
+	TotalStake       float64  `json:"total_stake"`
+	GuaranteedReturn float64  `json:"guaranteed_return"`
+	GuaranteedProfit float64  `json:"guaranteed_profit"`
+	Legs             []ArbLeg `json:"legs"`
+}
+
+// FindArbitrage finds arbitrage opportunities in a fixture's latest odds. For each
+// market and line it takes the best price per outcome across bookmakers; if every
+// outcome is covered and the implied probabilities sum to less than 1, the stake
+// is split so each outcome returns the same amount.
+func FindArbitrage(odds []models.Odds, totalStake float64) []Arbitrage {
+	type marketKey struct {
+		market string
+		line   float64
+	}
+
+	best := make(map[marketKey]map[string]models.Odds)
+	for _, o := range odds {
+		if o.OddsValue <= 1 {
+			continue
+		}
+		key := marketKey{o.MarketType, o.Line}
+		if best[key] == nil {
+			best[key] = make(map[string]models.Odds)
+		}
+		if current, ok := best[key][o.Outcome]; !ok || o.OddsValue > current.OddsValue {
+			best[key][o.Outcome] = o
+		}
+	}
+
+	var arbs []Arbitrage
+	for key, outcomes := range best {
+		// Every outcome must be priced, otherwise the "arb" leaves a result uncovered
+		expected := markets.OutcomeCount(key.market)
+		if expected == 0 || len(outcomes) != expected {
+			continue
+		}
+
+		impliedSum := 0.0
+		bookmakers := make(map[string]bool)
+		for _, o := range outcomes {
+			impliedSum += 1 / o.OddsValue
+			bookmakers[o.Bookmaker] = true
+		}
+
+		// A single bookmaker pricing under 100% is almost certainly bad data
+		if impliedSum >= 1 || len(bookmakers) < 2 {
+			continue
+		}
+
+		guaranteedReturn := totalStake / impliedSum
+		arb := Arbitrage{
+			Market:           key.market,
+			Line:             key.line,
+			ImpliedProbSum:   math.Round(impliedSum*10000) / 10000,
+			Margin:           math.Round((1/impliedSum-1)*10000) / 10000,
+			TotalStake:       totalStake,
+			GuaranteedReturn: math.Round(guaranteedReturn*100) / 100,
+			GuaranteedProfit: math.Round((guaranteedReturn-totalStake)*100) / 100,
+		}
+
+		for outcome, o := range outcomes {
+			stake := totalStake * (1 / o.OddsValue) / impliedSum
+			arb.Legs = append(arb.Legs, ArbLeg{
+				Outcome:   outcome,
+				Odds:      o.OddsValue,
+				Bookmaker: o.Bookmaker,
+				Stake:     math.Round(stake*100) / 100,
+				Return:    math.Round(stake*o.OddsValue*100) / 100,
+			})
+		}
+		sort.Slice(arb.Legs, func(i, j int) bool {
+			return arb.Legs[i].Outcome < arb.Legs[j].Outcome
+		})
+
+		arbs = append(arbs, arb)
+	}
+
+	// Best margin first
+	sort.Slice(arbs, func(i, j int) bool {
+		return arbs[i].Margin > arbs[j].Margin
+	})
+
+	return arbs
+}
+
+// GetArbitrage finds arbitrage opportunities for a fixture using its latest odds
+func (s *BettingService) GetArbitrage(ctx context.Context, fixtureID int, totalStake float64) ([]Arbitrage, error) {
+	odds, err := s.oddsRepo.GetLatestByFixture(ctx, fixtureID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get odds: %w", err)
+	}
+
+	return FindArbitrage(odds, totalStake), nil
+}
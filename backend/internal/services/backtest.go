@@ -0,0 +1,238 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/dEnchanter/OddsIQ/backend/config"
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+	"github.com/dEnchanter/OddsIQ/backend/internal/repository"
+)
+
+// backtestBatchSize is the number of fixtures sent to the ML service per batch
+const backtestBatchSize = 50
+
+// BacktestOptions configures a backtest run
+type BacktestOptions struct {
+	Season   int
+	Markets  []MarketType  // Markets to bet on (empty = all)
+	Bankroll float64       // Starting bankroll
+	MinEV    float64       // Minimum EV for a bet
+	Cutoff   time.Duration // Only use odds recorded at least this long before kickoff
+}
+
+// BacktestBet is a simulated bet placed during a backtest
+type BacktestBet struct {
+	FixtureID     int        `json:"fixture_id"`
+	MatchDate     time.Time  `json:"match_date"`
+	Market        MarketType `json:"market"`
+	Outcome       string     `json:"outcome"`
+	Probability   float64    `json:"probability"`
+	Odds          float64    `json:"odds"`
+	Bookmaker     string     `json:"bookmaker"`
+	EV            float64    `json:"ev"`
+	Stake         float64    `json:"stake"`
+	Result        string     `json:"result"`
+	ProfitLoss    float64    `json:"profit_loss"`
+	ClosingOdds   *float64   `json:"closing_odds"`
+	CLV           *float64   `json:"clv"`
+	BankrollAfter float64    `json:"bankroll_after"`
+}
+
+// BacktestResult summarizes a backtest run
+type BacktestResult struct {
+	Options           BacktestOptions            `json:"options"`
+	FixturesEvaluated int                        `json:"fixtures_evaluated"`
+	FixturesSkipped   int                        `json:"fixtures_skipped"` // No odds, prediction or gradable result
+	Bets              []BacktestBet              `json:"bets"`
+	Metrics           *models.PerformanceMetrics `json:"metrics"`
+	FinalBankroll     float64                    `json:"final_bankroll"`
+}
+
+// Backtester replays finished fixtures through the betting logic
+type Backtester struct {
+	config       *config.Config
+	mlClient     *MLClient
+	fixturesRepo *repository.FixturesRepository
+	oddsRepo     *repository.OddsRepository
+}
+
+// NewBacktester creates a new backtester
+func NewBacktester(
+	cfg *config.Config,
+	mlClient *MLClient,
+	fixturesRepo *repository.FixturesRepository,
+	oddsRepo *repository.OddsRepository,
+) *Backtester {
+	return &Backtester{
+		config:       cfg,
+		mlClient:     mlClient,
+		fixturesRepo: fixturesRepo,
+		oddsRepo:     oddsRepo,
+	}
+}
+
+// Run generates the picks the system would have made for a season's finished
+// fixtures, using odds stored before the cutoff, and settles them against the
+// actual results. At most one bet (the best value outcome) is placed per fixture,
+// staked from the running bankroll.
+func (b *Backtester) Run(ctx context.Context, opts BacktestOptions) (*BacktestResult, error) {
+	// Reuse the live EV/Kelly logic with the backtest's EV threshold
+	cfg := *b.config
+	cfg.MinEVThreshold = opts.MinEV
+	bettingService := NewBettingService(&cfg, b.mlClient, b.fixturesRepo, b.oddsRepo)
+
+	allowed := make(map[MarketType]bool, len(opts.Markets))
+	for _, m := range opts.Markets {
+		allowed[m] = true
+	}
+
+	fixtures, err := b.fixturesRepo.GetBySeason(ctx, opts.Season, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fixtures: %w", err)
+	}
+
+	var finished []*models.Fixture
+	for i := range fixtures {
+		f := &fixtures[i]
+		if IsFinished(f.Status) && f.HomeScore != nil && f.AwayScore != nil {
+			finished = append(finished, f)
+		}
+	}
+
+	result := &BacktestResult{Options: opts}
+	bankroll := opts.Bankroll
+	var bets []models.Bet
+
+	for start := 0; start < len(finished); start += backtestBatchSize {
+		end := min(start+backtestBatchSize, len(finished))
+		batch := finished[start:end]
+
+		// Odds available at the cutoff
+		batchOdds := make(map[int][]models.Odds, len(batch))
+		handicapLines := make(map[int][]float64, len(batch))
+		var priced []*models.Fixture
+		for _, f := range batch {
+			odds, err := b.oddsRepo.GetLatestByFixtureBefore(ctx, f.ID, f.MatchDate.Add(-opts.Cutoff))
+			if err != nil {
+				return nil, err
+			}
+			if len(odds) == 0 {
+				result.FixturesSkipped++
+				continue
+			}
+			batchOdds[f.ID] = odds
+			handicapLines[f.ID] = handicapLinesFromOdds(odds)
+			priced = append(priced, f)
+		}
+		if len(priced) == 0 {
+			continue
+		}
+
+		predictions, err := b.mlClient.PredictMultiMarketBatch(ctx, priced, handicapLines)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get predictions: %w", err)
+		}
+
+		for _, f := range priced {
+			pred, ok := predictions[f.ID]
+			if !ok {
+				result.FixturesSkipped++
+				continue
+			}
+			result.FixturesEvaluated++
+
+			pick := bettingService.evaluatePredictions(f, batchOdds[f.ID], handicapLines[f.ID], pred, bankroll, bettingService.OddsRange())
+			outcome := bestBacktestOutcome(pick.ValueOutcomes, allowed)
+			if outcome == nil {
+				continue
+			}
+
+			status, gradable := GradeOutcome(outcome.Market, outcome.Outcome, *f.HomeScore, *f.AwayScore)
+			if !gradable {
+				result.FixturesSkipped++
+				continue
+			}
+
+			payout, profit, err := CalculateSettlement(outcome.KellyStake, outcome.BestOdds, status)
+			if err != nil {
+				return nil, err
+			}
+			bankroll += profit
+
+			bet := BacktestBet{
+				FixtureID:     f.ID,
+				MatchDate:     f.MatchDate,
+				Market:        outcome.Market,
+				Outcome:       outcome.Outcome,
+				Probability:   outcome.Probability,
+				Odds:          outcome.BestOdds,
+				Bookmaker:     outcome.Bookmaker,
+				EV:            outcome.EV,
+				Stake:         outcome.KellyStake,
+				Result:        status,
+				ProfitLoss:    math.Round(profit*100) / 100,
+				BankrollAfter: math.Round(bankroll*100) / 100,
+			}
+			b.setClosingLine(ctx, bettingService, f, &bet)
+			result.Bets = append(result.Bets, bet)
+
+			settledAt := f.MatchDate
+			bets = append(bets, models.Bet{
+				Odds:             bet.Odds,
+				Stake:            bet.Stake,
+				Status:           status,
+				Payout:           &payout,
+				ProfitLoss:       &profit,
+				ClosingLineValue: bet.CLV,
+				SettledAt:        &settledAt,
+			})
+		}
+	}
+
+	result.Metrics = CalculatePerformanceMetrics(bets)
+	result.FinalBankroll = math.Round(bankroll*100) / 100
+
+	log.Printf("Backtest season %d: %d fixtures evaluated, %d skipped, %d bets",
+		opts.Season, result.FixturesEvaluated, result.FixturesSkipped, len(result.Bets))
+
+	return result, nil
+}
+
+// setClosingLine sets a bet's closing odds (best price at kickoff) and CLV
+func (b *Backtester) setClosingLine(ctx context.Context, bettingService *BettingService, f *models.Fixture, bet *BacktestBet) {
+	closing, err := b.oddsRepo.GetLatestByFixtureBefore(ctx, f.ID, f.MatchDate)
+	if err != nil {
+		log.Printf("Warning: Could not get closing odds for fixture %d: %v", f.ID, err)
+		return
+	}
+
+	quote, ok := bettingService.buildOddsMap(closing, nil)[fmt.Sprintf("%s_%s", bet.Market, bet.Outcome)]
+	if !ok || quote.Odds <= 1 {
+		return
+	}
+
+	closingOdds := quote.Odds
+	clv := CalculateCLV(bet.Odds, closingOdds)
+	bet.ClosingOdds = &closingOdds
+	bet.CLV = &clv
+}
+
+// bestBacktestOutcome returns the highest-EV value outcome priced by a real
+// bookmaker in an allowed market (value outcomes are sorted by EV)
+func bestBacktestOutcome(outcomes []BetOutcome, allowed map[MarketType]bool) *BetOutcome {
+	for i := range outcomes {
+		o := &outcomes[i]
+		if o.Bookmaker == "synthetic" {
+			continue
+		}
+		if len(allowed) > 0 && !allowed[o.Market] {
+			continue
+		}
+		return o
+	}
+	return nil
+}
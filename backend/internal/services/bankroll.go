@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+	"github.com/dEnchanter/OddsIQ/backend/internal/repository"
+)
+
+// BankrollService records and serves bankroll snapshots
+type BankrollService struct {
+	betsRepo        *repository.BetsRepository
+	bankrollRepo    *repository.BankrollRepository
+	initialBankroll float64
+}
+
+// NewBankrollService creates a new bankroll service
+func NewBankrollService(
+	betsRepo *repository.BetsRepository,
+	bankrollRepo *repository.BankrollRepository,
+	initialBankroll float64,
+) *BankrollService {
+	return &BankrollService{
+		betsRepo:        betsRepo,
+		bankrollRepo:    bankrollRepo,
+		initialBankroll: initialBankroll,
+	}
+}
+
+// CalculateBankrollSnapshot builds a bankroll snapshot from the starting balance and all settled bets
+func CalculateBankrollSnapshot(initialBankroll float64, bets []models.Bet) *models.Bankroll {
+	metrics := CalculatePerformanceMetrics(bets)
+
+	return &models.Bankroll{
+		Balance:         math.Round((initialBankroll+metrics.TotalProfit)*100) / 100,
+		TotalStaked:     math.Round(metrics.TotalStaked*100) / 100,
+		TotalReturned:   math.Round(metrics.TotalReturned*100) / 100,
+		TotalProfitLoss: math.Round(metrics.TotalProfit*100) / 100,
+		ROIPercentage:   math.Round(metrics.ROIPercentage*10000) / 10000,
+		NumBets:         metrics.TotalBets,
+		NumWins:         metrics.NumWins,
+		NumLosses:       metrics.NumLosses,
+		WinRate:         math.Round(metrics.WinRate*10000) / 10000,
+		RecordedAt:      time.Now(),
+	}
+}
+
+// RecordSnapshot recomputes the bankroll from settled bets and appends a snapshot
+func (s *BankrollService) RecordSnapshot(ctx context.Context) (*models.Bankroll, error) {
+	if _, err := s.ensureSeeded(ctx); err != nil {
+		return nil, err
+	}
+
+	bets, err := s.betsRepo.GetSettled(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := CalculateBankrollSnapshot(s.initialBankroll, bets)
+	if err := s.bankrollRepo.Create(ctx, snapshot); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// GetCurrent returns the latest bankroll snapshot
+func (s *BankrollService) GetCurrent(ctx context.Context) (*models.Bankroll, error) {
+	return s.ensureSeeded(ctx)
+}
+
+// GetHistory returns bankroll snapshots between from and to, oldest first
+func (s *BankrollService) GetHistory(ctx context.Context, from, to time.Time) ([]models.Bankroll, error) {
+	if _, err := s.ensureSeeded(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.bankrollRepo.GetHistory(ctx, from, to)
+}
+
+// ensureSeeded returns the latest snapshot, recording the initial bankroll if none exists yet
+func (s *BankrollService) ensureSeeded(ctx context.Context) (*models.Bankroll, error) {
+	latest, found, err := s.bankrollRepo.GetLatest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		return latest, nil
+	}
+
+	seed := &models.Bankroll{
+		Balance:    s.initialBankroll,
+		RecordedAt: time.Now(),
+	}
+	if err := s.bankrollRepo.Create(ctx, seed); err != nil {
+		return nil, err
+	}
+
+	return seed, nil
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"math"
+	"strings"
+
+	"github.com/dEnchanter/OddsIQ/backend/internal/markets"
+)
+
+// GradeOutcome grades a market outcome key (e.g. "home_win", "over_2_5", "yes",
+// "home_minus_0_5") against a final score, returning BetStatusWon, BetStatusLost
+// or BetStatusVoid (push). ok is false for unknown outcomes and for quarter-goal
+// handicap lines, which settle as half win/half loss.
+func GradeOutcome(market MarketType, outcome string, homeGoals, awayGoals int) (result string, ok bool) {
+	switch market {
+	case MarketType1X2:
+		side, found := map[string]string{"home_win": "Home", "draw": "Draw", "away_win": "Away"}[outcome]
+		if !found {
+			return "", false
+		}
+		return betStatus(markets.SettleH2H(homeGoals, awayGoals, side))
+
+	case MarketTypeOverUnder:
+		side, lineKey, found := strings.Cut(outcome, "_")
+		if !found {
+			return "", false
+		}
+		line, valid := markets.ParseLineKey(lineKey)
+		if !valid {
+			return "", false
+		}
+		return betStatus(markets.SettleOverUnder(homeGoals, awayGoals, line, side))
+
+	case MarketTypeBTTS:
+		return betStatus(markets.SettleBTTS(homeGoals, awayGoals, outcome))
+
+	case MarketTypeHandicap:
+		side, line, valid := markets.ParseHandicapOutcomeKey(outcome)
+		if !valid || math.Mod(math.Abs(line)*2, 1) != 0 {
+			return "", false // Quarter lines need split settlement
+		}
+		margin := float64(homeGoals - awayGoals)
+		if side == "Away" {
+			margin = -margin
+		}
+		adjusted := margin + line
+		if adjusted == 0 {
+			return BetStatusVoid, true
+		}
+		return wonOrLost(adjusted > 0), true
+	}
+
+	return "", false
+}
+
+// betStatus converts a settlement result into a bet status; ok is false for an
+// unrecognised side
+func betStatus(result markets.BetResult) (status string, ok bool) {
+	switch result {
+	case markets.ResultWin:
+		return BetStatusWon, true
+	case markets.ResultLose:
+		return BetStatusLost, true
+	case markets.ResultPush:
+		return BetStatusVoid, true
+	}
+	return "", false
+}
+
+// wonOrLost converts a boolean into a bet status
+func wonOrLost(won bool) string {
+	if won {
+		return BetStatusWon
+	}
+	return BetStatusLost
+}
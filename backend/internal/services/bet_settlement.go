@@ -0,0 +1,288 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/dEnchanter/OddsIQ/backend/internal/markets"
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+	"github.com/dEnchanter/OddsIQ/backend/internal/repository"
+)
+
+// Bet statuses
+const (
+	BetStatusPending = "pending"
+	BetStatusWon     = "won"
+	BetStatusLost    = "lost"
+	BetStatusVoid    = "void"
+)
+
+var (
+	// ErrBetAlreadySettled is returned when settling a bet that is no longer pending
+	ErrBetAlreadySettled = errors.New("bet is already settled")
+	// ErrInvalidBetResult is returned for settlement statuses other than won/lost/void
+	ErrInvalidBetResult = errors.New("invalid bet result, must be won, lost or void")
+)
+
+// BetSettlementService handles bet settlement
+type BetSettlementService struct {
+	betsRepo     *repository.BetsRepository
+	oddsRepo     *repository.OddsRepository
+	fixturesRepo *repository.FixturesRepository
+	bankroll     *BankrollService
+}
+
+// NewBetSettlementService creates a new bet settlement service
+func NewBetSettlementService(
+	betsRepo *repository.BetsRepository,
+	oddsRepo *repository.OddsRepository,
+	fixturesRepo *repository.FixturesRepository,
+	bankroll *BankrollService,
+) *BetSettlementService {
+	return &BetSettlementService{
+		betsRepo:     betsRepo,
+		oddsRepo:     oddsRepo,
+		fixturesRepo: fixturesRepo,
+		bankroll:     bankroll,
+	}
+}
+
+// betTypeOddsKeys maps bet types to the stored odds market/outcome
+var betTypeOddsKeys = map[string][2]string{
+	"home_win": {"h2h", "Home"},
+	"draw":     {"h2h", "Draw"},
+	"away_win": {"h2h", "Away"},
+	"btts_yes": {"btts", "Yes"},
+	"btts_no":  {"btts", "No"},
+}
+
+// BetTypeToOddsKey returns the stored odds market type, outcome, and line for a bet type.
+// Over/Under bet types carry their line (e.g. "over_3_5" -> totals, Over, 3.5).
+func BetTypeToOddsKey(betType string) (marketType, outcome string, line float64, ok bool) {
+	if key, found := betTypeOddsKeys[betType]; found {
+		return key[0], key[1], 0, true
+	}
+
+	if side, line, valid := markets.ParseHandicapOutcomeKey(betType); valid {
+		return "spreads", side, line, true
+	}
+
+	for prefix, side := range map[string]string{"over_": "Over", "under_": "Under"} {
+		if lineKey, found := strings.CutPrefix(betType, prefix); found {
+			if line, valid := markets.ParseLineKey(lineKey); valid {
+				return "totals", side, line, true
+			}
+		}
+	}
+
+	return "", "", 0, false
+}
+
+// BetTypeToMarketOutcome returns the market and outcome key used for grading a bet type
+// (e.g. "btts_yes" -> btts, "yes"; "over_2_5" -> over_under, "over_2_5")
+func BetTypeToMarketOutcome(betType string) (MarketType, string, bool) {
+	switch betType {
+	case "home_win", "draw", "away_win":
+		return MarketType1X2, betType, true
+	case "btts_yes":
+		return MarketTypeBTTS, "yes", true
+	case "btts_no":
+		return MarketTypeBTTS, "no", true
+	}
+
+	if _, _, valid := markets.ParseHandicapOutcomeKey(betType); valid {
+		return MarketTypeHandicap, betType, true
+	}
+
+	if strings.HasPrefix(betType, "over_") || strings.HasPrefix(betType, "under_") {
+		return MarketTypeOverUnder, betType, true
+	}
+
+	return "", "", false
+}
+
+// CalculateCLV calculates closing line value: (placed_odds / closing_odds) - 1
+func CalculateCLV(placedOdds, closingOdds float64) float64 {
+	if closingOdds <= 0 {
+		return 0
+	}
+	return placedOdds/closingOdds - 1
+}
+
+// closingLineValue looks up the closing odds for a bet and returns its CLV, or nil if unavailable
+func (s *BetSettlementService) closingLineValue(ctx context.Context, bet *models.Bet) *float64 {
+	marketType, outcome, line, ok := BetTypeToOddsKey(bet.BetType)
+	if !ok {
+		return nil
+	}
+
+	closing, err := s.oddsRepo.GetClosingOdds(ctx, bet.FixtureID, marketType, outcome, line)
+	if err != nil {
+		return nil
+	}
+
+	clv := math.Round(CalculateCLV(bet.Odds, closing.OddsValue)*10000) / 10000
+	return &clv
+}
+
+// CalculateSettlement returns payout and profit/loss for a bet result
+// Won:  payout = stake * odds, profit = stake * (odds - 1)
+// Lost: payout = 0, profit = -stake
+// Void: payout = stake, profit = 0 (stake refunded)
+func CalculateSettlement(stake, odds float64, result string) (payout, profitLoss float64, err error) {
+	switch result {
+	case BetStatusWon:
+		payout = stake * odds
+		profitLoss = stake * (odds - 1)
+	case BetStatusLost:
+		payout = 0
+		profitLoss = -stake
+	case BetStatusVoid:
+		payout = stake
+		profitLoss = 0
+	default:
+		return 0, 0, ErrInvalidBetResult
+	}
+
+	return math.Round(payout*100) / 100, math.Round(profitLoss*100) / 100, nil
+}
+
+// SettleBet settles a pending bet with the given result
+func (s *BetSettlementService) SettleBet(ctx context.Context, betID int, result string) (*models.Bet, error) {
+	bet, err := s.betsRepo.GetByID(ctx, betID)
+	if err != nil {
+		return nil, err
+	}
+
+	if bet.Status != BetStatusPending {
+		return nil, ErrBetAlreadySettled
+	}
+
+	if err := s.settle(ctx, bet, result); err != nil {
+		return nil, err
+	}
+
+	s.recordBankroll(ctx)
+
+	return bet, nil
+}
+
+// SettleFixture grades all pending bets on a fixture against its final score and
+// returns the number of bets settled. Bets on cancelled, abandoned, awarded or walkover
+// fixtures, and on postponed fixtures that weren't rescheduled in time, are voided (see
+// ShouldVoidBets). Bets are otherwise left open until the fixture is finished, and bets
+// whose type cannot be graded automatically are skipped for manual settlement.
+func (s *BetSettlementService) SettleFixture(ctx context.Context, fixtureID int) (int, error) {
+	fixture, err := s.fixturesRepo.GetByID(ctx, fixtureID)
+	if err != nil {
+		return 0, err
+	}
+
+	if ShouldVoidBets(fixture, time.Now()) {
+		return s.voidFixture(ctx, fixture)
+	}
+
+	if !IsFinished(fixture.Status) || fixture.HomeScore == nil || fixture.AwayScore == nil {
+		return 0, nil
+	}
+
+	bets, err := s.betsRepo.GetPendingByFixture(ctx, fixtureID)
+	if err != nil {
+		return 0, err
+	}
+
+	settled := 0
+	for i := range bets {
+		bet := &bets[i]
+
+		market, outcome, ok := BetTypeToMarketOutcome(bet.BetType)
+		if !ok {
+			log.Printf("Bet %d: cannot auto-settle bet type %q", bet.ID, bet.BetType)
+			continue
+		}
+
+		result, ok := GradeOutcome(market, outcome, *fixture.HomeScore, *fixture.AwayScore)
+		if !ok {
+			log.Printf("Bet %d: cannot auto-settle outcome %q", bet.ID, bet.BetType)
+			continue
+		}
+
+		if err := s.settle(ctx, bet, result); err != nil {
+			log.Printf("Bet %d: %v", bet.ID, err)
+			continue
+		}
+		settled++
+	}
+
+	if settled > 0 {
+		s.recordBankroll(ctx)
+	}
+
+	return settled, nil
+}
+
+// voidFixture voids every pending bet on a fixture, refunding the stakes, and returns
+// the number of bets voided
+func (s *BetSettlementService) voidFixture(ctx context.Context, fixture *models.Fixture) (int, error) {
+	bets, err := s.betsRepo.GetPendingByFixture(ctx, fixture.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	voided := 0
+	for i := range bets {
+		if err := s.settle(ctx, &bets[i], BetStatusVoid); err != nil {
+			log.Printf("Bet %d: %v", bets[i].ID, err)
+			continue
+		}
+		voided++
+	}
+
+	if voided > 0 {
+		log.Printf("Voided %d bets on fixture %d (status %s)", voided, fixture.ID, fixture.Status)
+		s.recordBankroll(ctx)
+	}
+
+	return voided, nil
+}
+
+// recordBankroll appends a bankroll snapshot after bets settle. Failures are logged
+// rather than returned since the bets themselves are already settled.
+func (s *BetSettlementService) recordBankroll(ctx context.Context) {
+	if s.bankroll == nil {
+		return
+	}
+
+	if _, err := s.bankroll.RecordSnapshot(ctx); err != nil {
+		log.Printf("Failed to record bankroll snapshot: %v", err)
+	}
+}
+
+// settle applies a result to a pending bet and persists it
+func (s *BetSettlementService) settle(ctx context.Context, bet *models.Bet, result string) error {
+	payout, profitLoss, err := CalculateSettlement(bet.Stake, bet.Odds, result)
+	if err != nil {
+		return err
+	}
+
+	settledAt := time.Now().UTC()
+	bet.Status = result
+	bet.Payout = &payout
+	bet.ProfitLoss = &profitLoss
+	bet.SettledAt = &settledAt
+	bet.ClosingLineValue = s.closingLineValue(ctx, bet)
+
+	// The bet was read as pending, so a concurrent settlement got there first
+	if err := s.betsRepo.Settle(ctx, bet); errors.Is(err, repository.ErrBetNotPending) {
+		return ErrBetAlreadySettled
+	} else if err != nil {
+		return fmt.Errorf("failed to settle bet: %w", err)
+	}
+
+	return nil
+}
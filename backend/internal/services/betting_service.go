@@ -2,13 +2,16 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
 	"math"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/dEnchanter/OddsIQ/backend/config"
+	"github.com/dEnchanter/OddsIQ/backend/internal/logging"
+	"github.com/dEnchanter/OddsIQ/backend/internal/markets"
 	"github.com/dEnchanter/OddsIQ/backend/internal/models"
 	"github.com/dEnchanter/OddsIQ/backend/internal/repository"
 )
@@ -20,6 +23,7 @@ const (
 	MarketType1X2       MarketType = "1x2"
 	MarketTypeOverUnder MarketType = "over_under"
 	MarketTypeBTTS      MarketType = "btts"
+	MarketTypeHandicap  MarketType = "asian_handicap"
 )
 
 // BetOutcome represents a specific betting outcome within a market
@@ -30,10 +34,15 @@ type BetOutcome struct {
 	Probability float64    `json:"probability"`  // Model probability
 	BestOdds    float64    `json:"best_odds"`    // Best available odds
 	Bookmaker   string     `json:"bookmaker"`    // Source of odds
+	BookmakerType string   `json:"bookmaker_type"` // sharp or soft (empty for synthetic odds)
 	EV          float64    `json:"ev"`           // Expected Value
 	EVPercent   float64    `json:"ev_percent"`   // EV as percentage
 	KellyStake  float64    `json:"kelly_stake"`  // Recommended stake (Kelly)
 	Confidence  float64    `json:"confidence"`   // Model confidence
+	StakeAdjusted bool     `json:"stake_adjusted"` // Stake raised to the configured minimum
+	MarketProbability float64 `json:"market_probability"` // No-vig probability from bookmaker odds (0 if unavailable)
+	Edge              float64 `json:"edge"`               // Model probability minus market probability
+	SharpProbability  float64 `json:"sharp_probability"`  // No-vig probability from sharp bookmakers only (0 if unavailable)
 }
 
 // MultiMarketPick represents a recommended bet with all market options evaluated
@@ -45,6 +54,8 @@ type MultiMarketPick struct {
 	SuggestedStake   float64          `json:"suggested_stake"`   // Stake for best outcome
 	TotalEV          float64          `json:"total_ev"`          // Sum of positive EVs
 	EvaluatedAt      time.Time        `json:"evaluated_at"`
+	SharpOnly        bool             `json:"sharp_only"`        // EV priced against sharp bookmakers only
+	ProbOverrides    bool             `json:"prob_overrides"`    // Priced with custom probabilities instead of the model's
 }
 
 // BettingService handles betting calculations and recommendations
@@ -90,6 +101,12 @@ func (s *BettingService) CalculateKellyStake(probability, odds, bankroll float64
 
 	kellyFraction := (b*p - q) / b
 
+	return s.scaleKellyStake(kellyFraction, bankroll, market)
+}
+
+// scaleKellyStake applies the market's fractional Kelly and the max bet cap to a
+// full-Kelly bankroll fraction, returning the stake
+func (s *BettingService) scaleKellyStake(kellyFraction, bankroll float64, market MarketType) float64 {
 	// Apply fractional Kelly based on market type
 	fraction := s.config.KellyFraction // Default 1/4 Kelly
 
@@ -101,6 +118,8 @@ func (s *BettingService) CalculateKellyStake(probability, odds, bankroll float64
 		fraction = s.config.KellyFraction * 0.8 // Slightly more conservative
 	case MarketTypeBTTS:
 		fraction = s.config.KellyFraction * 0.8 // Slightly more conservative
+	case MarketTypeHandicap:
+		fraction = s.config.KellyFraction * 0.8 // Slightly more conservative
 	}
 
 	adjustedKelly := kellyFraction * fraction
@@ -120,51 +139,88 @@ func (s *BettingService) CalculateKellyStake(probability, odds, bankroll float64
 
 // GetOutcomeDescription returns a human-readable description for an outcome
 func GetOutcomeDescription(market MarketType, outcome string) string {
-	descriptions := map[MarketType]map[string]string{
-		MarketType1X2: {
-			"home_win": "Home Win",
-			"draw":     "Draw",
-			"away_win": "Away Win",
-		},
-		MarketTypeOverUnder: {
-			"under_2_5": "Under 2.5 Goals",
-			"over_2_5":  "Over 2.5 Goals",
-		},
-		MarketTypeBTTS: {
-			"no":  "BTTS No",
-			"yes": "BTTS Yes",
-		},
-	}
-
-	if marketDescs, ok := descriptions[market]; ok {
-		if desc, ok := marketDescs[outcome]; ok {
-			return desc
-		}
+	if m, ok := markets.ForModel(string(market)); ok {
+		return m.Describe(outcome)
 	}
 	return outcome
 }
 
-// EvaluateFixture evaluates all markets for a single fixture
+// EvaluateFixture evaluates all markets for a single fixture. With sharpOnly, EV and
+// stakes are priced against the configured sharp bookmakers' odds only. probOverrides,
+// keyed by market then outcome, replaces the model's probabilities for what-if analysis;
+// pass nil to use the model as is.
 func (s *BettingService) EvaluateFixture(
 	ctx context.Context,
 	fixture *models.Fixture,
 	bankroll float64,
+	sharpOnly bool,
+	probOverrides map[string]map[string]float64,
 ) (*MultiMarketPick, error) {
-	// Get multi-market predictions from ML service
-	predictions, err := s.mlClient.PredictMultiMarket(ctx, fixture)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get predictions: %w", err)
+	return s.evaluateFixture(ctx, fixture, bankroll, sharpOnly, probOverrides, s.OddsRange())
+}
+
+// evaluateFixture evaluates a fixture, keeping only value outcomes priced within oddsRange
+func (s *BettingService) evaluateFixture(
+	ctx context.Context,
+	fixture *models.Fixture,
+	bankroll float64,
+	sharpOnly bool,
+	probOverrides map[string]map[string]float64,
+	oddsRange OddsRange,
+) (*MultiMarketPick, error) {
+	if err := ValidateProbOverrides(probOverrides); err != nil {
+		return nil, fmt.Errorf("invalid probability overrides: %w", err)
 	}
 
 	// Get odds for all markets
-	odds, err := s.oddsRepo.GetLatestByFixture(ctx, fixture.ID)
+	var odds []models.Odds
+	var err error
+	if sharpOnly {
+		odds, err = s.oddsRepo.GetLatestByFixtureForBookmakers(ctx, fixture.ID, s.config.SharpBookmakers)
+	} else {
+		odds, err = s.oddsRepo.GetLatestByFixture(ctx, fixture.ID)
+	}
 	if err != nil {
-		log.Printf("Warning: Could not get odds for fixture %d: %v", fixture.ID, err)
+		logging.Printf(ctx, "Warning: Could not get odds for fixture %d: %v", fixture.ID, err)
 		// Continue with synthetic odds
 	}
 
-	// Build odds map by market/outcome
+	// Get multi-market predictions from ML service, including any offered handicap lines
+	handicapLines := handicapLinesFromOdds(odds)
+	predictions, err := s.mlClient.PredictMultiMarketWithLines(ctx, fixture, handicapLines)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get predictions: %w", err)
+	}
+
+	applyProbOverrides(predictions, probOverrides)
+
+	pick := s.evaluatePredictions(fixture, odds, handicapLines, predictions, bankroll, oddsRange)
+	pick.SharpOnly = sharpOnly
+	pick.ProbOverrides = len(probOverrides) > 0
+	return pick, nil
+}
+
+// evaluatePredictions prices every predicted outcome against the available odds. Outcomes
+// priced outside oddsRange are still evaluated but never count as value bets.
+func (s *BettingService) evaluatePredictions(
+	fixture *models.Fixture,
+	odds []models.Odds,
+	handicapLines []float64,
+	predictions *MultiMarketPredictionResponse,
+	bankroll float64,
+	oddsRange OddsRange,
+) *MultiMarketPick {
+	// Fall back to handicap probabilities derived from 1X2 when the ML service doesn't provide them
+	if _, ok := predictions.Predictions[string(MarketTypeHandicap)]; !ok {
+		if derived := deriveHandicapPrediction(predictions, handicapLines); derived != nil {
+			predictions.Predictions[string(MarketTypeHandicap)] = *derived
+		}
+	}
+
+	// Build odds map by market/outcome, plus the market's vig-free view of each outcome
 	oddsMap := s.buildOddsMap(odds, predictions)
+	marketProbs := marketProbabilities(odds)
+	sharpProbs := marketProbabilities(SharpOdds(odds, s.config.SharpBookmakers))
 
 	// Evaluate all outcomes
 	var allOutcomes []BetOutcome
@@ -175,20 +231,19 @@ func (s *BettingService) EvaluateFixture(
 
 		for outcome, prob := range marketPred.Probabilities {
 			oddsKey := fmt.Sprintf("%s_%s", marketStr, outcome)
-			bestOdds, bookmaker := oddsMap[oddsKey], "synthetic"
+			quote := oddsMap[oddsKey]
+			bestOdds, bookmaker := quote.Odds, quote.Bookmaker
 
 			// If no real odds, use synthetic odds (fair odds with 5% margin)
 			if bestOdds == 0 && prob > 0 {
 				bestOdds = (1.0 / prob) * 0.95
+				bookmaker = "synthetic"
 			}
 
 			if bestOdds <= 1 {
 				continue // Invalid odds
 			}
 
-			ev := s.CalculateEV(prob, bestOdds)
-			stake := s.CalculateKellyStake(prob, bestOdds, bankroll, market)
-
 			betOutcome := BetOutcome{
 				Market:      market,
 				Outcome:     outcome,
@@ -196,18 +251,39 @@ func (s *BettingService) EvaluateFixture(
 				Probability: prob,
 				BestOdds:    bestOdds,
 				Bookmaker:   bookmaker,
-				EV:          ev,
-				EVPercent:   ev * 100,
-				KellyStake:  math.Round(stake*100) / 100,
+				EV:          s.CalculateEV(prob, bestOdds),
+				KellyStake:  s.CalculateKellyStake(prob, bestOdds, bankroll, market),
 				Confidence:  marketPred.Confidence,
 			}
+			betOutcome.EVPercent = betOutcome.EV * 100
+
+			if marketProb, ok := marketProbs[oddsKey]; ok {
+				betOutcome.MarketProbability = marketProb
+				betOutcome.Edge = math.Round((prob-marketProb)*10000) / 10000
+			}
+			if sharpProb, ok := sharpProbs[oddsKey]; ok {
+				betOutcome.SharpProbability = sharpProb
+			}
+			if bookmaker != "synthetic" {
+				betOutcome.BookmakerType = BookmakerType(s.config.SharpBookmakers, bookmaker)
+			}
 
 			allOutcomes = append(allOutcomes, betOutcome)
+		}
+	}
 
-			// Check if this is a value bet (meets minimum EV threshold)
-			if ev >= s.config.MinEVThreshold {
-				valueOutcomes = append(valueOutcomes, betOutcome)
-			}
+	// Size mutually exclusive value outcomes jointly rather than one at a time
+	s.applySimultaneousKelly(allOutcomes, bankroll)
+
+	for i := range allOutcomes {
+		outcome := &allOutcomes[i]
+		stake, stakeAdjusted, keep := normalizeStake(s.config, outcome.KellyStake)
+		outcome.KellyStake = stake
+		outcome.StakeAdjusted = stakeAdjusted
+
+		// Check if this is a value bet (meets minimum EV threshold and minimum stake, priced within range)
+		if outcome.EV >= s.config.MinEVThreshold && keep && oddsRange.Contains(outcome.BestOdds) {
+			valueOutcomes = append(valueOutcomes, *outcome)
 		}
 	}
 
@@ -242,75 +318,258 @@ func (s *BettingService) EvaluateFixture(
 		SuggestedStake: suggestedStake,
 		TotalEV:        totalEV,
 		EvaluatedAt:    time.Now(),
-	}, nil
+	}
 }
 
-// buildOddsMap creates a map of odds by market_outcome key
-func (s *BettingService) buildOddsMap(odds []models.Odds, predictions *MultiMarketPredictionResponse) map[string]float64 {
-	oddsMap := make(map[string]float64)
+// oddsQuote is the best price found for an outcome and the bookmaker offering it
+type oddsQuote struct {
+	Odds      float64
+	Bookmaker string
+}
+
+// buildOddsMap creates a map of the best (highest) odds by market_outcome key
+func (s *BettingService) buildOddsMap(odds []models.Odds, predictions *MultiMarketPredictionResponse) map[string]oddsQuote {
+	oddsMap := make(map[string]oddsQuote)
+
+	// Keep the highest price per key, remembering which bookmaker offered it
+	setBest := func(key string, odd models.Odds) {
+		if current, ok := oddsMap[key]; !ok || odd.OddsValue > current.Odds {
+			oddsMap[key] = oddsQuote{Odds: odd.OddsValue, Bookmaker: odd.Bookmaker}
+		}
+	}
 
 	for _, odd := range odds {
-		// Map market types from odds to our keys
-		switch odd.MarketType {
-		case "h2h", "1x2":
-			// Home/Draw/Away odds
-			if odd.Outcome == "Home" || odd.Outcome == "home" {
-				oddsMap["1x2_home_win"] = odd.OddsValue
-			} else if odd.Outcome == "Draw" || odd.Outcome == "draw" {
-				oddsMap["1x2_draw"] = odd.OddsValue
-			} else if odd.Outcome == "Away" || odd.Outcome == "away" {
-				oddsMap["1x2_away_win"] = odd.OddsValue
-			}
-		case "totals", "over_under":
-			// Over/Under odds
-			if odd.Outcome == "Over" || odd.Outcome == "over" {
-				oddsMap["over_under_over_2_5"] = odd.OddsValue
-			} else if odd.Outcome == "Under" || odd.Outcome == "under" {
-				oddsMap["over_under_under_2_5"] = odd.OddsValue
-			}
-		case "btts":
-			// Both Teams To Score odds
-			if odd.Outcome == "Yes" || odd.Outcome == "yes" {
-				oddsMap["btts_yes"] = odd.OddsValue
-			} else if odd.Outcome == "No" || odd.Outcome == "no" {
-				oddsMap["btts_no"] = odd.OddsValue
-			}
+		if key, _, ok := outcomeOddsKey(odd); ok {
+			setBest(key, odd)
 		}
 	}
 
 	return oddsMap
 }
 
-// GetMultiMarketWeeklyPicks generates weekly picks across all markets
-func (s *BettingService) GetMultiMarketWeeklyPicks(ctx context.Context, bankroll float64) ([]*MultiMarketPick, error) {
+// oddsGroup identifies a set of mutually exclusive outcomes priced together
+type oddsGroup struct {
+	Market string  // Canonical odds market (h2h, totals, btts, spreads)
+	Line   float64 // Totals line, or the home team's handicap line
+}
+
+// outcomeOddsKey maps a stored odds row to its market_outcome key (as used for
+// ML predictions) and the group of outcomes it is priced against
+func outcomeOddsKey(odd models.Odds) (string, oddsGroup, bool) {
+	market, ok := markets.Get(odd.MarketType)
+	if !ok {
+		return "", oddsGroup{}, false
+	}
+
+	outcome, ok := market.ModelOutcome(odd.Outcome, odd.Line)
+	if !ok {
+		return "", oddsGroup{}, false
+	}
+
+	// Group by line: totals on the goals line, handicaps on the home team's line
+	// (the stored line is from the outcome side's perspective)
+	group := oddsGroup{Market: market.MainKey()}
+	switch market.ModelKey {
+	case markets.ModelOverUnder:
+		group.Line = odd.Line
+		if group.Line == 0 {
+			group.Line = markets.DefaultTotalsLine
+		}
+	case markets.ModelHandicap:
+		group.Line = odd.Line
+		if strings.EqualFold(odd.Outcome, "away") {
+			group.Line = -odd.Line
+		}
+	}
+
+	return market.ModelKey + "_" + outcome, group, true
+}
+
+// marketProbabilities returns vig-free market probabilities keyed like buildOddsMap,
+// using the average price across bookmakers. Groups missing an outcome are skipped
+// since they can't be normalized.
+func marketProbabilities(odds []models.Odds) map[string]float64 {
+	type priceAgg struct {
+		sum   float64
+		count int
+	}
+
+	groups := make(map[oddsGroup]map[string]*priceAgg)
+	for _, odd := range odds {
+		if odd.OddsValue <= 1 {
+			continue
+		}
+		key, group, ok := outcomeOddsKey(odd)
+		if !ok {
+			continue
+		}
+		if groups[group] == nil {
+			groups[group] = make(map[string]*priceAgg)
+		}
+		if groups[group][key] == nil {
+			groups[group][key] = &priceAgg{}
+		}
+		groups[group][key].sum += odd.OddsValue
+		groups[group][key].count++
+	}
+
+	probs := make(map[string]float64)
+	for group, outcomes := range groups {
+		if len(outcomes) != markets.OutcomeCount(group.Market) {
+			continue
+		}
+
+		prices := make(map[string]float64, len(outcomes))
+		for key, agg := range outcomes {
+			prices[key] = agg.sum / float64(agg.count)
+		}
+
+		for _, o := range CalculateImpliedProbabilities(prices, true).Outcomes {
+			probs[o.Outcome] = o.NoVigProb
+		}
+	}
+
+	return probs
+}
+
+// handicapLinesFromOdds returns the distinct home-team handicap lines offered in the odds
+func handicapLinesFromOdds(odds []models.Odds) []float64 {
+	seen := make(map[float64]bool)
+	var lines []float64
+
+	for _, odd := range odds {
+		if market, ok := markets.Get(odd.MarketType); !ok || market.ModelKey != markets.ModelHandicap {
+			continue
+		}
+
+		line := odd.Line
+		if odd.Outcome == "Away" || odd.Outcome == "away" {
+			line = -line // Convert to the home team's line
+		}
+
+		if !seen[line] {
+			seen[line] = true
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
+}
+
+// deriveHandicapPrediction derives half-goal handicap probabilities from 1X2 probabilities.
+// Only +/-0.5 lines can be derived exactly; other lines need the ML service.
+func deriveHandicapPrediction(predictions *MultiMarketPredictionResponse, homeLines []float64) *MarketPrediction {
+	result, ok := predictions.Predictions[string(MarketType1X2)]
+	if !ok || len(homeLines) == 0 {
+		return nil
+	}
+
+	home := result.Probabilities["home_win"]
+	draw := result.Probabilities["draw"]
+	away := result.Probabilities["away_win"]
+
+	probs := make(map[string]float64)
+	for _, line := range homeLines {
+		switch line {
+		case -0.5: // Home must win; Away +0.5 covers draw or away win
+			probs[markets.HandicapOutcomeKey("home", -0.5)] = home
+			probs[markets.HandicapOutcomeKey("away", 0.5)] = draw + away
+		case 0.5: // Home +0.5 covers home win or draw; Away must win
+			probs[markets.HandicapOutcomeKey("home", 0.5)] = home + draw
+			probs[markets.HandicapOutcomeKey("away", -0.5)] = away
+		}
+	}
+
+	if len(probs) == 0 {
+		return nil
+	}
+
+	return &MarketPrediction{
+		Market:        string(MarketTypeHandicap),
+		Description:   "Asian Handicap (derived from 1X2)",
+		Probabilities: probs,
+		Confidence:    result.Confidence,
+	}
+}
+
+const (
+	// fixtureEvalTimeout bounds how long a single fixture evaluation may take
+	fixtureEvalTimeout = 10 * time.Second
+	// batchEvalPerFixture is the extra time a batch prediction gets per fixture
+	batchEvalPerFixture = 500 * time.Millisecond
+	// maxBatchEvalTimeout caps a batch prediction at the ML client's own timeout
+	maxBatchEvalTimeout = 30 * time.Second
+	// fallbackEvalTimeout bounds all per-fixture evaluations after a failed batch, so a
+	// struggling ML service can't hold a request for fixtureEvalTimeout per fixture
+	fallbackEvalTimeout = 30 * time.Second
+)
+
+// batchEvalTimeout returns the deadline for one batch prediction covering n fixtures
+func batchEvalTimeout(n int) time.Duration {
+	return min(fixtureEvalTimeout+time.Duration(n)*batchEvalPerFixture, maxBatchEvalTimeout)
+}
+
+// ErrAllFixturesFailed is returned when no upcoming fixture could be evaluated
+var ErrAllFixturesFailed = errors.New("failed to evaluate every upcoming fixture")
+
+// PicksResult holds multi-market picks along with evaluation status
+type PicksResult struct {
+	Picks       []*MultiMarketPick
+	All         []*MultiMarketPick // Every fixture evaluated, including those without a value bet
+	Evaluated   int  // Fixtures evaluated successfully
+	Skipped     int  // Fixtures skipped because evaluation failed
+	MLAvailable bool // Whether the ML service was reachable
+	StakeScale  float64 // Factor applied to suggested stakes by the exposure cap (1 = unchanged)
+}
+
+// EvaluateUpcomingFixtures evaluates upcoming fixtures in the window, skipping any that fail.
+// An error is only returned if every fixture failed.
+func (s *BettingService) EvaluateUpcomingFixtures(ctx context.Context, bankroll float64, window FixtureWindow, oddsRange OddsRange) (*PicksResult, error) {
+	result := &PicksResult{
+		Picks:       []*MultiMarketPick{},
+		MLAvailable: true,
+	}
+
 	// Get upcoming fixtures
-	fixtures, err := s.fixturesRepo.GetUpcoming(ctx, 20)
+	fixtures, err := s.fixturesRepo.GetUpcomingInWindow(ctx, window.From, window.To)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get upcoming fixtures: %w", err)
 	}
 
 	if len(fixtures) == 0 {
-		log.Println("No upcoming fixtures found")
-		return []*MultiMarketPick{}, nil
+		logging.Printf(ctx, "No upcoming fixtures found")
+		return result, nil
 	}
 
-	var picks []*MultiMarketPick
-
+	// Load odds up front so the ML service can be called once for every fixture
+	fixturePtrs := make([]*models.Fixture, len(fixtures))
+	fixtureOdds := make(map[int][]models.Odds, len(fixtures))
 	for i := range fixtures {
 		fixture := &fixtures[i]
-		pick, err := s.EvaluateFixture(ctx, fixture, bankroll)
+		fixturePtrs[i] = fixture
+
+		odds, err := s.oddsRepo.GetLatestByFixture(ctx, fixture.ID)
 		if err != nil {
-			log.Printf("Warning: Failed to evaluate fixture %d: %v", fixture.ID, err)
-			continue
+			logging.Printf(ctx, "Warning: Could not get odds for fixture %d: %v", fixture.ID, err)
+			// Continue with synthetic odds
 		}
+		fixtureOdds[fixture.ID] = odds
+	}
 
-		// Only include fixtures with at least one value bet
-		if pick.BestOutcome != nil {
-			picks = append(picks, pick)
+	s.evaluateLoadedFixtures(ctx, result, fixturePtrs, fixtureOdds, bankroll, oddsRange)
+
+	if result.Skipped > 0 {
+		if _, err := s.mlClient.HealthCheck(ctx); err != nil {
+			result.MLAvailable = false
 		}
 	}
 
+	if result.Evaluated == 0 {
+		return result, ErrAllFixturesFailed
+	}
+
 	// Sort picks by best outcome EV (highest first)
+	picks := result.Picks
 	sort.Slice(picks, func(i, j int) bool {
 		if picks[i].BestOutcome == nil {
 			return false
@@ -321,21 +580,214 @@ func (s *BettingService) GetMultiMarketWeeklyPicks(ctx context.Context, bankroll
 		return picks[i].BestOutcome.EV > picks[j].BestOutcome.EV
 	})
 
-	return picks, nil
+	return result, nil
+}
+
+// evaluateLoadedFixtures prices fixtures whose odds are already loaded, recording each
+// in result. Predictions come from one batch ML request; if that fails, fixtures are
+// predicted one at a time within fallbackEvalTimeout, and those left when it runs out
+// (or when ctx is done) are skipped.
+func (s *BettingService) evaluateLoadedFixtures(
+	ctx context.Context,
+	result *PicksResult,
+	fixtures []*models.Fixture,
+	fixtureOdds map[int][]models.Odds,
+	bankroll float64,
+	oddsRange OddsRange,
+) {
+	handicapLines := make(map[int][]float64, len(fixtures))
+	for _, fixture := range fixtures {
+		handicapLines[fixture.ID] = handicapLinesFromOdds(fixtureOdds[fixture.ID])
+	}
+
+	batchCtx, cancel := context.WithTimeout(ctx, batchEvalTimeout(len(fixtures)))
+	predictions, batchErr := s.mlClient.PredictMultiMarketBatch(batchCtx, fixtures, handicapLines)
+	cancel()
+	if batchErr != nil {
+		logging.Printf(ctx, "Warning: Batch prediction failed, evaluating fixtures individually: %v", batchErr)
+	}
+
+	fallbackCtx, cancelFallback := context.WithTimeout(ctx, fallbackEvalTimeout)
+	defer cancelFallback()
+
+	for _, fixture := range fixtures {
+		pred, ok := predictions[fixture.ID]
+		if batchErr == nil && !ok {
+			logging.Printf(ctx, "Warning: Skipping fixture %d: no prediction returned", fixture.ID)
+			result.Skipped++
+			continue
+		}
+
+		if batchErr != nil {
+			if err := fallbackCtx.Err(); err != nil {
+				logging.Printf(ctx, "Warning: Skipping fixture %d: %v", fixture.ID, err)
+				result.Skipped++
+				continue
+			}
+
+			// Bound each prediction so one slow fixture doesn't use up the fallback budget
+			fixtureCtx, cancel := context.WithTimeout(fallbackCtx, fixtureEvalTimeout)
+			var err error
+			pred, err = s.mlClient.PredictMultiMarketWithLines(fixtureCtx, fixture, handicapLines[fixture.ID])
+			cancel()
+			if err != nil {
+				logging.Printf(ctx, "Warning: Skipping fixture %d: failed to get predictions: %v", fixture.ID, err)
+				result.Skipped++
+				continue
+			}
+		}
+
+		pick := s.evaluatePredictions(fixture, fixtureOdds[fixture.ID], handicapLines[fixture.ID], pred, bankroll, oddsRange)
+		result.Evaluated++
+		result.All = append(result.All, pick)
+
+		// Only include fixtures with at least one value bet
+		if pick.BestOutcome != nil {
+			result.Picks = append(result.Picks, pick)
+		}
+	}
+}
+
+// GetMultiMarketWeeklyPicks generates picks across all markets for fixtures in the window
+func (s *BettingService) GetMultiMarketWeeklyPicks(ctx context.Context, bankroll float64, window FixtureWindow, oddsRange OddsRange) ([]*MultiMarketPick, error) {
+	result, err := s.EvaluateUpcomingFixtures(ctx, bankroll, window, oddsRange)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Picks, nil
+}
+
+// GetTopPicks returns the top N picks by EV along with evaluation status
+func (s *BettingService) GetTopPicks(ctx context.Context, bankroll float64, limit int, window FixtureWindow, oddsRange OddsRange) (*PicksResult, error) {
+	result, err := s.EvaluateUpcomingFixtures(ctx, bankroll, window, oddsRange)
+	if err != nil {
+		return result, err
+	}
+
+	if len(result.Picks) > limit {
+		result.Picks = result.Picks[:limit]
+	}
+
+	result.StakeScale = s.ApplyExposureCap(result.Picks, bankroll)
+
+	return result, nil
+}
+
+// ApplyExposureCap scales suggested stakes down proportionally so that the picks
+// together never risk more than MaxTotalExposure of the bankroll. Kelly sizes each
+// bet in isolation, so taking every pick at once would otherwise over-commit.
+// Outcome KellyStake values are left as the independent sizing. Returns the
+// scaling factor applied (1 if the total was already within the cap).
+func (s *BettingService) ApplyExposureCap(picks []*MultiMarketPick, bankroll float64) float64 {
+	maxExposure := bankroll * s.config.MaxTotalExposure
+
+	total := 0.0
+	for _, pick := range picks {
+		total += pick.SuggestedStake
+	}
+
+	if total <= maxExposure || total == 0 {
+		return 1
+	}
+
+	scale := maxExposure / total
+	for _, pick := range picks {
+		pick.SuggestedStake = floorStake(s.config, pick.SuggestedStake*scale)
+	}
+
+	return scale
+}
+
+// ValueBet is a single value outcome together with its fixture
+type ValueBet struct {
+	Fixture models.Fixture `json:"fixture"`
+	BetOutcome
+}
+
+// OddsRange bounds the decimal odds a value bet may be priced at. A zero bound is open.
+type OddsRange struct {
+	Min float64 `json:"min,omitempty"`
+	Max float64 `json:"max,omitempty"`
 }
 
-// GetTopPicks returns the top N picks by EV
-func (s *BettingService) GetTopPicks(ctx context.Context, bankroll float64, limit int) ([]*MultiMarketPick, error) {
-	allPicks, err := s.GetMultiMarketWeeklyPicks(ctx, bankroll)
+// Contains reports whether odds fall within the range, bounds included
+func (r OddsRange) Contains(odds float64) bool {
+	if r.Min > 0 && odds < r.Min {
+		return false
+	}
+	if r.Max > 0 && odds > r.Max {
+		return false
+	}
+	return true
+}
+
+// OddsRange returns the configured value bet odds range
+func (s *BettingService) OddsRange() OddsRange {
+	return OddsRange{Min: s.config.MinOdds, Max: s.config.MaxOdds}
+}
+
+// ValueBetFilter narrows value bets by EV, confidence, market, odds, and kick-off window
+type ValueBetFilter struct {
+	MinEV         float64
+	MinConfidence float64
+	Markets       []MarketType // Empty means all markets
+	Odds          OddsRange
+	Window        FixtureWindow
+}
+
+// marketAliases maps odds market keys to internal market types
+var marketAliases = map[string]MarketType{
+	"h2h":     MarketType1X2,
+	"totals":  MarketTypeOverUnder,
+	"spreads": MarketTypeHandicap,
+}
+
+// ParseMarketType resolves a market name or odds market key (h2h, totals, spreads) to a MarketType
+func ParseMarketType(name string) (MarketType, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if market, ok := marketAliases[name]; ok {
+		return market, true
+	}
+
+	switch market := MarketType(name); market {
+	case MarketType1X2, MarketTypeOverUnder, MarketTypeBTTS, MarketTypeHandicap:
+		return market, true
+	}
+
+	return "", false
+}
+
+// GetValueBets returns value outcomes across upcoming fixtures matching the filter, sorted by EV
+func (s *BettingService) GetValueBets(ctx context.Context, bankroll float64, filter ValueBetFilter) ([]ValueBet, error) {
+	picks, err := s.GetMultiMarketWeeklyPicks(ctx, bankroll, filter.Window, filter.Odds)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(allPicks) <= limit {
-		return allPicks, nil
+	allowed := make(map[MarketType]bool, len(filter.Markets))
+	for _, market := range filter.Markets {
+		allowed[market] = true
+	}
+
+	valueBets := []ValueBet{}
+	for _, pick := range picks {
+		for _, outcome := range pick.ValueOutcomes {
+			if outcome.EV < filter.MinEV || outcome.Confidence < filter.MinConfidence {
+				continue
+			}
+			if len(allowed) > 0 && !allowed[outcome.Market] {
+				continue
+			}
+			valueBets = append(valueBets, ValueBet{Fixture: pick.Fixture, BetOutcome: outcome})
+		}
 	}
 
-	return allPicks[:limit], nil
+	sort.Slice(valueBets, func(i, j int) bool {
+		return valueBets[i].EV > valueBets[j].EV
+	})
+
+	return valueBets, nil
 }
 
 // PicksSummary represents a summary of weekly picks
@@ -347,14 +799,22 @@ type PicksSummary struct {
 	PicksByMarket      map[string]int         `json:"picks_by_market"`
 	AverageEV          float64               `json:"average_ev"`
 	Bankroll           float64               `json:"bankroll"`
+	Currency           string                `json:"currency"`
+	Skipped            int                   `json:"skipped"`      // Fixtures that could not be evaluated
+	MLAvailable        bool                  `json:"ml_available"` // Whether the ML service was reachable
+	MaxStakeAllocation float64               `json:"max_stake_allocation"`
+	StakeScale         float64               `json:"stake_scale"` // < 1 when stakes were reduced to fit the allocation
 }
 
 // GetPicksSummary calculates summary statistics for picks
 func (s *BettingService) GetPicksSummary(picks []*MultiMarketPick, bankroll float64) *PicksSummary {
 	summary := &PicksSummary{
-		TotalPicks:    len(picks),
-		PicksByMarket: make(map[string]int),
-		Bankroll:      bankroll,
+		TotalPicks:         len(picks),
+		PicksByMarket:      make(map[string]int),
+		Bankroll:           bankroll,
+		Currency:           s.config.StakeCurrency,
+		MaxStakeAllocation: bankroll * s.config.MaxTotalExposure,
+		StakeScale:         1,
 	}
 
 	for _, pick := range picks {
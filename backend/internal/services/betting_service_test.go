@@ -0,0 +1,253 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dEnchanter/OddsIQ/backend/config"
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+)
+
+func TestBuildOddsMap_ThreeBookmakers_KeepsHighestPriceAndBookmaker(t *testing.T) {
+	s := &BettingService{}
+	odds := []models.Odds{
+		{Bookmaker: "bet365", MarketType: "h2h", Outcome: "Home", OddsValue: 2.10},
+		{Bookmaker: "pinnacle", MarketType: "h2h", Outcome: "Home", OddsValue: 2.25},
+		{Bookmaker: "williamhill", MarketType: "h2h", Outcome: "Home", OddsValue: 2.05},
+		{Bookmaker: "bet365", MarketType: "h2h", Outcome: "Draw", OddsValue: 3.40},
+		{Bookmaker: "pinnacle", MarketType: "h2h", Outcome: "Draw", OddsValue: 3.30},
+		{Bookmaker: "williamhill", MarketType: "h2h", Outcome: "Draw", OddsValue: 3.50},
+		{Bookmaker: "bet365", MarketType: "h2h", Outcome: "Away", OddsValue: 3.60},
+		{Bookmaker: "pinnacle", MarketType: "h2h", Outcome: "Away", OddsValue: 3.55},
+		{Bookmaker: "williamhill", MarketType: "h2h", Outcome: "Away", OddsValue: 3.40},
+	}
+
+	oddsMap := s.buildOddsMap(odds, nil)
+
+	expected := map[string]oddsQuote{
+		"1x2_home_win": {Odds: 2.25, Bookmaker: "pinnacle"},
+		"1x2_draw":     {Odds: 3.50, Bookmaker: "williamhill"},
+		"1x2_away_win": {Odds: 3.60, Bookmaker: "bet365"},
+	}
+	if len(oddsMap) != len(expected) {
+		t.Errorf("Expected %d keys, got %d: %+v", len(expected), len(oddsMap), oddsMap)
+	}
+	for key, want := range expected {
+		if got := oddsMap[key]; got != want {
+			t.Errorf("%s: expected %+v, got %+v", key, want, got)
+		}
+	}
+}
+
+func TestBuildOddsMap_TiedPrice_KeepsFirstBookmaker(t *testing.T) {
+	s := &BettingService{}
+	odds := []models.Odds{
+		{Bookmaker: "bet365", MarketType: "h2h", Outcome: "Home", OddsValue: 2.20},
+		{Bookmaker: "pinnacle", MarketType: "h2h", Outcome: "Home", OddsValue: 2.20},
+	}
+
+	got := s.buildOddsMap(odds, nil)["1x2_home_win"]
+	if got.Bookmaker != "bet365" || got.Odds != 2.20 {
+		t.Errorf("Expected bet365 at 2.20, got %+v", got)
+	}
+}
+
+// newCountingMLServer stands in for the ML service, counting batch and single-fixture
+// prediction requests. When failBatch is set the batch endpoint returns 500.
+func newCountingMLServer(t *testing.T, failBatch bool, batchCalls, singleCalls *int32) *httptest.Server {
+	t.Helper()
+
+	prediction := `"predictions":{"1x2":{"market":"1x2","probabilities":{"home_win":0.5,"draw":0.3,"away_win":0.2},"confidence":0.6}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/predict/multi/batch":
+			atomic.AddInt32(batchCalls, 1)
+			if failBatch {
+				http.Error(w, "model unavailable", http.StatusInternalServerError)
+				return
+			}
+
+			var req struct {
+				Fixtures []struct {
+					FixtureID int `json:"fixture_id"`
+				} `json:"fixtures"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("invalid batch request: %v", err)
+			}
+			items := make([]string, len(req.Fixtures))
+			for i, f := range req.Fixtures {
+				items[i] = fmt.Sprintf(`{"fixture_id":%d,%s}`, f.FixtureID, prediction)
+			}
+			fmt.Fprintf(w, `{"predictions":[%s],"count":%d}`, strings.Join(items, ","), len(items))
+		case "/api/predict/multi":
+			atomic.AddInt32(singleCalls, 1)
+			fmt.Fprintf(w, `{%s}`, prediction)
+		default:
+			fmt.Fprint(w, `{"status":"healthy"}`)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// newEvalTestFixtures returns n upcoming fixtures, each with home/draw/away odds
+func newEvalTestFixtures(n int) ([]*models.Fixture, map[int][]models.Odds) {
+	fixtures := make([]*models.Fixture, n)
+	odds := make(map[int][]models.Odds, n)
+	for i := range fixtures {
+		id := i + 1
+		fixtures[i] = &models.Fixture{ID: id, HomeTeamID: 100 + id, AwayTeamID: 200 + id, MatchDate: time.Now().Add(48 * time.Hour)}
+		odds[id] = []models.Odds{
+			{FixtureID: id, Bookmaker: "bet365", MarketType: "h2h", Outcome: "Home", OddsValue: 2.40},
+			{FixtureID: id, Bookmaker: "bet365", MarketType: "h2h", Outcome: "Draw", OddsValue: 3.20},
+			{FixtureID: id, Bookmaker: "bet365", MarketType: "h2h", Outcome: "Away", OddsValue: 4.00},
+		}
+	}
+	return fixtures, odds
+}
+
+func newEvalTestService(mlURL string) *BettingService {
+	cfg := &config.Config{MinEVThreshold: 0.03, KellyFraction: 0.25, MaxBetPercentage: 0.05}
+	return NewBettingService(cfg, NewMLClient(mlURL), nil, nil)
+}
+
+func TestEvaluateLoadedFixtures_BatchSucceeds_OneMLCallForAllFixtures(t *testing.T) {
+	var batchCalls, singleCalls int32
+	server := newCountingMLServer(t, false, &batchCalls, &singleCalls)
+	s := newEvalTestService(server.URL)
+	fixtures, odds := newEvalTestFixtures(5)
+
+	result := &PicksResult{}
+	s.evaluateLoadedFixtures(context.Background(), result, fixtures, odds, 1000, OddsRange{})
+
+	if batchCalls != 1 {
+		t.Errorf("Expected 1 batch call, got %d", batchCalls)
+	}
+	if singleCalls != 0 {
+		t.Errorf("Expected 0 single-fixture calls, got %d", singleCalls)
+	}
+	if result.Evaluated != 5 || result.Skipped != 0 {
+		t.Errorf("Expected 5 evaluated and 0 skipped, got %d and %d", result.Evaluated, result.Skipped)
+	}
+}
+
+func TestEvaluateLoadedFixtures_BatchFails_FallsBackPerFixture(t *testing.T) {
+	var batchCalls, singleCalls int32
+	server := newCountingMLServer(t, true, &batchCalls, &singleCalls)
+	s := newEvalTestService(server.URL)
+	fixtures, odds := newEvalTestFixtures(3)
+
+	result := &PicksResult{}
+	s.evaluateLoadedFixtures(context.Background(), result, fixtures, odds, 1000, OddsRange{})
+
+	if batchCalls != 1 {
+		t.Errorf("Expected 1 batch call, got %d", batchCalls)
+	}
+	if singleCalls != 3 {
+		t.Errorf("Expected 3 single-fixture calls, got %d", singleCalls)
+	}
+	if result.Evaluated != 3 {
+		t.Errorf("Expected 3 evaluated, got %d", result.Evaluated)
+	}
+}
+
+func TestEvaluateLoadedFixtures_ContextCancelled_SkipsFallback(t *testing.T) {
+	var batchCalls, singleCalls int32
+	server := newCountingMLServer(t, true, &batchCalls, &singleCalls)
+	s := newEvalTestService(server.URL)
+	fixtures, odds := newEvalTestFixtures(3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result := &PicksResult{}
+	s.evaluateLoadedFixtures(ctx, result, fixtures, odds, 1000, OddsRange{})
+
+	if singleCalls != 0 {
+		t.Errorf("Expected no single-fixture calls after cancellation, got %d", singleCalls)
+	}
+	if result.Skipped != 3 {
+		t.Errorf("Expected 3 skipped, got %d", result.Skipped)
+	}
+}
+
+func TestBatchEvalTimeout_ScalesWithFixturesUpToCap(t *testing.T) {
+	if got := batchEvalTimeout(1); got <= fixtureEvalTimeout {
+		t.Errorf("Expected more than %v for one fixture, got %v", fixtureEvalTimeout, got)
+	}
+	if got := batchEvalTimeout(1000); got != maxBatchEvalTimeout {
+		t.Errorf("Expected %v, got %v", maxBatchEvalTimeout, got)
+	}
+}
+
+func TestOddsRange_Contains_BoundsIncluded(t *testing.T) {
+	tests := []struct {
+		name     string
+		r        OddsRange
+		odds     float64
+		expected bool
+	}{
+		{"exactly at min", OddsRange{Min: 1.5}, 1.5, true},
+		{"just below min", OddsRange{Min: 1.5}, 1.49, false},
+		{"exactly at max", OddsRange{Max: 5.0}, 5.0, true},
+		{"just above max", OddsRange{Max: 5.0}, 5.01, false},
+		{"at both bounds of a point range", OddsRange{Min: 2.0, Max: 2.0}, 2.0, true},
+		{"inside both bounds", OddsRange{Min: 1.5, Max: 5.0}, 3.0, true},
+		{"no bounds", OddsRange{}, 101, true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.r.Contains(tt.odds); got != tt.expected {
+			t.Errorf("%s: expected %v for %v in %+v, got %v", tt.name, tt.expected, tt.odds, tt.r, got)
+		}
+	}
+}
+
+func TestEvaluatePredictions_OddsAtRangeBounds_IncludedAsValueBets(t *testing.T) {
+	fixture := &models.Fixture{ID: 1}
+	odds := []models.Odds{
+		{Bookmaker: "bet365", MarketType: "h2h", Outcome: "Home", OddsValue: 2.40},
+		{Bookmaker: "bet365", MarketType: "h2h", Outcome: "Draw", OddsValue: 3.60},
+		{Bookmaker: "bet365", MarketType: "h2h", Outcome: "Away", OddsValue: 6.00},
+	}
+	prediction := func() *MultiMarketPredictionResponse {
+		return &MultiMarketPredictionResponse{Predictions: map[string]MarketPrediction{
+			"1x2": {Market: "1x2", Probabilities: map[string]float64{"home_win": 0.5, "draw": 0.3, "away_win": 0.2}},
+		}}
+	}
+	s := newEvalTestService("")
+
+	tests := []struct {
+		name     string
+		r        OddsRange
+		expected []string
+	}{
+		{"bounds at lowest and highest price", OddsRange{Min: 2.40, Max: 6.00}, []string{"away_win", "draw", "home_win"}},
+		{"min just above lowest price", OddsRange{Min: 2.41}, []string{"away_win", "draw"}},
+		{"max just below highest price", OddsRange{Max: 5.99}, []string{"draw", "home_win"}},
+		{"point range on the draw price", OddsRange{Min: 3.60, Max: 3.60}, []string{"draw"}},
+	}
+
+	for _, tt := range tests {
+		pick := s.evaluatePredictions(fixture, odds, nil, prediction(), 1000, tt.r)
+
+		var got []string
+		for _, outcome := range pick.ValueOutcomes {
+			got = append(got, outcome.Outcome)
+		}
+		slices.Sort(got)
+		if !slices.Equal(got, tt.expected) {
+			t.Errorf("%s: expected value outcomes %v, got %v", tt.name, tt.expected, got)
+		}
+	}
+}
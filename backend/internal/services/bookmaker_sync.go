@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+	"github.com/dEnchanter/OddsIQ/backend/internal/repository"
+	"github.com/dEnchanter/OddsIQ/backend/pkg/apifootball"
+	"github.com/dEnchanter/OddsIQ/backend/pkg/oddsapi"
+)
+
+// BookmakerSyncService keeps the bookmakers reference table in line with the
+// bookmakers each odds source offers
+type BookmakerSyncService struct {
+	apiClient       *apifootball.Client
+	bookmakersRepo  *repository.BookmakersRepository
+	statusRepo      *repository.SyncStatusRepository // optional, records sync outcomes
+	sharpBookmakers []string                         // Bookmaker keys classed as sharp
+}
+
+// NewBookmakerSyncService creates a new bookmaker sync service
+func NewBookmakerSyncService(
+	apiClient *apifootball.Client,
+	bookmakersRepo *repository.BookmakersRepository,
+	statusRepo *repository.SyncStatusRepository,
+	sharpBookmakers []string,
+) *BookmakerSyncService {
+	return &BookmakerSyncService{
+		apiClient:       apiClient,
+		bookmakersRepo:  bookmakersRepo,
+		statusRepo:      statusRepo,
+		sharpBookmakers: sharpBookmakers,
+	}
+}
+
+// SyncBookmakers upserts The Odds API and API-Football bookmakers and records the
+// outcome in sync_status. Keys match those stored on odds rows from each source, and
+// the sharp flag follows the configured sharp bookmakers.
+func (s *BookmakerSyncService) SyncBookmakers(ctx context.Context) error {
+	log.Println("Syncing bookmakers...")
+
+	total, err := s.syncBookmakers(ctx)
+	recordSyncRun(ctx, s.statusRepo, models.SyncTypeBookmakers, total, err)
+	return err
+}
+
+// syncBookmakers upserts both sources and returns the number of bookmakers stored
+func (s *BookmakerSyncService) syncBookmakers(ctx context.Context) (int, error) {
+	total := 0
+	for _, info := range oddsapi.Bookmakers {
+		bookmaker := &models.Bookmaker{
+			Key:    info.Key,
+			Name:   info.Title,
+			Source: models.OddsSourceOddsAPI,
+			Region: info.Region,
+		}
+		if err := s.upsert(ctx, bookmaker); err != nil {
+			return total, err
+		}
+		total++
+	}
+
+	footballBookmakers, err := s.apiClient.GetBookmakers(ctx)
+	if err != nil {
+		return total, fmt.Errorf("failed to fetch API-Football bookmakers: %w", err)
+	}
+
+	for _, info := range footballBookmakers {
+		bookmaker := &models.Bookmaker{
+			Key:    APIFootballBookmakerKey(info.Name),
+			Name:   info.Name,
+			Source: models.OddsSourceAPIFootball,
+		}
+		if err := s.upsert(ctx, bookmaker); err != nil {
+			return total, err
+		}
+		total++
+	}
+
+	log.Printf("Synced %d bookmakers", total)
+	return total, nil
+}
+
+// upsert classifies a bookmaker as sharp or soft and stores it
+func (s *BookmakerSyncService) upsert(ctx context.Context, bookmaker *models.Bookmaker) error {
+	bookmaker.IsSharp = BookmakerType(s.sharpBookmakers, bookmaker.Key) == models.BookmakerTypeSharp
+	if err := s.bookmakersRepo.Upsert(ctx, bookmaker); err != nil {
+		return fmt.Errorf("failed to store bookmaker %s: %w", bookmaker.Key, err)
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package services
+
+import (
+	"math"
+	"sort"
+
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+)
+
+// MinCalibrationSamples is the number of outcome samples (three per fixture) needed
+// before fitted calibration is applied to predictions
+const MinCalibrationSamples = 300
+
+// DefaultCalibrationBuckets is the number of reliability diagram buckets
+const DefaultCalibrationBuckets = 10
+
+// CalibrationSample is one predicted probability and whether the outcome happened
+type CalibrationSample struct {
+	Predicted float64
+	Occurred  bool
+}
+
+// ReliabilityBucket is one point on a reliability diagram
+type ReliabilityBucket struct {
+	Lower             float64 `json:"lower"`
+	Upper             float64 `json:"upper"`
+	MeanPredicted     float64 `json:"mean_predicted"`
+	ObservedFrequency float64 `json:"observed_frequency"`
+	Count             int     `json:"count"`
+}
+
+// CalibrationReport summarizes how well predicted probabilities match results
+type CalibrationReport struct {
+	Buckets    []ReliabilityBucket `json:"buckets"`
+	Fixtures   int                 `json:"fixtures"`
+	Samples    int                 `json:"samples"`
+	BrierScore float64             `json:"brier_score"`
+	Applied    bool                `json:"applied"` // Whether calibration is applied to EV calculations
+}
+
+// CalibrationSamples expands prediction results into one sample per 1X2 outcome
+func CalibrationSamples(results []models.PredictionResult) []CalibrationSample {
+	samples := make([]CalibrationSample, 0, len(results)*3)
+	for _, r := range results {
+		samples = append(samples,
+			CalibrationSample{Predicted: r.HomeWinProb, Occurred: r.HomeScore > r.AwayScore},
+			CalibrationSample{Predicted: r.DrawProb, Occurred: r.HomeScore == r.AwayScore},
+			CalibrationSample{Predicted: r.AwayWinProb, Occurred: r.AwayScore > r.HomeScore},
+		)
+	}
+	return samples
+}
+
+// ReliabilityBuckets groups samples into equal-width probability buckets and returns
+// the mean prediction and observed frequency of each non-empty bucket
+func ReliabilityBuckets(samples []CalibrationSample, numBuckets int) []ReliabilityBucket {
+	if numBuckets <= 0 {
+		numBuckets = DefaultCalibrationBuckets
+	}
+
+	predictedSums := make([]float64, numBuckets)
+	occurred := make([]int, numBuckets)
+	counts := make([]int, numBuckets)
+
+	for _, sample := range samples {
+		i := int(sample.Predicted * float64(numBuckets))
+		if i >= numBuckets {
+			i = numBuckets - 1 // p = 1.0 falls in the top bucket
+		}
+		if i < 0 {
+			i = 0
+		}
+
+		predictedSums[i] += sample.Predicted
+		counts[i]++
+		if sample.Occurred {
+			occurred[i]++
+		}
+	}
+
+	buckets := []ReliabilityBucket{}
+	width := 1.0 / float64(numBuckets)
+	for i := 0; i < numBuckets; i++ {
+		if counts[i] == 0 {
+			continue
+		}
+		buckets = append(buckets, ReliabilityBucket{
+			Lower:             math.Round(float64(i)*width*10000) / 10000,
+			Upper:             math.Round(float64(i+1)*width*10000) / 10000,
+			MeanPredicted:     math.Round(predictedSums[i]/float64(counts[i])*10000) / 10000,
+			ObservedFrequency: math.Round(float64(occurred[i])/float64(counts[i])*10000) / 10000,
+			Count:             counts[i],
+		})
+	}
+
+	return buckets
+}
+
+// BrierScore returns the mean squared error between predictions and outcomes
+func BrierScore(samples []CalibrationSample) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+
+	var sum float64
+	for _, sample := range samples {
+		outcome := 0.0
+		if sample.Occurred {
+			outcome = 1
+		}
+		sum += (sample.Predicted - outcome) * (sample.Predicted - outcome)
+	}
+
+	return sum / float64(len(samples))
+}
+
+// IsotonicCalibrator maps raw probabilities to calibrated ones using a monotonic
+// step function fitted with pool-adjacent-violators, interpolating between steps
+type IsotonicCalibrator struct {
+	x []float64 // Mean raw probability of each block
+	y []float64 // Observed frequency of each block
+}
+
+// FitIsotonic fits an isotonic calibration curve to the samples
+func FitIsotonic(samples []CalibrationSample) *IsotonicCalibrator {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	sorted := make([]CalibrationSample, len(samples))
+	copy(sorted, samples)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Predicted < sorted[j].Predicted
+	})
+
+	type block struct {
+		sumX, sumY float64
+		n          float64
+	}
+
+	var blocks []block
+	for _, sample := range sorted {
+		y := 0.0
+		if sample.Occurred {
+			y = 1
+		}
+		blocks = append(blocks, block{sumX: sample.Predicted, sumY: y, n: 1})
+
+		// Merge backwards while the observed frequencies decrease
+		for len(blocks) > 1 {
+			last, prev := blocks[len(blocks)-1], blocks[len(blocks)-2]
+			if prev.sumY/prev.n <= last.sumY/last.n {
+				break
+			}
+			blocks = blocks[:len(blocks)-2]
+			blocks = append(blocks, block{sumX: prev.sumX + last.sumX, sumY: prev.sumY + last.sumY, n: prev.n + last.n})
+		}
+	}
+
+	cal := &IsotonicCalibrator{}
+	for _, b := range blocks {
+		cal.x = append(cal.x, b.sumX/b.n)
+		cal.y = append(cal.y, b.sumY/b.n)
+	}
+
+	return cal
+}
+
+// Calibrate returns the calibrated probability for a raw probability.
+// A nil calibrator returns the input unchanged.
+func (c *IsotonicCalibrator) Calibrate(p float64) float64 {
+	if c == nil || len(c.x) == 0 {
+		return p
+	}
+
+	if p <= c.x[0] {
+		return c.y[0]
+	}
+	last := len(c.x) - 1
+	if p >= c.x[last] {
+		return c.y[last]
+	}
+
+	i := sort.SearchFloat64s(c.x, p)
+	if c.x[i] == p {
+		return c.y[i]
+	}
+
+	// Linear interpolation between neighbouring blocks
+	t := (p - c.x[i-1]) / (c.x[i] - c.x[i-1])
+	return c.y[i-1] + t*(c.y[i]-c.y[i-1])
+}
+
+// CalibratePrediction returns a copy of the prediction with calibrated 1X2
+// probabilities, renormalized to sum to one
+func (c *IsotonicCalibrator) CalibratePrediction(pred *models.Prediction) *models.Prediction {
+	if c == nil {
+		return pred
+	}
+
+	home := c.Calibrate(pred.HomeWinProb)
+	draw := c.Calibrate(pred.DrawProb)
+	away := c.Calibrate(pred.AwayWinProb)
+
+	total := home + draw + away
+	if total <= 0 {
+		return pred
+	}
+
+	calibrated := *pred
+	calibrated.HomeWinProb = home / total
+	calibrated.DrawProb = draw / total
+	calibrated.AwayWinProb = away / total
+
+	return &calibrated
+}
@@ -0,0 +1,227 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	htmltemplate "html/template"
+	"log"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+// digestTemplateFuncs format numbers in the email digest templates
+var digestTemplateFuncs = map[string]interface{}{
+	"pct":   func(v float64) string { return fmt.Sprintf("%+.1f%%", v*100) },
+	"odds":  func(v float64) string { return fmt.Sprintf("%.2f", v) },
+	"money": func(v float64) string { return fmt.Sprintf("%.2f", v) },
+	"date":  func(t time.Time) string { return t.Format("Mon 2 Jan") },
+	"kickoff": func(t time.Time) string {
+		return t.UTC().Format("Mon 2 Jan 15:04 MST")
+	},
+	"inc": func(i int) int { return i + 1 },
+}
+
+// digestTextTemplate is the plain text part of the email digest, shown by clients that
+// don't render HTML
+var digestTextTemplate = texttemplate.Must(texttemplate.New("digest.txt").Funcs(digestTemplateFuncs).Parse(
+	`OddsIQ weekly picks ({{date .Window.From}} - {{date .Window.To}})
+
+VALUE PICKS
+{{- range $i, $p := .Picks}}
+
+{{inc $i}}. {{$p.Match}} ({{kickoff $p.Kickoff}})
+   {{$p.Selection}} @ {{odds $p.Odds}} ({{$p.Bookmaker}})
+   EV {{pct $p.EV}} | Stake {{money $p.Stake}} {{$.Currency}}
+{{- else}}
+
+No value picks this week.
+{{- end}}
+{{- if .Accumulators}}
+
+ACCUMULATORS
+{{- range $i, $a := .Accumulators}}
+
+{{inc $i}}. {{len $a.Legs}} legs @ {{odds $a.CombinedOdds}} | EV {{pct $a.EV}} | Stake {{money $a.Stake}} {{$.Currency}}
+{{- range $a.Legs}}
+   - {{.Match}}: {{.Selection}} @ {{odds .Odds}}
+{{- end}}
+{{- end}}
+{{- end}}
+
+Generated {{.GeneratedAt.UTC.Format "2006-01-02 15:04 MST"}}
+`))
+
+// digestHTMLTemplate is the HTML part of the email digest
+var digestHTMLTemplate = htmltemplate.Must(htmltemplate.New("digest.html").Funcs(digestTemplateFuncs).Parse(
+	`<!DOCTYPE html>
+<html>
+<body style="font-family: Arial, sans-serif; color: #222;">
+<h2>OddsIQ weekly picks</h2>
+<p>{{date .Window.From}} - {{date .Window.To}}</p>
+
+<h3>Value picks</h3>
+{{- if .Picks}}
+<table cellpadding="6" style="border-collapse: collapse;">
+<tr style="background: #f0f0f0; text-align: left;">
+<th>Match</th><th>Kick-off</th><th>Selection</th><th>Odds</th><th>EV</th><th>Stake ({{.Currency}})</th>
+</tr>
+{{- range .Picks}}
+<tr style="border-top: 1px solid #ddd;">
+<td>{{.Match}}</td><td>{{kickoff .Kickoff}}</td><td>{{.Selection}}</td>
+<td>{{odds .Odds}} <small>({{.Bookmaker}})</small></td><td>{{pct .EV}}</td><td>{{money .Stake}}</td>
+</tr>
+{{- end}}
+</table>
+{{- else}}
+<p>No value picks this week.</p>
+{{- end}}
+{{- if .Accumulators}}
+
+<h3>Accumulators</h3>
+{{- range $i, $a := .Accumulators}}
+<p><strong>{{inc $i}}. {{len $a.Legs}} legs @ {{odds $a.CombinedOdds}}</strong>
+| EV {{pct $a.EV}} | Stake {{money $a.Stake}} {{$.Currency}}</p>
+<ul>
+{{- range $a.Legs}}
+<li>{{.Match}}: {{.Selection}} @ {{odds .Odds}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- end}}
+
+<p style="color: #888; font-size: 12px;">Generated {{.GeneratedAt.UTC.Format "2006-01-02 15:04 MST"}}</p>
+</body>
+</html>
+`))
+
+// emailSink sends the weekly digest by email over SMTP
+type emailSink struct {
+	addr string // host:port
+	host string
+	auth smtp.Auth // nil when the server needs no authentication
+	from string
+	to   []string
+}
+
+// newEmailSink creates an email sink. Authentication is only used when a username is set.
+func newEmailSink(host string, port int, username, password, from string, to []string) *emailSink {
+	sink := &emailSink{
+		addr: net.JoinHostPort(host, strconv.Itoa(port)),
+		host: host,
+		from: from,
+		to:   to,
+	}
+	if username != "" {
+		sink.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return sink
+}
+
+// Name identifies the sink in logs
+func (e *emailSink) Name() string {
+	return "email"
+}
+
+// Send renders the digest as a text and HTML email and sends it to every recipient.
+// smtp.SendMail upgrades to TLS when the server offers STARTTLS.
+func (e *emailSink) Send(ctx context.Context, digest *WeeklyDigest) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf("OddsIQ weekly picks: %s - %s", digest.Window.From.Format("2 Jan"), digest.Window.To.Format("2 Jan"))
+	msg, err := e.buildMessage(subject, digest)
+	if err != nil {
+		return err
+	}
+
+	// The envelope takes bare addresses; the headers keep any display names
+	from, err := mail.ParseAddress(e.from)
+	if err != nil {
+		return fmt.Errorf("invalid sender address: %w", err)
+	}
+	recipients := make([]string, 0, len(e.to))
+	for _, to := range e.to {
+		addr, err := mail.ParseAddress(to)
+		if err != nil {
+			return fmt.Errorf("invalid recipient address %q: %w", to, err)
+		}
+		recipients = append(recipients, addr.Address)
+	}
+
+	if err := smtp.SendMail(e.addr, e.auth, from.Address, recipients, msg); err != nil {
+		log.Printf("Failed to send weekly digest email to %s via %s: %v", strings.Join(e.to, ", "), e.addr, err)
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+// buildMessage renders a multipart/alternative message with the plain text part first,
+// so clients that can't render HTML fall back to it
+func (e *emailSink) buildMessage(subject string, digest *WeeklyDigest) ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+
+	for _, part := range []struct {
+		contentType string
+		render      func(*bytes.Buffer) error
+	}{
+		{"text/plain; charset=UTF-8", func(buf *bytes.Buffer) error { return digestTextTemplate.Execute(buf, digest) }},
+		{"text/html; charset=UTF-8", func(buf *bytes.Buffer) error { return digestHTMLTemplate.Execute(buf, digest) }},
+	} {
+		var rendered bytes.Buffer
+		if err := part.render(&rendered); err != nil {
+			return nil, fmt.Errorf("failed to render email: %w", err)
+		}
+
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to build email: %w", err)
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write(rendered.Bytes()); err != nil {
+			return nil, fmt.Errorf("failed to build email: %w", err)
+		}
+		if err := qp.Close(); err != nil {
+			return nil, fmt.Errorf("failed to build email: %w", err)
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, fmt.Errorf("failed to build email: %w", err)
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", e.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(e.to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "Message-ID: <%s@%s>\r\n", randomMessageID(), e.host)
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", parts.Boundary())
+	msg.Write(body.Bytes())
+
+	return msg.Bytes(), nil
+}
+
+// randomMessageID returns a random local part for the Message-ID header
+func randomMessageID() string {
+	b := make([]byte, 12)
+	_, _ = rand.Read(b) // crypto/rand doesn't fail on supported platforms
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"time"
+
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+)
+
+// Fixture statuses (API-Football short codes) with lifecycle handling
+const (
+	FixtureStatusNotStarted = "NS"
+	FixtureStatusTBD        = "TBD"
+	FixtureStatusPostponed  = "PST"
+	FixtureStatusCancelled  = "CANC"
+	FixtureStatusAbandoned  = "ABD"
+	FixtureStatusAwarded    = "AWD"
+	FixtureStatusWalkover   = "WO"
+)
+
+// postponedVoidAfter is how long after its original kickoff a postponed fixture waits
+// for a new date before its open bets are voided
+const postponedVoidAfter = 48 * time.Hour
+
+// voidStatuses are the statuses of matches that were not played out on the pitch.
+// Bets on them are void (stake refunded) rather than graded: an awarded result or a
+// walkover isn't the match that was priced, and an abandoned match has no final score.
+//
+// Void differs from a push: a push is a finished match graded to a refund because the
+// score landed exactly on an integer line (see GradeOutcome); both settle as "void".
+var voidStatuses = map[string]bool{
+	FixtureStatusCancelled: true,
+	FixtureStatusAbandoned: true,
+	FixtureStatusAwarded:   true,
+	FixtureStatusWalkover:  true,
+}
+
+// IsFinished reports whether a fixture status is a completed match
+func IsFinished(status string) bool {
+	return status == "FT" || status == "AET" || status == "PEN"
+}
+
+// HasKickedOff reports whether a fixture has started: its status has moved on from not
+// started, or its kickoff time has passed before the status was synced. Postponed and
+// cancelled fixtures never kicked off.
+func HasKickedOff(fixture *models.Fixture, now time.Time) bool {
+	switch fixture.Status {
+	case FixtureStatusNotStarted, FixtureStatusTBD, "":
+		return !now.Before(fixture.MatchDate)
+	case FixtureStatusPostponed, FixtureStatusCancelled:
+		return false
+	}
+	return true
+}
+
+// IsVoidStatus reports whether bets on a fixture with this status are void
+func IsVoidStatus(status string) bool {
+	return voidStatuses[status]
+}
+
+// ShouldVoidBets reports whether a fixture's open bets should be voided: it was
+// cancelled, abandoned, awarded or a walkover, or it was postponed and no new date
+// arrived within postponedVoidAfter of the original kickoff
+func ShouldVoidBets(fixture *models.Fixture, now time.Time) bool {
+	if IsVoidStatus(fixture.Status) {
+		return true
+	}
+	return fixture.Status == FixtureStatusPostponed && now.Sub(fixture.MatchDate) >= postponedVoidAfter
+}
+
+// IsRescheduled reports whether a postponed fixture has been given a new date. The
+// fixture is moved back to not started so it shows as upcoming again.
+func IsRescheduled(previous *models.Fixture, status string, matchDate time.Time) bool {
+	if previous == nil || previous.Status != FixtureStatusPostponed {
+		return false
+	}
+	if status != FixtureStatusNotStarted && status != FixtureStatusTBD {
+		return false
+	}
+	return !matchDate.Equal(previous.MatchDate)
+}
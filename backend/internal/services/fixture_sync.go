@@ -2,8 +2,11 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/dEnchanter/OddsIQ/backend/internal/models"
@@ -16,6 +19,15 @@ type FixtureSyncService struct {
 	apiClient   *apifootball.Client
 	teamsRepo   *repository.TeamsRepository
 	fixturesRepo *repository.FixturesRepository
+	leaguesRepo  *repository.LeaguesRepository
+	settlement   *BetSettlementService            // optional, settles open bets on finished fixtures
+	statusRepo   *repository.SyncStatusRepository // optional, records sync outcomes
+	leagueIDs    []int                            // API-Football league IDs synced by scheduled jobs
+
+	// Team IDs by API-Football ID, saving a lookup per fixture. The mapping never
+	// changes once a team is stored, so entries don't expire.
+	teamIDs   map[int]int
+	teamIDsMu sync.RWMutex
 }
 
 // NewFixtureSyncService creates a new fixture sync service
@@ -23,26 +35,40 @@ func NewFixtureSyncService(
 	apiClient *apifootball.Client,
 	teamsRepo *repository.TeamsRepository,
 	fixturesRepo *repository.FixturesRepository,
+	leaguesRepo *repository.LeaguesRepository,
+	settlement *BetSettlementService,
+	statusRepo *repository.SyncStatusRepository,
+	leagueIDs []int,
 ) *FixtureSyncService {
 	return &FixtureSyncService{
 		apiClient:   apiClient,
 		teamsRepo:   teamsRepo,
 		fixturesRepo: fixturesRepo,
+		leaguesRepo:  leaguesRepo,
+		settlement:   settlement,
+		statusRepo:   statusRepo,
+		leagueIDs:    leagueIDs,
+		teamIDs:      make(map[int]int),
 	}
 }
 
-// SyncTeams fetches and stores Premier League teams
-func (s *FixtureSyncService) SyncTeams(ctx context.Context, season int) error {
-	log.Printf("Syncing teams for season %d...", season)
+// SyncTeams fetches and stores teams for a league and season
+func (s *FixtureSyncService) SyncTeams(ctx context.Context, leagueID, season int) error {
+	log.Printf("Syncing teams for league %d season %d...", leagueID, season)
 
 	// Fetch teams from API
-	teamsResp, err := s.apiClient.GetTeams(apifootball.PremierLeagueID, season)
+	teamsResp, err := s.apiClient.GetTeams(ctx, leagueID, season)
 	if err != nil {
 		return fmt.Errorf("failed to fetch teams: %w", err)
 	}
 
 	log.Printf("Fetched %d teams from API", len(teamsResp))
 
+	league, err := s.leaguesRepo.GetByAPIFootballID(ctx, leagueID)
+	if err != nil {
+		log.Printf("League %d not in leagues table, teams will not be tagged: %v", leagueID, err)
+	}
+
 	// Upsert each team
 	for _, teamResp := range teamsResp {
 		team := &models.Team{
@@ -60,6 +86,13 @@ func (s *FixtureSyncService) SyncTeams(ctx context.Context, season int) error {
 			log.Printf("Failed to upsert team %s: %v", team.Name, err)
 			continue
 		}
+		s.cacheTeamID(team.APIFootballID, team.ID)
+
+		if league != nil {
+			if err := s.leaguesRepo.AddTeam(ctx, league.ID, team.ID, season); err != nil {
+				log.Printf("Failed to tag team %s with league %s: %v", team.Name, league.Name, err)
+			}
+		}
 
 		log.Printf("Upserted team: %s (ID: %d)", team.Name, team.ID)
 	}
@@ -68,43 +101,215 @@ func (s *FixtureSyncService) SyncTeams(ctx context.Context, season int) error {
 	return nil
 }
 
-// SyncFixturesBySeason fetches and stores all fixtures for a season
-func (s *FixtureSyncService) SyncFixturesBySeason(ctx context.Context, season int) error {
-	log.Printf("Syncing fixtures for season %d...", season)
+// SeasonSyncOptions controls a season fixture sync
+type SeasonSyncOptions struct {
+	// ResumeAfter skips the rounds up to and including this one, resuming a partially
+	// completed season. Empty syncs every round.
+	ResumeAfter string
+	// OnRound, if set, is called after each round completes with the round name and
+	// the number of fixtures stored so far. An error stops the sync.
+	OnRound func(ctx context.Context, round string, synced int) error
+	// Workers is how many fixtures of a round are stored concurrently (default 1)
+	Workers int
+	// Bulk writes the season with one FixturesRepository.BulkUpsert instead of a
+	// statement per fixture. Rescheduling checks and bet settlement are skipped, so
+	// it is meant for historical backfills.
+	Bulk bool
+}
+
+// SyncFixturesBySeason fetches and stores all fixtures for a league and season, round
+// by round in kick-off order
+func (s *FixtureSyncService) SyncFixturesBySeason(ctx context.Context, leagueID, season int, opts SeasonSyncOptions) error {
+	log.Printf("Syncing fixtures for league %d season %d...", leagueID, season)
 
 	// Fetch fixtures from API
-	fixturesResp, err := s.apiClient.GetFixtures(apifootball.PremierLeagueID, season)
+	fixturesResp, err := s.apiClient.GetFixtures(ctx, leagueID, season)
 	if err != nil {
 		return fmt.Errorf("failed to fetch fixtures: %w", err)
 	}
 
 	log.Printf("Fetched %d fixtures from API", len(fixturesResp))
 
-	// Process each fixture
-	successCount := 0
-	for _, fixtureResp := range fixturesResp {
-		if err := s.processFixture(ctx, fixtureResp, season); err != nil {
-			log.Printf("Failed to process fixture %d: %v", fixtureResp.Fixture.ID, err)
-			continue
+	rounds, byRound := groupFixturesByRound(fixturesResp)
+
+	if opts.ResumeAfter != "" {
+		resumed := false
+		for i, round := range rounds {
+			if round == opts.ResumeAfter {
+				log.Printf("Resuming after round %q (%d/%d rounds done)", round, i+1, len(rounds))
+				rounds = rounds[i+1:]
+				resumed = true
+				break
+			}
+		}
+		if !resumed {
+			log.Printf("Round %q not found, syncing all rounds", opts.ResumeAfter)
 		}
-		successCount++
 	}
 
-	log.Printf("Successfully synced %d/%d fixtures", successCount, len(fixturesResp))
+	// In bulk mode the remaining rounds are written together in one upsert, checkpointed
+	// at the last round
+	process := s.processFixtures
+	if opts.Bulk && len(rounds) > 0 {
+		process = s.bulkProcessFixtures
+		last := rounds[len(rounds)-1]
+		var remaining []apifootball.FixtureResponse
+		for _, round := range rounds {
+			remaining = append(remaining, byRound[round]...)
+		}
+		rounds, byRound = []string{last}, map[string][]apifootball.FixtureResponse{last: remaining}
+	}
+
+	// Process each round. A round's fixtures are stored concurrently, but a round only
+	// completes once all of them are done so checkpoints never skip a fixture.
+	successCount, total := 0, 0
+	var failures []error
+	for _, round := range rounds {
+		stored, roundFailures, err := process(ctx, byRound[round], season, opts.Workers)
+		if err != nil {
+			return fmt.Errorf("failed to store round %q: %w", round, err)
+		}
+		total += len(byRound[round])
+		successCount += stored
+		failures = append(failures, roundFailures...)
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if opts.OnRound != nil {
+			if err := opts.OnRound(ctx, round, successCount); err != nil {
+				return fmt.Errorf("failed to record progress after round %q: %w", round, err)
+			}
+		}
+	}
+
+	log.Printf("Successfully synced %d/%d fixtures", successCount, total)
+	if len(failures) > 0 {
+		log.Printf("Failed to process %d fixtures:", len(failures))
+		for _, err := range failures {
+			log.Printf("  %v", err)
+		}
+	}
 	return nil
 }
 
-// SyncFixturesByDateRange fetches and stores fixtures within a date range
-func (s *FixtureSyncService) SyncFixturesByDateRange(ctx context.Context, from, to time.Time) error {
+// processFixtures stores fixtures one statement each using up to workers goroutines,
+// returning how many were stored along with an error per fixture that failed
+func (s *FixtureSyncService) processFixtures(ctx context.Context, fixtures []apifootball.FixtureResponse, season, workers int) (int, []error, error) {
+	failures := forEachFixture(ctx, fixtures, workers, func(fixtureResp apifootball.FixtureResponse) error {
+		return s.processFixture(ctx, fixtureResp, season)
+	})
+	return len(fixtures) - len(failures), failures, nil
+}
+
+// bulkProcessFixtures converts fixtures using up to workers goroutines and stores them
+// with a single bulk upsert, returning how many were stored and the fixtures that
+// couldn't be converted. A failed upsert stores nothing and is returned as the error.
+func (s *FixtureSyncService) bulkProcessFixtures(ctx context.Context, fixtures []apifootball.FixtureResponse, season, workers int) (int, []error, error) {
+	var mu sync.Mutex
+	built := make([]models.Fixture, 0, len(fixtures))
+
+	failures := forEachFixture(ctx, fixtures, workers, func(fixtureResp apifootball.FixtureResponse) error {
+		fixture, err := s.buildFixture(ctx, fixtureResp, season)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		built = append(built, *fixture)
+		mu.Unlock()
+		return nil
+	})
+
+	stored, err := s.fixturesRepo.BulkUpsert(ctx, built)
+	if err != nil {
+		return 0, failures, err
+	}
+
+	return stored, failures, nil
+}
+
+// forEachFixture calls fn for each fixture on up to workers goroutines, stopping early
+// if ctx is cancelled, and returns an error per fixture that failed
+func forEachFixture(ctx context.Context, fixtures []apifootball.FixtureResponse, workers int, fn func(apifootball.FixtureResponse) error) []error {
+	if workers < 1 {
+		workers = 1
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		failures []error
+	)
+
+	jobs := make(chan apifootball.FixtureResponse)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for fixtureResp := range jobs {
+				if err := fn(fixtureResp); err != nil {
+					mu.Lock()
+					failures = append(failures, fmt.Errorf("fixture %d: %w", fixtureResp.Fixture.ID, err))
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for _, fixtureResp := range fixtures {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- fixtureResp
+	}
+	close(jobs)
+	wg.Wait()
+
+	return failures
+}
+
+// groupFixturesByRound groups fixtures by round, returning the rounds in order of their
+// first kick-off
+func groupFixturesByRound(fixtures []apifootball.FixtureResponse) ([]string, map[string][]apifootball.FixtureResponse) {
+	sorted := make([]apifootball.FixtureResponse, len(fixtures))
+	copy(sorted, fixtures)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Fixture.Date.Before(sorted[j].Fixture.Date)
+	})
+
+	var rounds []string
+	byRound := make(map[string][]apifootball.FixtureResponse)
+	for _, f := range sorted {
+		round := f.League.Round
+		if _, ok := byRound[round]; !ok {
+			rounds = append(rounds, round)
+		}
+		byRound[round] = append(byRound[round], f)
+	}
+
+	return rounds, byRound
+}
+
+// SyncFixturesByDateRange fetches and stores a league's fixtures within a date range
+func (s *FixtureSyncService) SyncFixturesByDateRange(ctx context.Context, leagueID int, from, to time.Time) error {
+	_, err := s.syncFixturesByDateRange(ctx, leagueID, from, to)
+	return err
+}
+
+// syncFixturesByDateRange syncs a league's fixtures within a date range and returns
+// the number stored
+func (s *FixtureSyncService) syncFixturesByDateRange(ctx context.Context, leagueID int, from, to time.Time) (int, error) {
 	fromStr := from.Format("2006-01-02")
 	toStr := to.Format("2006-01-02")
 
-	log.Printf("Syncing fixtures from %s to %s...", fromStr, toStr)
+	log.Printf("Syncing fixtures for league %d from %s to %s...", leagueID, fromStr, toStr)
 
 	// Fetch fixtures from API
-	fixturesResp, err := s.apiClient.GetFixturesByDateRange(fromStr, toStr)
+	fixturesResp, err := s.apiClient.GetFixturesByDateRange(ctx, leagueID, fromStr, toStr)
 	if err != nil {
-		return fmt.Errorf("failed to fetch fixtures: %w", err)
+		return 0, fmt.Errorf("failed to fetch fixtures: %w", err)
 	}
 
 	log.Printf("Fetched %d fixtures from API", len(fixturesResp))
@@ -123,20 +328,90 @@ func (s *FixtureSyncService) SyncFixturesByDateRange(ctx context.Context, from,
 	}
 
 	log.Printf("Successfully synced %d/%d fixtures", successCount, len(fixturesResp))
-	return nil
+	return successCount, nil
 }
 
-// SyncUpcomingFixtures syncs upcoming fixtures (next 7 days)
+// SyncUpcomingFixtures syncs upcoming fixtures (next 7 days) for all configured leagues
+// and records the outcome in sync_status. A failing league doesn't stop the others;
+// their errors are joined, and the run stops early only when the quota is exhausted.
 func (s *FixtureSyncService) SyncUpcomingFixtures(ctx context.Context) error {
 	now := time.Now()
 	to := now.AddDate(0, 0, 7) // Next 7 days
 
-	return s.SyncFixturesByDateRange(ctx, now, to)
+	var total int
+	var errs []error
+	for _, leagueID := range s.leagueIDs {
+		synced, syncErr := s.syncFixturesByDateRange(ctx, leagueID, now, to)
+		total += synced
+		if syncErr != nil {
+			errs = append(errs, fmt.Errorf("league %d: %w", leagueID, syncErr))
+			if apifootball.IsQuotaExceeded(syncErr) {
+				break
+			}
+		}
+	}
+
+	err := errors.Join(errs...)
+	recordSyncRun(ctx, s.statusRepo, models.SyncTypeFixtures, total, err)
+	return err
 }
 
-// UpdateFixtureResults updates scores and status for recently completed fixtures
+// UpdateFixtureResults updates scores and status for recently completed fixtures in all
+// configured leagues and records the outcome in sync_status. As with upcoming fixtures,
+// league errors are joined rather than stopping the remaining leagues. Postponed bets
+// are only settled when every league updated, since a failed league may hold reschedules.
 func (s *FixtureSyncService) UpdateFixtureResults(ctx context.Context) error {
-	log.Println("Updating fixture results...")
+	var total int
+	var errs []error
+	for _, leagueID := range s.leagueIDs {
+		updated, syncErr := s.updateLeagueResults(ctx, leagueID)
+		total += updated
+		if syncErr != nil {
+			errs = append(errs, fmt.Errorf("league %d: %w", leagueID, syncErr))
+			if apifootball.IsQuotaExceeded(syncErr) {
+				break
+			}
+		}
+	}
+
+	err := errors.Join(errs...)
+	if err == nil {
+		s.settlePostponed(ctx)
+	}
+
+	recordSyncRun(ctx, s.statusRepo, models.SyncTypeResults, total, err)
+	return err
+}
+
+// settlePostponed voids open bets on postponed fixtures that fell outside the results
+// window without being rescheduled. Failures are logged since results already updated.
+func (s *FixtureSyncService) settlePostponed(ctx context.Context) {
+	if s.settlement == nil {
+		return
+	}
+
+	fixtures, err := s.fixturesRepo.GetByStatuses(ctx, []string{FixtureStatusPostponed})
+	if err != nil {
+		log.Printf("Failed to get postponed fixtures: %v", err)
+		return
+	}
+
+	now := time.Now()
+	for i := range fixtures {
+		if !ShouldVoidBets(&fixtures[i], now) {
+			continue
+		}
+
+		if _, err := s.settlement.SettleFixture(ctx, fixtures[i].ID); err != nil {
+			log.Printf("Failed to settle bets for postponed fixture %d: %v", fixtures[i].ID, err)
+		}
+	}
+}
+
+// updateLeagueResults updates recent fixture results for a single league and returns
+// the number updated
+func (s *FixtureSyncService) updateLeagueResults(ctx context.Context, leagueID int) (int, error) {
+	log.Printf("Updating fixture results for league %d...", leagueID)
 
 	// Get fixtures from last 2 days that might have been completed
 	from := time.Now().AddDate(0, 0, -2)
@@ -146,9 +421,9 @@ func (s *FixtureSyncService) UpdateFixtureResults(ctx context.Context) error {
 	fromStr := from.Format("2006-01-02")
 	toStr := to.Format("2006-01-02")
 
-	fixturesResp, err := s.apiClient.GetFixturesByDateRange(fromStr, toStr)
+	fixturesResp, err := s.apiClient.GetFixturesByDateRange(ctx, leagueID, fromStr, toStr)
 	if err != nil {
-		return fmt.Errorf("failed to fetch fixtures: %w", err)
+		return 0, fmt.Errorf("failed to fetch fixtures: %w", err)
 	}
 
 	log.Printf("Checking %d fixtures for result updates", len(fixturesResp))
@@ -166,67 +441,127 @@ func (s *FixtureSyncService) UpdateFixtureResults(ctx context.Context) error {
 	}
 
 	log.Printf("Successfully updated %d/%d fixtures", successCount, len(fixturesResp))
-	return nil
+	return successCount, nil
 }
 
 // processFixture converts API fixture to model and upserts to database
 func (s *FixtureSyncService) processFixture(ctx context.Context, fixtureResp apifootball.FixtureResponse, season int) error {
-	// Get team IDs from database using API-Football IDs
-	homeTeam, err := s.teamsRepo.GetByAPIFootballID(ctx, fixtureResp.Teams.Home.ID)
+	fixture, err := s.buildFixture(ctx, fixtureResp, season)
 	if err != nil {
-		return fmt.Errorf("home team not found: %w", err)
+		return err
+	}
+
+	// A postponed fixture that has been given a new date goes back to not started
+	if previous, err := s.fixturesRepo.GetByAPIFootballID(ctx, fixture.APIFootballID); err == nil &&
+		IsRescheduled(previous, fixture.Status, fixture.MatchDate) {
+		log.Printf("Fixture %d rescheduled from %s to %s", previous.ID,
+			previous.MatchDate.Format(time.RFC3339), fixture.MatchDate.Format(time.RFC3339))
+		fixture.Status = FixtureStatusNotStarted
+	}
+
+	// Upsert fixture
+	if err := s.fixturesRepo.Upsert(ctx, fixture); err != nil {
+		return fmt.Errorf("failed to upsert fixture: %w", err)
+	}
+
+	// Grade any open bets once the result is final, or void them if the match won't be played
+	if s.settlement != nil && (IsFinished(fixture.Status) || IsVoidStatus(fixture.Status)) {
+		settled, err := s.settlement.SettleFixture(ctx, fixture.ID)
+		if err != nil {
+			log.Printf("Failed to settle bets for fixture %d: %v", fixture.ID, err)
+		} else if settled > 0 {
+			log.Printf("Settled %d bets for fixture %d", settled, fixture.ID)
+		}
 	}
 
-	awayTeam, err := s.teamsRepo.GetByAPIFootballID(ctx, fixtureResp.Teams.Away.ID)
+	return nil
+}
+
+// buildFixture converts an API fixture to a model, resolving its teams and league
+func (s *FixtureSyncService) buildFixture(ctx context.Context, fixtureResp apifootball.FixtureResponse, season int) (*models.Fixture, error) {
+	// Get team IDs from database using API-Football IDs
+	homeTeamID, err := s.teamID(ctx, fixtureResp.Teams.Home.ID)
 	if err != nil {
-		return fmt.Errorf("away team not found: %w", err)
+		return nil, fmt.Errorf("home team not found: %w", err)
 	}
 
-	// Extract scores (may be nil if match hasn't started)
-	var homeScore, awayScore *int
-	if fixtureResp.Goals.Home >= 0 {
-		homeScore = &fixtureResp.Goals.Home
+	awayTeamID, err := s.teamID(ctx, fixtureResp.Teams.Away.ID)
+	if err != nil {
+		return nil, fmt.Errorf("away team not found: %w", err)
 	}
-	if fixtureResp.Goals.Away >= 0 {
-		awayScore = &fixtureResp.Goals.Away
+
+	// Extract scores (nil if match hasn't started)
+	homeScore := fixtureResp.Goals.Home
+	awayScore := fixtureResp.Goals.Away
+
+	// Resolve league (left unset if the league isn't in the leagues table)
+	var leagueID *int
+	if league, err := s.leaguesRepo.GetByAPIFootballID(ctx, fixtureResp.League.ID); err == nil {
+		leagueID = &league.ID
 	}
 
 	// Create fixture model
-	fixture := &models.Fixture{
+	return &models.Fixture{
 		APIFootballID: fixtureResp.Fixture.ID,
+		LeagueID:      leagueID,
 		Season:        season,
 		MatchDate:     fixtureResp.Fixture.Date,
 		Round:         fixtureResp.League.Round,
-		HomeTeamID:    homeTeam.ID,
-		AwayTeamID:    awayTeam.ID,
+		HomeTeamID:    homeTeamID,
+		AwayTeamID:    awayTeamID,
 		Status:        fixtureResp.Fixture.Status.Short,
 		HomeScore:     homeScore,
 		AwayScore:     awayScore,
 		VenueName:     fixtureResp.Fixture.Venue.Name,
 		Referee:       fixtureResp.Fixture.Referee,
+	}, nil
+}
+
+// teamID returns the internal ID of a team by API-Football ID, from the cache when
+// possible
+func (s *FixtureSyncService) teamID(ctx context.Context, apiFootballID int) (int, error) {
+	s.teamIDsMu.RLock()
+	id, ok := s.teamIDs[apiFootballID]
+	s.teamIDsMu.RUnlock()
+	if ok {
+		return id, nil
 	}
 
-	// Upsert fixture
-	if err := s.fixturesRepo.Upsert(ctx, fixture); err != nil {
-		return fmt.Errorf("failed to upsert fixture: %w", err)
+	team, err := s.teamsRepo.GetByAPIFootballID(ctx, apiFootballID)
+	if err != nil {
+		return 0, err
 	}
 
-	return nil
+	s.cacheTeamID(apiFootballID, team.ID)
+	return team.ID, nil
+}
+
+// cacheTeamID remembers a team's internal ID
+func (s *FixtureSyncService) cacheTeamID(apiFootballID, id int) {
+	s.teamIDsMu.Lock()
+	s.teamIDs[apiFootballID] = id
+	s.teamIDsMu.Unlock()
 }
 
-// SyncAllSeasons syncs teams and fixtures for multiple seasons
-func (s *FixtureSyncService) SyncAllSeasons(ctx context.Context, seasons []int) error {
+// SyncAllSeasons syncs teams and fixtures for a league across multiple seasons
+func (s *FixtureSyncService) SyncAllSeasons(ctx context.Context, leagueID int, seasons []int) error {
 	for _, season := range seasons {
-		log.Printf("=== Syncing season %d ===", season)
+		log.Printf("=== Syncing league %d season %d ===", leagueID, season)
 
 		// First sync teams
-		if err := s.SyncTeams(ctx, season); err != nil {
+		if err := s.SyncTeams(ctx, leagueID, season); err != nil {
+			if apifootball.IsQuotaExceeded(err) {
+				return fmt.Errorf("stopping season sync at %d: %w", season, err)
+			}
 			log.Printf("Failed to sync teams for season %d: %v", season, err)
 			continue
 		}
 
 		// Then sync fixtures
-		if err := s.SyncFixturesBySeason(ctx, season); err != nil {
+		if err := s.SyncFixturesBySeason(ctx, leagueID, season, SeasonSyncOptions{}); err != nil {
+			if apifootball.IsQuotaExceeded(err) {
+				return fmt.Errorf("stopping season sync at %d: %w", season, err)
+			}
 			log.Printf("Failed to sync fixtures for season %d: %v", season, err)
 			continue
 		}
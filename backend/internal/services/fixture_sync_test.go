@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/dEnchanter/OddsIQ/backend/internal/repository"
+	"github.com/dEnchanter/OddsIQ/backend/pkg/apifootball"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// fixtureJSON is an API-Football fixture with the given goals ("null" before kickoff)
+func fixtureJSON(status, homeGoals, awayGoals string) string {
+	return fmt.Sprintf(`{
+		"fixture": {"id": 1001, "date": "2026-01-10T15:00:00Z", "status": {"short": %q}},
+		"league": {"id": 39, "round": "Regular Season - 21"},
+		"teams": {"home": {"id": 42}, "away": {"id": 49}},
+		"goals": {"home": %s, "away": %s},
+		"score": {"halftime": {"home": null, "away": null}, "fulltime": {"home": null, "away": null}}
+	}`, status, homeGoals, awayGoals)
+}
+
+// newBuildFixtureService returns a sync service with both teams cached. Its leagues
+// repository points at a closed port, so the league lookup fails and is left unset.
+func newBuildFixtureService(t *testing.T) *FixtureSyncService {
+	t.Helper()
+
+	pool, err := pgxpool.New(context.Background(), "postgres://test@127.0.0.1:1/test?connect_timeout=1")
+	if err != nil {
+		t.Fatalf("failed to create pool: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	s := NewFixtureSyncService(nil, nil, nil, repository.NewLeaguesRepository(pool), nil, nil, nil)
+	s.cacheTeamID(42, 1)
+	s.cacheTeamID(49, 2)
+	return s
+}
+
+func TestBuildFixture_NullGoals_StoresNilScores(t *testing.T) {
+	var resp apifootball.FixtureResponse
+	if err := json.Unmarshal([]byte(fixtureJSON("NS", "null", "null")), &resp); err != nil {
+		t.Fatalf("failed to decode fixture: %v", err)
+	}
+
+	fixture, err := newBuildFixtureService(t).buildFixture(context.Background(), resp, 2025)
+	if err != nil {
+		t.Fatalf("buildFixture returned error: %v", err)
+	}
+
+	if fixture.HomeScore != nil || fixture.AwayScore != nil {
+		t.Errorf("Expected nil scores, got %v and %v", fixture.HomeScore, fixture.AwayScore)
+	}
+	if fixture.HomeTeamID != 1 || fixture.AwayTeamID != 2 {
+		t.Errorf("Expected teams 1 and 2, got %d and %d", fixture.HomeTeamID, fixture.AwayTeamID)
+	}
+}
+
+func TestBuildFixture_GoallessDraw_StoresZeroScores(t *testing.T) {
+	var resp apifootball.FixtureResponse
+	if err := json.Unmarshal([]byte(fixtureJSON("FT", "0", "0")), &resp); err != nil {
+		t.Fatalf("failed to decode fixture: %v", err)
+	}
+
+	fixture, err := newBuildFixtureService(t).buildFixture(context.Background(), resp, 2025)
+	if err != nil {
+		t.Fatalf("buildFixture returned error: %v", err)
+	}
+
+	if fixture.HomeScore == nil || fixture.AwayScore == nil || *fixture.HomeScore != 0 || *fixture.AwayScore != 0 {
+		t.Errorf("Expected a 0-0 score, got %v and %v", fixture.HomeScore, fixture.AwayScore)
+	}
+}
+
+func TestBuildFixture_FinishedMatch_StoresScores(t *testing.T) {
+	var resp apifootball.FixtureResponse
+	if err := json.Unmarshal([]byte(fixtureJSON("FT", "2", "1")), &resp); err != nil {
+		t.Fatalf("failed to decode fixture: %v", err)
+	}
+
+	fixture, err := newBuildFixtureService(t).buildFixture(context.Background(), resp, 2025)
+	if err != nil {
+		t.Fatalf("buildFixture returned error: %v", err)
+	}
+
+	if fixture.HomeScore == nil || fixture.AwayScore == nil || *fixture.HomeScore != 2 || *fixture.AwayScore != 1 {
+		t.Errorf("Expected a 2-1 score, got %v and %v", fixture.HomeScore, fixture.AwayScore)
+	}
+}
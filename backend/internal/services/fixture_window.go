@@ -0,0 +1,28 @@
+package services
+
+import "time"
+
+// DefaultFixtureWindowDays is how far ahead picks look when no window is given
+const DefaultFixtureWindowDays = 7
+
+// FixtureWindow is the kick-off time range of fixtures considered for picks
+type FixtureWindow struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// NextDays returns a window from now until the given number of days ahead
+func NextDays(days int) FixtureWindow {
+	now := time.Now()
+	return FixtureWindow{From: now, To: now.AddDate(0, 0, days)}
+}
+
+// In returns the window with both bounds shown in loc
+func (w FixtureWindow) In(loc *time.Location) FixtureWindow {
+	return FixtureWindow{From: w.From.In(loc), To: w.To.In(loc)}
+}
+
+// Location returns the timezone the window is expressed in
+func (w FixtureWindow) Location() *time.Location {
+	return w.From.Location()
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/dEnchanter/OddsIQ/backend/internal/markets"
+)
+
+// CalculateSimultaneousKelly returns full-Kelly stakes for betting on several mutually
+// exclusive outcomes of one event at once, maximizing expected log growth jointly.
+//
+// Outcomes are ranked by expected return p*o and added to the bet set while their
+// expected return exceeds the reserve rate R = (1 - sum p) / (1 - sum 1/o) of the
+// outcomes already chosen. Each chosen outcome is staked f = p - R/o of bankroll.
+// For example, with probabilities 0.5/0.3/0.2 at odds 2.5/3.5/3.0, home and draw
+// are backed: R = 0.2/(1-0.4-0.2857) = 0.6364, giving fractions 0.2455 and 0.1182
+// (away's 0.6 expected return is below R).
+func CalculateSimultaneousKelly(probs, odds []float64, bankroll float64) []float64 {
+	stakes := make([]float64, len(probs))
+	if len(probs) != len(odds) {
+		return stakes
+	}
+
+	order := make([]int, 0, len(probs))
+	for i := range probs {
+		if odds[i] > 1 && probs[i] > 0 {
+			order = append(order, i)
+		}
+	}
+	sort.Slice(order, func(a, b int) bool {
+		return probs[order[a]]*odds[order[a]] > probs[order[b]]*odds[order[b]]
+	})
+
+	reserve := 1.0
+	var chosen []int
+	var probSum, impliedSum float64
+	for _, i := range order {
+		if probs[i]*odds[i] <= reserve {
+			break
+		}
+
+		// Stop before the chosen prices imply 100% or more, where R is undefined
+		if impliedSum+1/odds[i] >= 1 {
+			break
+		}
+
+		chosen = append(chosen, i)
+		probSum += probs[i]
+		impliedSum += 1 / odds[i]
+		reserve = (1 - probSum) / (1 - impliedSum)
+	}
+
+	for _, i := range chosen {
+		if f := probs[i] - reserve/odds[i]; f > 0 {
+			stakes[i] = f * bankroll
+		}
+	}
+
+	return stakes
+}
+
+// exclusiveGroup returns a key shared by outcomes that are mutually exclusive (only one
+// can win), or false if the outcome can push. Totals and handicaps only qualify on
+// half-goal lines.
+func exclusiveGroup(outcome BetOutcome) (string, bool) {
+	switch outcome.Market {
+	case MarketType1X2, MarketTypeBTTS:
+		return string(outcome.Market), true
+
+	case MarketTypeOverUnder:
+		for _, prefix := range []string{"over_", "under_"} {
+			if lineKey, found := strings.CutPrefix(outcome.Outcome, prefix); found {
+				if line, ok := markets.ParseLineKey(lineKey); ok && isHalfLine(line) {
+					return string(outcome.Market) + "_" + markets.LineKey(line), true
+				}
+			}
+		}
+
+	case MarketTypeHandicap:
+		side, line, ok := markets.ParseHandicapOutcomeKey(outcome.Outcome)
+		if ok && isHalfLine(line) {
+			if side == "Away" {
+				line = -line // Group on the home line
+			}
+			return string(outcome.Market) + "_" + markets.HandicapOutcomeKey("home", line), true
+		}
+	}
+
+	return "", false
+}
+
+// isHalfLine reports whether a goals line ends in .5, so it can't be pushed
+func isHalfLine(line float64) bool {
+	return math.Mod(math.Abs(line), 1) == 0.5
+}
+
+// applySimultaneousKelly re-sizes KellyStake for groups of mutually exclusive outcomes
+// where more than one clears the EV threshold, since per-outcome Kelly over-stakes them
+func (s *BettingService) applySimultaneousKelly(outcomes []BetOutcome, bankroll float64) {
+	groups := make(map[string][]int)
+	for i, outcome := range outcomes {
+		if key, ok := exclusiveGroup(outcome); ok {
+			groups[key] = append(groups[key], i)
+		}
+	}
+
+	for _, members := range groups {
+		qualifying := 0
+		probs := make([]float64, len(members))
+		odds := make([]float64, len(members))
+		for j, i := range members {
+			probs[j] = outcomes[i].Probability
+			odds[j] = outcomes[i].BestOdds
+			if outcomes[i].EV >= s.config.MinEVThreshold {
+				qualifying++
+			}
+		}
+		if qualifying < 2 {
+			continue
+		}
+
+		// Full-Kelly fractions, then the usual fractional Kelly and cap per outcome
+		fractions := CalculateSimultaneousKelly(probs, odds, 1)
+		for j, i := range members {
+			outcomes[i].KellyStake = s.scaleKellyStake(fractions[j], bankroll, outcomes[i].Market)
+		}
+	}
+}
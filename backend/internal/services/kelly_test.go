@@ -0,0 +1,129 @@
+package services
+
+import (
+	"math"
+	"testing"
+)
+
+const kellyTolerance = 1e-4
+
+// expectedLogGrowth returns sum p*log(wealth) after staking fractions f on mutually
+// exclusive outcomes, where any probability mass not covered by probs loses every stake
+func expectedLogGrowth(probs, odds, f []float64) float64 {
+	var staked, probSum float64
+	for _, fi := range f {
+		staked += fi
+	}
+
+	growth := 0.0
+	for i, p := range probs {
+		growth += p * math.Log(1-staked+f[i]*odds[i])
+		probSum += p
+	}
+	if rest := 1 - probSum; rest > 0 {
+		growth += rest * math.Log(1-staked)
+	}
+	return growth
+}
+
+// The worked example follows the reserve-rate algorithm of Smoczynski & Tomkins (2010):
+// home and draw are backed, R = 0.2 / (1 - 1/2.5 - 1/3.5) = 0.6364 and f = p - R/o.
+func TestCalculateSimultaneousKelly_WorkedExample_ReturnsReserveRateStakes(t *testing.T) {
+	probs := []float64{0.5, 0.3, 0.2}
+	odds := []float64{2.5, 3.5, 3.0}
+
+	stakes := CalculateSimultaneousKelly(probs, odds, 1)
+
+	expectedReserve := 0.2 / (1 - 1/2.5 - 1/3.5)
+	if math.Abs(expectedReserve-0.6364) > kellyTolerance {
+		t.Fatalf("Expected R of 0.6364, got %v", expectedReserve)
+	}
+	expected := []float64{0.2455, 0.1182, 0}
+	for i := range expected {
+		if math.Abs(stakes[i]-expected[i]) > kellyTolerance {
+			t.Errorf("Outcome %d: expected fraction %v, got %v", i, expected[i], stakes[i])
+		}
+	}
+
+	// Every backed outcome returns exactly R per unit of bankroll beyond its stake
+	for i := 0; i < 2; i++ {
+		if reserve := (probs[i] - stakes[i]) * odds[i]; math.Abs(reserve-expectedReserve) > kellyTolerance {
+			t.Errorf("Outcome %d: expected R %v, got %v", i, expectedReserve, reserve)
+		}
+	}
+}
+
+func TestCalculateSimultaneousKelly_WorkedExample_MaximizesLogGrowth(t *testing.T) {
+	probs := []float64{0.5, 0.3, 0.2}
+	odds := []float64{2.5, 3.5, 3.0}
+
+	stakes := CalculateSimultaneousKelly(probs, odds, 1)
+	best := expectedLogGrowth(probs, odds, stakes)
+
+	for i := range stakes {
+		for _, delta := range []float64{-0.01, 0.01} {
+			perturbed := append([]float64(nil), stakes...)
+			perturbed[i] = math.Max(0, perturbed[i]+delta)
+			if growth := expectedLogGrowth(probs, odds, perturbed); growth > best {
+				t.Errorf("Changing outcome %d by %v raised growth from %v to %v", i, delta, best, growth)
+			}
+		}
+	}
+}
+
+func TestCalculateSimultaneousKelly_ScalesByBankroll(t *testing.T) {
+	probs := []float64{0.5, 0.3, 0.2}
+	odds := []float64{2.5, 3.5, 3.0}
+
+	fractions := CalculateSimultaneousKelly(probs, odds, 1)
+	stakes := CalculateSimultaneousKelly(probs, odds, 1000)
+
+	for i := range fractions {
+		if math.Abs(stakes[i]-fractions[i]*1000) > kellyTolerance {
+			t.Errorf("Outcome %d: expected %v, got %v", i, fractions[i]*1000, stakes[i])
+		}
+	}
+}
+
+// Prices implying 100% or more would make R undefined, so the outcome that
+// would push the implied sum to 1 is left out
+func TestCalculateSimultaneousKelly_ImpliedProbabilityReachesOne_StopsBeforeIt(t *testing.T) {
+	probs := []float64{0.6, 0.5}
+	odds := []float64{1.8, 2.1} // 1/1.8 + 1/2.1 = 1.03
+
+	stakes := CalculateSimultaneousKelly(probs, odds, 1)
+
+	// Home alone: R = 0.4 / (1 - 1/1.8) = 0.9, f = 0.6 - 0.9/1.8 = 0.1
+	if math.Abs(stakes[0]-0.1) > kellyTolerance {
+		t.Errorf("Expected home fraction 0.1, got %v", stakes[0])
+	}
+	if stakes[1] != 0 {
+		t.Errorf("Expected no stake on draw, got %v", stakes[1])
+	}
+	for i, stake := range stakes {
+		if math.IsNaN(stake) || math.IsInf(stake, 0) {
+			t.Errorf("Outcome %d: expected a finite stake, got %v", i, stake)
+		}
+	}
+}
+
+func TestCalculateSimultaneousKelly_NoPositiveEV_ReturnsZeroStakes(t *testing.T) {
+	probs := []float64{0.45, 0.28, 0.27}
+	odds := []float64{2.10, 3.40, 3.60} // expected returns 0.945, 0.952, 0.972
+
+	stakes := CalculateSimultaneousKelly(probs, odds, 1000)
+
+	for i, stake := range stakes {
+		if stake != 0 {
+			t.Errorf("Outcome %d: expected 0, got %v", i, stake)
+		}
+	}
+}
+
+func TestCalculateSimultaneousKelly_MismatchedLengths_ReturnsZeroStakes(t *testing.T) {
+	stakes := CalculateSimultaneousKelly([]float64{0.5, 0.5}, []float64{2.5}, 1000)
+
+	if len(stakes) != 2 || stakes[0] != 0 || stakes[1] != 0 {
+		t.Errorf("Expected [0 0], got %v", stakes)
+	}
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"github.com/dEnchanter/OddsIQ/backend/pkg/apifootball"
+	"github.com/dEnchanter/OddsIQ/backend/pkg/oddsapi"
+)
+
+// LeagueInfo maps an API-Football league to its The Odds API sport key
+type LeagueInfo struct {
+	APIFootballID   int    `json:"api_football_id"`
+	Name            string `json:"name"`
+	Country         string `json:"country"`
+	OddsAPISportKey string `json:"odds_api_sport_key"`
+}
+
+// SupportedLeagues lists the leagues that can be synced
+var SupportedLeagues = []LeagueInfo{
+	{APIFootballID: apifootball.PremierLeagueID, Name: "Premier League", Country: "England", OddsAPISportKey: oddsapi.SportEPL},
+	{APIFootballID: apifootball.LaLigaID, Name: "La Liga", Country: "Spain", OddsAPISportKey: oddsapi.SportLaLiga},
+	{APIFootballID: apifootball.BundesligaID, Name: "Bundesliga", Country: "Germany", OddsAPISportKey: oddsapi.SportBundesliga},
+	{APIFootballID: apifootball.SerieAID, Name: "Serie A", Country: "Italy", OddsAPISportKey: oddsapi.SportSerieA},
+	{APIFootballID: apifootball.Ligue1ID, Name: "Ligue 1", Country: "France", OddsAPISportKey: oddsapi.SportLigue1},
+}
+
+// GetLeagueInfo returns the supported league with the given API-Football ID
+func GetLeagueInfo(leagueID int) (LeagueInfo, bool) {
+	for _, league := range SupportedLeagues {
+		if league.APIFootballID == leagueID {
+			return league, true
+		}
+	}
+	return LeagueInfo{}, false
+}
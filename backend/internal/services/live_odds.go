@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/dEnchanter/OddsIQ/backend/internal/repository"
+	"github.com/dEnchanter/OddsIQ/backend/pkg/apifootball"
+)
+
+// LiveStatuses are the fixture statuses during which a match is in play
+var LiveStatuses = []string{"1H", "HT", "2H", "ET"}
+
+// LiveOddsSyncService stores in-play odds from API-Football
+type LiveOddsSyncService struct {
+	apiClient    *apifootball.Client
+	fixturesRepo *repository.FixturesRepository
+	oddsRepo     *repository.OddsRepository
+}
+
+// NewLiveOddsSyncService creates a new live odds sync service
+func NewLiveOddsSyncService(
+	apiClient *apifootball.Client,
+	fixturesRepo *repository.FixturesRepository,
+	oddsRepo *repository.OddsRepository,
+) *LiveOddsSyncService {
+	return &LiveOddsSyncService{
+		apiClient:    apiClient,
+		fixturesRepo: fixturesRepo,
+		oddsRepo:     oddsRepo,
+	}
+}
+
+// SyncAllLiveOdds syncs live odds for every in-play fixture. No API requests are made
+// when nothing is live.
+func (s *LiveOddsSyncService) SyncAllLiveOdds(ctx context.Context) error {
+	fixtures, err := s.fixturesRepo.GetByStatuses(ctx, LiveStatuses)
+	if err != nil {
+		return fmt.Errorf("failed to get live fixtures: %w", err)
+	}
+	if len(fixtures) == 0 {
+		return nil
+	}
+
+	log.Printf("Syncing live odds for %d in-play fixtures...", len(fixtures))
+
+	total := 0
+	for _, fixture := range fixtures {
+		inserted, err := s.SyncLiveOdds(ctx, fixture.ID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			log.Printf("Failed to sync live odds for fixture %d: %v", fixture.ID, err)
+			continue
+		}
+		total += inserted
+	}
+
+	log.Printf("Stored %d live odds rows", total)
+	return nil
+}
+
+// SyncLiveOdds fetches live odds for a fixture and stores those that moved since the
+// last quote, returning the number of rows inserted
+func (s *LiveOddsSyncService) SyncLiveOdds(ctx context.Context, fixtureID int) (int, error) {
+	fixture, err := s.fixturesRepo.GetByID(ctx, fixtureID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get fixture: %w", err)
+	}
+	if fixture.APIFootballID <= 0 {
+		return 0, fmt.Errorf("fixture %d is not an API-Football fixture", fixtureID)
+	}
+
+	responses, err := s.apiClient.GetLiveOdds(ctx, fixture.APIFootballID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch live odds: %w", err)
+	}
+
+	return storeAPIFootballOdds(ctx, s.oddsRepo, responses, fixture.ID, true)
+}
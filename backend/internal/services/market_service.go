@@ -0,0 +1,383 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dEnchanter/OddsIQ/backend/internal/markets"
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+	"github.com/dEnchanter/OddsIQ/backend/internal/repository"
+)
+
+// Price aggregation methods for implied probabilities
+const (
+	PriceMethodAverage = "average" // Mean price across bookmakers
+	PriceMethodBest    = "best"    // Highest price across bookmakers
+)
+
+// ImpliedOutcome holds the market's view of a single outcome
+type ImpliedOutcome struct {
+	Outcome     string  `json:"outcome"`
+	Odds        float64 `json:"odds"`
+	ImpliedProb float64 `json:"implied_prob"` // 1 / odds, includes the bookmaker margin
+	NoVigProb   float64 `json:"no_vig_prob"`  // Implied probability normalized to sum to 1
+	FairOdds    float64 `json:"fair_odds"`    // 1 / no-vig probability
+}
+
+// MarketImplied holds implied probabilities for one market and line
+type MarketImplied struct {
+	Market       string           `json:"market"`
+	Line         float64          `json:"line"`
+	Method       string           `json:"method"`
+	Outcomes     []ImpliedOutcome `json:"outcomes"`
+	Overround    float64          `json:"overround"`     // Sum of implied probabilities
+	OverroundPct float64          `json:"overround_pct"` // Bookmaker margin as a percentage
+	Complete     bool             `json:"complete"`      // All outcomes priced; no-vig values are only set when true
+}
+
+// MarketService provides market-derived analytics such as vig-free probabilities
+type MarketService struct {
+	oddsRepo *repository.OddsRepository
+}
+
+// NewMarketService creates a new market service
+func NewMarketService(oddsRepo *repository.OddsRepository) *MarketService {
+	return &MarketService{oddsRepo: oddsRepo}
+}
+
+// GetImpliedProbabilities returns implied and no-vig probabilities for a fixture's
+// market, one entry per line
+func (s *MarketService) GetImpliedProbabilities(ctx context.Context, fixtureID int, market, method string) ([]MarketImplied, error) {
+	odds, err := s.oddsRepo.GetLatestByFixtureAndMarket(ctx, fixtureID, market)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get odds: %w", err)
+	}
+
+	return BuildMarketImplied(odds, market, method), nil
+}
+
+// BestPrice is the highest latest price for an outcome across every odds source
+type BestPrice struct {
+	Market     string    `json:"market"`
+	Outcome    string    `json:"outcome"`
+	Line       float64   `json:"line"`
+	Odds       float64   `json:"odds"`
+	Source     string    `json:"source"`
+	Bookmaker  string    `json:"bookmaker"`
+	RecordedAt time.Time `json:"recorded_at"`
+	Quotes     int       `json:"quotes"` // Latest quotes compared, one per source and bookmaker
+}
+
+// GetBestAcrossSources returns the best latest price for a fixture's market, outcome and
+// line across all odds sources, and the source and bookmaker offering it. found is
+// false if no source quotes the outcome.
+func (s *MarketService) GetBestAcrossSources(ctx context.Context, fixtureID int, market, outcome string, line float64) (best *BestPrice, found bool, err error) {
+	odds, err := s.oddsRepo.GetLatestByFixtureAndMarket(ctx, fixtureID, market)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get odds: %w", err)
+	}
+
+	best = BestAcrossSources(odds, outcome, line)
+	return best, best != nil, nil
+}
+
+// BestAcrossSources picks the highest price for an outcome and line from the latest
+// odds of each source and bookmaker, or nil if none match. Outcomes match
+// case-insensitively; earlier quotes win ties.
+func BestAcrossSources(odds []models.Odds, outcome string, line float64) *BestPrice {
+	var best *BestPrice
+	quotes := 0
+
+	for _, o := range odds {
+		if !strings.EqualFold(o.Outcome, outcome) || o.Line != line {
+			continue
+		}
+		quotes++
+
+		if best == nil || o.OddsValue > best.Odds {
+			best = &BestPrice{
+				Market:     o.MarketType,
+				Outcome:    o.Outcome,
+				Line:       o.Line,
+				Odds:       o.OddsValue,
+				Source:     o.Source,
+				Bookmaker:  o.Bookmaker,
+				RecordedAt: o.Timestamp,
+			}
+		}
+	}
+
+	if best != nil {
+		best.Quotes = quotes
+	}
+	return best
+}
+
+// OddsGridCell is one bookmaker's latest price for an outcome
+type OddsGridCell struct {
+	Odds       float64   `json:"odds"`
+	RecordedAt time.Time `json:"recorded_at"`
+	Best       bool      `json:"best"` // Highest price in its column (ties are all marked)
+}
+
+// OddsGridRow is one bookmaker's prices, with a cell per grid column
+type OddsGridRow struct {
+	Bookmaker string          `json:"bookmaker"`
+	Source    string          `json:"source"`
+	Cells     []*OddsGridCell `json:"cells"` // Same order as the grid's columns; nil where the bookmaker has no price
+}
+
+// OddsGridColumn is an outcome of the grid and the best price for it
+type OddsGridColumn struct {
+	Outcome        string   `json:"outcome"`
+	BestOdds       float64  `json:"best_odds,omitempty"` // 0 if no bookmaker prices the outcome
+	BestBookmakers []string `json:"best_bookmakers"`     // Every bookmaker offering the best price
+	Quotes         int      `json:"quotes"`              // Bookmakers pricing the outcome
+}
+
+// OddsGrid compares the latest odds for one market and line across bookmakers:
+// rows are bookmakers and columns are the market's outcomes
+type OddsGrid struct {
+	Market   string           `json:"market"`
+	Line     float64          `json:"line"`
+	Columns  []OddsGridColumn `json:"columns"`
+	Rows     []OddsGridRow    `json:"rows"`
+	Complete bool             `json:"complete"` // Every bookmaker prices every outcome
+}
+
+// GetOddsGrid returns a bookmaker comparison grid of a fixture's latest odds for
+// market, one grid per line. A non-nil line restricts the result to that line.
+func (s *MarketService) GetOddsGrid(ctx context.Context, fixtureID int, market *markets.Market, line *float64) ([]OddsGrid, error) {
+	odds, err := s.oddsRepo.GetLatestByFixture(ctx, fixtureID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get odds: %w", err)
+	}
+
+	grids := BuildOddsGrid(odds, market)
+	if line == nil {
+		return grids, nil
+	}
+	for _, grid := range grids {
+		if grid.Line == *line {
+			return []OddsGrid{grid}, nil
+		}
+	}
+	return []OddsGrid{}, nil
+}
+
+// BuildOddsGrid pivots latest odds into one grid per line of market. Columns follow the
+// market's outcome order, so every grid has the same columns even where no bookmaker
+// prices an outcome. Bookmakers are keyed by source and bookmaker, since the same
+// bookmaker can be quoted by more than one provider; if a provider returns more than
+// one quote for a cell, the latest is kept. Outcomes outside the market and invalid
+// prices are ignored.
+func BuildOddsGrid(odds []models.Odds, market *markets.Market) []OddsGrid {
+	type rowKey struct {
+		source, bookmaker string
+	}
+
+	column := make(map[string]int, len(market.Outcomes))
+	for i, outcome := range market.Outcomes {
+		column[outcome] = i
+	}
+
+	rowsByLine := make(map[float64]map[rowKey]*OddsGridRow)
+	for _, o := range odds {
+		if o.OddsValue <= 1 {
+			continue
+		}
+		if m, ok := markets.Get(o.MarketType); !ok || m.Key != market.Key {
+			continue
+		}
+		outcome, ok := market.NormalizeOutcome(o.Outcome)
+		if !ok {
+			continue
+		}
+
+		if rowsByLine[o.Line] == nil {
+			rowsByLine[o.Line] = make(map[rowKey]*OddsGridRow)
+		}
+		key := rowKey{o.Source, o.Bookmaker}
+		row := rowsByLine[o.Line][key]
+		if row == nil {
+			row = &OddsGridRow{
+				Bookmaker: o.Bookmaker,
+				Source:    o.Source,
+				Cells:     make([]*OddsGridCell, len(market.Outcomes)),
+			}
+			rowsByLine[o.Line][key] = row
+		}
+
+		i := column[outcome]
+		if cell := row.Cells[i]; cell == nil || o.Timestamp.After(cell.RecordedAt) {
+			row.Cells[i] = &OddsGridCell{Odds: o.OddsValue, RecordedAt: o.Timestamp}
+		}
+	}
+
+	grids := make([]OddsGrid, 0, len(rowsByLine))
+	for line, byRow := range rowsByLine {
+		grid := OddsGrid{
+			Market:   market.Key,
+			Line:     line,
+			Columns:  make([]OddsGridColumn, len(market.Outcomes)),
+			Rows:     make([]OddsGridRow, 0, len(byRow)),
+			Complete: true,
+		}
+		for _, row := range byRow {
+			grid.Rows = append(grid.Rows, *row)
+		}
+		sort.Slice(grid.Rows, func(i, j int) bool {
+			if grid.Rows[i].Bookmaker != grid.Rows[j].Bookmaker {
+				return grid.Rows[i].Bookmaker < grid.Rows[j].Bookmaker
+			}
+			return grid.Rows[i].Source < grid.Rows[j].Source
+		})
+
+		for i, outcome := range market.Outcomes {
+			col := OddsGridColumn{Outcome: outcome, BestBookmakers: []string{}}
+			for _, row := range grid.Rows {
+				cell := row.Cells[i]
+				if cell == nil {
+					grid.Complete = false
+					continue
+				}
+				col.Quotes++
+				col.BestOdds = math.Max(col.BestOdds, cell.Odds)
+			}
+
+			// Mark every cell at the best price, listing each bookmaker once even if
+			// several providers quote it
+			for _, row := range grid.Rows {
+				if cell := row.Cells[i]; cell != nil && cell.Odds == col.BestOdds {
+					cell.Best = true
+					if !slices.Contains(col.BestBookmakers, row.Bookmaker) {
+						col.BestBookmakers = append(col.BestBookmakers, row.Bookmaker)
+					}
+				}
+			}
+			grid.Columns[i] = col
+		}
+
+		grids = append(grids, grid)
+	}
+
+	sort.Slice(grids, func(i, j int) bool {
+		return grids[i].Line < grids[j].Line
+	})
+
+	return grids
+}
+
+// BuildMarketImplied aggregates a market's odds per line using method and
+// computes implied probabilities
+func BuildMarketImplied(odds []models.Odds, market, method string) []MarketImplied {
+	type priceAgg struct {
+		sum   float64
+		best  float64
+		count int
+	}
+
+	byLine := make(map[float64]map[string]*priceAgg)
+	for _, o := range odds {
+		if o.MarketType != market || o.OddsValue <= 1 {
+			continue
+		}
+		if byLine[o.Line] == nil {
+			byLine[o.Line] = make(map[string]*priceAgg)
+		}
+		agg := byLine[o.Line][o.Outcome]
+		if agg == nil {
+			agg = &priceAgg{}
+			byLine[o.Line][o.Outcome] = agg
+		}
+		agg.sum += o.OddsValue
+		agg.count++
+		agg.best = math.Max(agg.best, o.OddsValue)
+	}
+
+	var result []MarketImplied
+	for line, outcomes := range byLine {
+		prices := make(map[string]float64, len(outcomes))
+		for outcome, agg := range outcomes {
+			if method == PriceMethodBest {
+				prices[outcome] = agg.best
+			} else {
+				prices[outcome] = agg.sum / float64(agg.count)
+			}
+		}
+
+		implied := CalculateImpliedProbabilities(prices, len(prices) == markets.OutcomeCount(market))
+		implied.Market = market
+		implied.Line = line
+		implied.Method = method
+		result = append(result, implied)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Line < result[j].Line
+	})
+
+	return result
+}
+
+// BookmakerType classifies a bookmaker key as sharp if it is in the sharp list, soft otherwise
+func BookmakerType(sharpBookmakers []string, bookmaker string) string {
+	key := strings.ToLower(bookmaker)
+	for _, sharp := range sharpBookmakers {
+		if key == sharp {
+			return models.BookmakerTypeSharp
+		}
+	}
+	return models.BookmakerTypeSoft
+}
+
+// SharpOdds returns only the odds quoted by sharp bookmakers
+func SharpOdds(odds []models.Odds, sharpBookmakers []string) []models.Odds {
+	var sharp []models.Odds
+	for _, o := range odds {
+		if BookmakerType(sharpBookmakers, o.Bookmaker) == models.BookmakerTypeSharp {
+			sharp = append(sharp, o)
+		}
+	}
+	return sharp
+}
+
+// CalculateImpliedProbabilities converts outcome prices to implied probabilities,
+// the overround and, when the outcome set is complete, vig-free probabilities
+// normalized so they sum to 1. Works for 2-way and 3-way markets alike.
+func CalculateImpliedProbabilities(prices map[string]float64, complete bool) MarketImplied {
+	overround := 0.0
+	for _, price := range prices {
+		overround += 1 / price
+	}
+
+	result := MarketImplied{Complete: complete}
+	for outcome, price := range prices {
+		implied := 1 / price
+		o := ImpliedOutcome{
+			Outcome:     outcome,
+			Odds:        math.Round(price*100) / 100,
+			ImpliedProb: math.Round(implied*10000) / 10000,
+		}
+		if complete && overround > 0 {
+			noVig := implied / overround
+			o.NoVigProb = math.Round(noVig*10000) / 10000
+			o.FairOdds = math.Round(1/noVig*100) / 100
+		}
+		result.Outcomes = append(result.Outcomes, o)
+	}
+
+	sort.Slice(result.Outcomes, func(i, j int) bool {
+		return result.Outcomes[i].Outcome < result.Outcomes[j].Outcome
+	})
+
+	result.Overround = math.Round(overround*10000) / 10000
+	result.OverroundPct = math.Round((overround-1)*10000) / 100
+
+	return result
+}
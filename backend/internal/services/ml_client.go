@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/dEnchanter/OddsIQ/backend/internal/logging"
 	"github.com/dEnchanter/OddsIQ/backend/internal/models"
 )
 
@@ -23,6 +24,8 @@ type PredictionRequest struct {
 	AwayTeamID int    `json:"away_team_id"`
 	MatchDate  string `json:"match_date"`
 	FixtureID  *int   `json:"fixture_id,omitempty"`
+	// HandicapLines requests Asian handicap probabilities for these home-team lines (multi-market only)
+	HandicapLines []float64 `json:"handicap_lines,omitempty"`
 }
 
 // BatchPredictionRequest represents a batch prediction request
@@ -97,6 +100,20 @@ type MultiMarketPredictionResponse struct {
 	PredictedAt  string                      `json:"predicted_at"`
 }
 
+// BatchPredictionError describes a fixture that failed within a batch
+type BatchPredictionError struct {
+	FixtureID *int   `json:"fixture_id"`
+	Error     string `json:"error"`
+}
+
+// BatchMultiMarketPredictionResponse represents the multi-market batch response
+type BatchMultiMarketPredictionResponse struct {
+	Predictions []MultiMarketPredictionResponse `json:"predictions"`
+	Errors      []BatchPredictionError          `json:"errors"`
+	Count       int                             `json:"count"`
+	PredictedAt string                          `json:"predicted_at"`
+}
+
 // AllMarketsMetricsResponse represents metrics for all markets
 type AllMarketsMetricsResponse struct {
 	Markets          map[string]ModelMetricsResponse `json:"markets"`
@@ -108,11 +125,27 @@ func NewMLClient(baseURL string) *MLClient {
 	return &MLClient{
 		baseURL: baseURL,
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   30 * time.Second,
+			Transport: requestIDTransport{base: http.DefaultTransport},
 		},
 	}
 }
 
+// requestIDTransport forwards the request ID of the API request being served to the ML
+// service as X-Request-ID, so its logs can be matched to ours
+type requestIDTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip adds the X-Request-ID header when the request's context carries an ID
+func (t requestIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if id := logging.RequestID(req.Context()); id != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("X-Request-ID", id)
+	}
+	return t.base.RoundTrip(req)
+}
+
 // HealthCheck checks if the ML service is healthy
 func (c *MLClient) HealthCheck(ctx context.Context) (*HealthResponse, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/health", nil)
@@ -303,11 +336,18 @@ func (c *MLClient) ReloadModel(ctx context.Context) error {
 
 // PredictMultiMarket gets predictions for all markets (1X2, O/U, BTTS)
 func (c *MLClient) PredictMultiMarket(ctx context.Context, fixture *models.Fixture) (*MultiMarketPredictionResponse, error) {
+	return c.PredictMultiMarketWithLines(ctx, fixture, nil)
+}
+
+// PredictMultiMarketWithLines gets predictions for all markets, also requesting
+// Asian handicap probabilities for the given home-team lines
+func (c *MLClient) PredictMultiMarketWithLines(ctx context.Context, fixture *models.Fixture, handicapLines []float64) (*MultiMarketPredictionResponse, error) {
 	reqBody := PredictionRequest{
-		HomeTeamID: fixture.HomeTeamID,
-		AwayTeamID: fixture.AwayTeamID,
-		MatchDate:  fixture.MatchDate.Format("2006-01-02"),
-		FixtureID:  &fixture.ID,
+		HomeTeamID:    fixture.HomeTeamID,
+		AwayTeamID:    fixture.AwayTeamID,
+		MatchDate:     fixture.MatchDate.Format("2006-01-02"),
+		FixtureID:     &fixture.ID,
+		HandicapLines: handicapLines,
 	}
 
 	body, err := json.Marshal(reqBody)
@@ -339,6 +379,60 @@ func (c *MLClient) PredictMultiMarket(ctx context.Context, fixture *models.Fixtu
 	return &multiResp, nil
 }
 
+// PredictMultiMarketBatch gets multi-market predictions for several fixtures in a
+// single request. handicapLines is keyed by fixture ID. The result is keyed by
+// fixture ID; fixtures the ML service failed on are absent from the map.
+func (c *MLClient) PredictMultiMarketBatch(ctx context.Context, fixtures []*models.Fixture, handicapLines map[int][]float64) (map[int]*MultiMarketPredictionResponse, error) {
+	requests := make([]PredictionRequest, len(fixtures))
+	for i, f := range fixtures {
+		requests[i] = PredictionRequest{
+			HomeTeamID:    f.HomeTeamID,
+			AwayTeamID:    f.AwayTeamID,
+			MatchDate:     f.MatchDate.Format("2006-01-02"),
+			FixtureID:     &f.ID,
+			HandicapLines: handicapLines[f.ID],
+		}
+	}
+
+	reqBody := BatchPredictionRequest{Fixtures: requests}
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/api/predict/multi/batch", bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call ML service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ML service error: status %d", resp.StatusCode)
+	}
+
+	var batchResp BatchMultiMarketPredictionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&batchResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	predictions := make(map[int]*MultiMarketPredictionResponse, len(batchResp.Predictions))
+	for i := range batchResp.Predictions {
+		pred := &batchResp.Predictions[i]
+		if pred.FixtureID == nil {
+			continue
+		}
+		predictions[*pred.FixtureID] = pred
+	}
+
+	return predictions, nil
+}
+
 // GetAllMarketsMetrics retrieves metrics for all market models
 func (c *MLClient) GetAllMarketsMetrics(ctx context.Context) (*AllMarketsMetricsResponse, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/api/model/metrics/all", nil)
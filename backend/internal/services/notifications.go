@@ -0,0 +1,203 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/dEnchanter/OddsIQ/backend/config"
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+	"github.com/dEnchanter/OddsIQ/backend/internal/repository"
+)
+
+// digestAccumulators is how many accumulators the weekly digest includes
+const digestAccumulators = 3
+
+// notificationSink delivers the weekly digest to one destination
+type notificationSink interface {
+	Name() string
+	Send(ctx context.Context, digest *WeeklyDigest) error
+}
+
+// NotificationService pushes the weekly value picks and accumulators to the configured
+// destinations
+type NotificationService struct {
+	bettingService     *BettingService
+	accumulatorService *AccumulatorService
+	teamsRepo          *repository.TeamsRepository
+	config             *config.Config
+	sinks              []notificationSink
+}
+
+// NewNotificationService creates a notification service sending to every destination
+// set in the configuration
+func NewNotificationService(
+	cfg *config.Config,
+	bettingService *BettingService,
+	accumulatorService *AccumulatorService,
+	teamsRepo *repository.TeamsRepository,
+) *NotificationService {
+	s := &NotificationService{
+		bettingService:     bettingService,
+		accumulatorService: accumulatorService,
+		teamsRepo:          teamsRepo,
+		config:             cfg,
+	}
+
+	if cfg.NotifyWebhookURL != "" {
+		s.sinks = append(s.sinks, newWebhookSink(cfg.NotifyWebhookURL, cfg.NotifyWebhookType))
+	}
+	if cfg.SMTP.Host != "" {
+		smtpCfg := cfg.SMTP
+		s.sinks = append(s.sinks, newEmailSink(smtpCfg.Host, smtpCfg.Port, smtpCfg.Username, smtpCfg.Password, smtpCfg.From, smtpCfg.To))
+	}
+
+	return s
+}
+
+// Enabled reports whether any notification destination is configured
+func (s *NotificationService) Enabled() bool {
+	return len(s.sinks) > 0
+}
+
+// DigestSelection is a pick or accumulator leg as shown in the weekly digest
+type DigestSelection struct {
+	Match     string // "Arsenal vs Chelsea"
+	Kickoff   time.Time
+	Selection string // Outcome description, e.g. "Over 2.5 Goals"
+	Odds      float64
+	Bookmaker string
+	EV        float64
+	Stake     float64 // Suggested stake (0 for accumulator legs)
+}
+
+// DigestAccumulator is an accumulator as shown in the weekly digest
+type DigestAccumulator struct {
+	Legs         []DigestSelection
+	CombinedOdds float64
+	EV           float64
+	Stake        float64
+}
+
+// WeeklyDigest is the week's top value picks and accumulators
+type WeeklyDigest struct {
+	Window       FixtureWindow
+	Currency     string
+	Picks        []DigestSelection
+	Accumulators []DigestAccumulator
+	GeneratedAt  time.Time
+}
+
+// SendWeeklyDigest builds the digest for the coming week and sends it to every
+// destination. A failing destination doesn't stop the others; their errors are joined.
+func (s *NotificationService) SendWeeklyDigest(ctx context.Context) error {
+	if !s.Enabled() {
+		return nil
+	}
+
+	digest, err := s.BuildWeeklyDigest(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, sink := range s.sinks {
+		if err := sink.Send(ctx, digest); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sink.Name(), err))
+			continue
+		}
+		log.Printf("Sent weekly digest to %s (%d picks, %d accumulators)", sink.Name(), len(digest.Picks), len(digest.Accumulators))
+	}
+
+	return errors.Join(errs...)
+}
+
+// BuildWeeklyDigest collects the top value picks and accumulators for the next
+// DefaultFixtureWindowDays, sized against the initial bankroll
+func (s *NotificationService) BuildWeeklyDigest(ctx context.Context) (*WeeklyDigest, error) {
+	bankroll := s.config.InitialBankroll
+	window := NextDays(DefaultFixtureWindowDays)
+
+	picks, err := s.bettingService.GetTopPicks(ctx, bankroll, s.config.NotifyTopPicks, window, s.bettingService.OddsRange())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get picks: %w", err)
+	}
+
+	accumulators, err := s.accumulatorService.GetWeeklyAccumulators(ctx, bankroll, window, s.accumulatorService.Config(), AccumulatorModeValue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accumulators: %w", err)
+	}
+
+	// Resolve team names for every fixture in the digest
+	var teamIDs []int
+	for _, pick := range picks.Picks {
+		teamIDs = append(teamIDs, pick.Fixture.HomeTeamID, pick.Fixture.AwayTeamID)
+	}
+	for _, acc := range accumulators.Accumulators {
+		for _, leg := range acc.Legs {
+			teamIDs = append(teamIDs, leg.Fixture.HomeTeamID, leg.Fixture.AwayTeamID)
+		}
+	}
+	teams, err := s.teamsRepo.GetByIDs(ctx, teamIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teams: %w", err)
+	}
+
+	digest := &WeeklyDigest{
+		Window:      window,
+		Currency:    s.config.StakeCurrency,
+		GeneratedAt: time.Now(),
+	}
+
+	for _, pick := range picks.Picks {
+		if pick.BestOutcome == nil {
+			continue
+		}
+		digest.Picks = append(digest.Picks, DigestSelection{
+			Match:     matchName(pick.Fixture, teams),
+			Kickoff:   pick.Fixture.MatchDate,
+			Selection: pick.BestOutcome.Description,
+			Odds:      pick.BestOutcome.BestOdds,
+			Bookmaker: pick.BestOutcome.Bookmaker,
+			EV:        pick.BestOutcome.EV,
+			Stake:     pick.SuggestedStake,
+		})
+	}
+
+	for i, acc := range accumulators.Accumulators {
+		if i == digestAccumulators {
+			break
+		}
+		digestAcc := DigestAccumulator{
+			CombinedOdds: acc.CombinedOdds,
+			EV:           acc.ExpectedValue,
+			Stake:        acc.SuggestedStake,
+		}
+		for _, leg := range acc.Legs {
+			digestAcc.Legs = append(digestAcc.Legs, DigestSelection{
+				Match:     matchName(leg.Fixture, teams),
+				Kickoff:   leg.Fixture.MatchDate,
+				Selection: leg.Description,
+				Odds:      leg.Odds,
+				Bookmaker: leg.Bookmaker,
+				EV:        leg.SingleEV,
+			})
+		}
+		digest.Accumulators = append(digest.Accumulators, digestAcc)
+	}
+
+	return digest, nil
+}
+
+// matchName returns "Home vs Away", falling back to team IDs for unknown teams
+func matchName(fixture models.Fixture, teams map[int]models.Team) string {
+	name := func(id int) string {
+		if team, ok := teams[id]; ok {
+			return team.Name
+		}
+		return fmt.Sprintf("Team %d", id)
+	}
+	return name(fixture.HomeTeamID) + " vs " + name(fixture.AwayTeamID)
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+)
+
+// OddsPoint is a single price observation
+type OddsPoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	OddsValue float64   `json:"odds_value"`
+}
+
+// OddsHistorySeries is the price movement for one bookmaker and line
+type OddsHistorySeries struct {
+	Bookmaker   string      `json:"bookmaker"`
+	Line        float64     `json:"line"`
+	Points      []OddsPoint `json:"points"`
+	Opening     float64     `json:"opening"`
+	Closing     float64     `json:"closing"` // Latest stored price
+	Movement    float64     `json:"movement"`
+	MovementPct float64     `json:"movement_pct"`
+}
+
+// BuildOddsHistory groups odds (ordered oldest first) into per-bookmaker, per-line
+// series with opening/closing prices and net movement
+func BuildOddsHistory(odds []models.Odds) []OddsHistorySeries {
+	type seriesKey struct {
+		bookmaker string
+		line      float64
+	}
+
+	index := make(map[seriesKey]int)
+	var series []OddsHistorySeries
+
+	for _, o := range odds {
+		key := seriesKey{o.Bookmaker, o.Line}
+		i, ok := index[key]
+		if !ok {
+			i = len(series)
+			index[key] = i
+			series = append(series, OddsHistorySeries{Bookmaker: o.Bookmaker, Line: o.Line})
+		}
+		series[i].Points = append(series[i].Points, OddsPoint{Timestamp: o.Timestamp, OddsValue: o.OddsValue})
+	}
+
+	for i := range series {
+		s := &series[i]
+		s.Opening = s.Points[0].OddsValue
+		s.Closing = s.Points[len(s.Points)-1].OddsValue
+		s.Movement = math.Round((s.Closing-s.Opening)*100) / 100
+		if s.Opening > 0 {
+			s.MovementPct = math.Round((s.Closing-s.Opening)/s.Opening*10000) / 100
+		}
+	}
+
+	sort.Slice(series, func(i, j int) bool {
+		if series[i].Bookmaker != series[j].Bookmaker {
+			return series[i].Bookmaker < series[j].Bookmaker
+		}
+		return series[i].Line < series[j].Line
+	})
+
+	return series
+}
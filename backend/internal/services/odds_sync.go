@@ -4,9 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"strings"
 	"time"
 
+	"github.com/dEnchanter/OddsIQ/backend/internal/markets"
 	"github.com/dEnchanter/OddsIQ/backend/internal/models"
 	"github.com/dEnchanter/OddsIQ/backend/internal/repository"
 	"github.com/dEnchanter/OddsIQ/backend/pkg/oddsapi"
@@ -14,10 +14,17 @@ import (
 
 // OddsSyncService handles syncing odds from The Odds API
 type OddsSyncService struct {
-	apiClient    *oddsapi.Client
-	fixturesRepo *repository.FixturesRepository
-	oddsRepo     *repository.OddsRepository
-	teamsRepo    *repository.TeamsRepository
+	apiClient      *oddsapi.Client
+	fixturesRepo   *repository.FixturesRepository
+	oddsRepo       *repository.OddsRepository
+	teamsRepo      *repository.TeamsRepository
+	aliasRepo      *repository.TeamAliasRepository
+	matchThreshold float64       // Minimum TeamNameSimilarity to auto-match team names
+	lookahead      time.Duration // Only events commencing within this of now are synced (0 = all)
+	leagueIDs      []int         // API-Football league IDs to sync odds for
+
+	// statusRepo records sync outcomes in sync_status (optional)
+	statusRepo *repository.SyncStatusRepository
 }
 
 // NewOddsSyncService creates a new odds sync service
@@ -26,68 +33,94 @@ func NewOddsSyncService(
 	fixturesRepo *repository.FixturesRepository,
 	oddsRepo *repository.OddsRepository,
 	teamsRepo *repository.TeamsRepository,
+	aliasRepo *repository.TeamAliasRepository,
+	statusRepo *repository.SyncStatusRepository,
+	matchThreshold float64,
+	lookahead time.Duration,
+	leagueIDs []int,
 ) *OddsSyncService {
+	if matchThreshold <= 0 {
+		matchThreshold = DefaultTeamMatchThreshold
+	}
+
 	return &OddsSyncService{
-		apiClient:    apiClient,
-		fixturesRepo: fixturesRepo,
-		oddsRepo:     oddsRepo,
-		teamsRepo:    teamsRepo,
+		apiClient:      apiClient,
+		fixturesRepo:   fixturesRepo,
+		oddsRepo:       oddsRepo,
+		teamsRepo:      teamsRepo,
+		aliasRepo:      aliasRepo,
+		statusRepo:     statusRepo,
+		matchThreshold: matchThreshold,
+		lookahead:      lookahead,
+		leagueIDs:      leagueIDs,
 	}
 }
 
-// SyncAllMarkets syncs odds for all supported markets (1X2, Over/Under, BTTS)
+// SyncAllMarkets syncs odds for all supported markets (1X2, Over/Under, BTTS) and
+// records the outcome in sync_status
 func (s *OddsSyncService) SyncAllMarkets(ctx context.Context) error {
 	log.Println("Syncing odds for all markets...")
 
-	// Fetch events with all markets
-	events, err := s.apiClient.GetAllMarketsEPL()
-	if err != nil {
-		return fmt.Errorf("failed to fetch odds: %w", err)
-	}
+	synced, err := s.syncMarkets(ctx, markets.OddsAPIMarkets())
+	recordSyncRun(ctx, s.statusRepo, models.SyncTypeOdds, synced, err)
+	return err
+}
+
+// SyncMarket syncs odds for a specific market type, recording the outcome in
+// sync_status as odds_<market>
+func (s *OddsSyncService) SyncMarket(ctx context.Context, marketType string) error {
+	log.Printf("Syncing odds for market: %s...", marketType)
+
+	synced, err := s.syncMarkets(ctx, []string{marketType})
+	recordSyncRun(ctx, s.statusRepo, models.SyncTypeOdds+"_"+marketType, synced, err)
+	return err
+}
 
-	log.Printf("Fetched odds for %d events", len(events))
+// syncMarkets fetches and stores odds for the given markets in every configured league
+// and returns the number of events synced
+func (s *OddsSyncService) syncMarkets(ctx context.Context, markets []string) (int, error) {
+	total := 0
+	window := s.syncWindow(time.Now())
 
-	// Process each event
-	successCount := 0
-	for _, event := range events {
-		if err := s.processEvent(ctx, event); err != nil {
-			log.Printf("Failed to process event %s: %v", event.ID, err)
+	for _, leagueID := range s.leagueIDs {
+		league, ok := GetLeagueInfo(leagueID)
+		if !ok {
+			log.Printf("No odds sport key for league %d, skipping", leagueID)
 			continue
 		}
-		successCount++
-	}
 
-	log.Printf("Successfully synced odds for %d/%d events", successCount, len(events))
-	return nil
-}
+		// Fetch events
+		events, err := s.apiClient.GetLeagueOdds(ctx, league.OddsAPISportKey, markets, window)
+		if err != nil {
+			return total, fmt.Errorf("failed to fetch odds for %s: %w", league.Name, err)
+		}
 
-// SyncMarket syncs odds for a specific market type
-func (s *OddsSyncService) SyncMarket(ctx context.Context, marketType string) error {
-	log.Printf("Syncing odds for market: %s...", marketType)
+		log.Printf("Fetched odds for %d %s events", len(events), league.Name)
 
-	// Map market types
-	markets := []string{marketType}
+		// Process each event
+		successCount := 0
+		for _, event := range events {
+			if err := s.processEvent(ctx, event); err != nil {
+				log.Printf("Failed to process event %s: %v", event.ID, err)
+				continue
+			}
+			successCount++
+		}
 
-	// Fetch events
-	events, err := s.apiClient.GetEPLOdds(markets)
-	if err != nil {
-		return fmt.Errorf("failed to fetch odds: %w", err)
+		log.Printf("Successfully synced odds for %d/%d %s events", successCount, len(events), league.Name)
+		total += successCount
 	}
 
-	log.Printf("Fetched odds for %d events", len(events))
+	return total, nil
+}
 
-	// Process each event
-	successCount := 0
-	for _, event := range events {
-		if err := s.processEvent(ctx, event); err != nil {
-			log.Printf("Failed to process event %s: %v", event.ID, err)
-			continue
-		}
-		successCount++
+// syncWindow returns the commence time window to fetch odds for: from now until the
+// lookahead, or unbounded if no lookahead is set. Events already in play are skipped.
+func (s *OddsSyncService) syncWindow(now time.Time) oddsapi.TimeWindow {
+	if s.lookahead <= 0 {
+		return oddsapi.TimeWindow{}
 	}
-
-	log.Printf("Successfully synced odds for %d/%d events", successCount, len(events))
-	return nil
+	return oddsapi.TimeWindow{From: now, To: now.Add(s.lookahead)}
 }
 
 // SyncH2HOdds syncs 1X2 (Home/Draw/Away) odds
@@ -105,6 +138,11 @@ func (s *OddsSyncService) SyncBTTSOdds(ctx context.Context) error {
 	return s.SyncMarket(ctx, oddsapi.MarketBTTS)
 }
 
+// SyncSpreadsOdds syncs Asian handicap (spreads) odds
+func (s *OddsSyncService) SyncSpreadsOdds(ctx context.Context) error {
+	return s.SyncMarket(ctx, oddsapi.MarketSpread)
+}
+
 // processEvent processes a single event and stores odds in database
 func (s *OddsSyncService) processEvent(ctx context.Context, event oddsapi.Event) error {
 	// Find matching fixture in database
@@ -124,10 +162,11 @@ func (s *OddsSyncService) processEvent(ctx context.Context, event oddsapi.Event)
 
 	// Batch insert odds
 	if len(oddsList) > 0 {
-		if err := s.oddsRepo.CreateBatch(ctx, oddsList); err != nil {
+		inserted, skipped, err := s.oddsRepo.BulkInsert(ctx, oddsList)
+		if err != nil {
 			return fmt.Errorf("failed to store odds: %w", err)
 		}
-		log.Printf("Stored %d odds entries for fixture %d", len(oddsList), fixture.ID)
+		log.Printf("Stored %d odds entries for fixture %d (%d unchanged skipped)", inserted, fixture.ID, skipped)
 	}
 
 	return nil
@@ -144,73 +183,109 @@ func (s *OddsSyncService) findMatchingFixture(ctx context.Context, event oddsapi
 		return nil, err
 	}
 
-	// Try to match by team names
-	for _, fixture := range fixtures {
-		// Get team names
-		homeTeam, err := s.teamsRepo.GetByID(ctx, fixture.HomeTeamID)
-		if err != nil {
-			continue
-		}
+	// Resolve team names via aliases and exact DB names first
+	homeID, homeFound := s.resolveTeamID(ctx, event.HomeTeam)
+	awayID, awayFound := s.resolveTeamID(ctx, event.AwayTeam)
 
-		awayTeam, err := s.teamsRepo.GetByID(ctx, fixture.AwayTeamID)
-		if err != nil {
+	// Best fuzzy candidates for unresolved sides, logged if nothing matches
+	var homeBest, awayBest teamCandidate
+
+	for _, fixture := range fixtures {
+		if homeFound && awayFound {
+			if fixture.HomeTeamID == homeID && fixture.AwayTeamID == awayID {
+				return &fixture, nil
+			}
 			continue
 		}
 
-		// Match by team names (case-insensitive, partial match)
-		if s.matchTeamNames(homeTeam.Name, event.HomeTeam) && s.matchTeamNames(awayTeam.Name, event.AwayTeam) {
+		// Fall back to fuzzy name matching for the unresolved side(s)
+		homeMatch := s.matchSide(ctx, fixture.HomeTeamID, event.HomeTeam, homeID, homeFound, &homeBest)
+		awayMatch := s.matchSide(ctx, fixture.AwayTeamID, event.AwayTeam, awayID, awayFound, &awayBest)
+		if homeMatch && awayMatch {
 			return &fixture, nil
 		}
 	}
 
+	// Log names we couldn't resolve so aliases can be added for them
+	if !homeFound {
+		s.recordUnmatched(ctx, event.HomeTeam, homeBest)
+	}
+	if !awayFound {
+		s.recordUnmatched(ctx, event.AwayTeam, awayBest)
+	}
+
 	return nil, nil
 }
 
-// matchTeamNames checks if two team names match (handles variations)
-func (s *OddsSyncService) matchTeamNames(dbName, apiName string) bool {
-	// Normalize names (lowercase, remove spaces)
-	normalize := func(name string) string {
-		return strings.ToLower(strings.ReplaceAll(name, " ", ""))
+// teamCandidate is the closest team found for an unresolved name
+type teamCandidate struct {
+	TeamID int
+	Score  float64
+}
+
+// matchSide reports whether a fixture's team matches an event team, either by
+// resolved ID or by fuzzy name similarity above the threshold. best tracks the
+// highest-scoring fuzzy candidate.
+func (s *OddsSyncService) matchSide(ctx context.Context, teamID int, apiName string, resolvedID int, resolved bool, best *teamCandidate) bool {
+	if resolved {
+		return teamID == resolvedID
 	}
 
-	dbNorm := normalize(dbName)
-	apiNorm := normalize(apiName)
+	team, err := s.teamsRepo.GetByID(ctx, teamID)
+	if err != nil {
+		return false
+	}
 
-	// Exact match
-	if dbNorm == apiNorm {
-		return true
+	score := TeamNameSimilarity(team.Name, apiName)
+	if score > best.Score {
+		*best = teamCandidate{TeamID: teamID, Score: score}
 	}
 
-	// Partial match (one contains the other)
-	if strings.Contains(dbNorm, apiNorm) || strings.Contains(apiNorm, dbNorm) {
-		return true
+	return score >= s.matchThreshold
+}
+
+// resolveTeamID looks up a team by alias, then by exact name
+func (s *OddsSyncService) resolveTeamID(ctx context.Context, name string) (int, bool) {
+	if s.aliasRepo != nil {
+		id, found, err := s.aliasRepo.GetTeamIDByAlias(ctx, name)
+		if err != nil {
+			log.Printf("Warning: %v", err)
+		} else if found {
+			return id, true
+		}
+	}
+
+	id, found, err := s.teamsRepo.GetIDByName(ctx, name)
+	if err != nil {
+		log.Printf("Warning: %v", err)
+		return 0, false
 	}
 
-	// Handle common abbreviations
-	abbreviations := map[string]string{
-		"manchester":  "man",
-		"united":      "utd",
-		"city":        "",
-		"tottenham":   "spurs",
-		"newcastle":   "newcastle",
-		"brighton":    "brighton",
-		"westham":     "westham",
-		"wolverhampton": "wolves",
-		"nottingham":  "nottingham",
+	return id, found
+}
+
+// recordUnmatched logs an unresolved team name with its best fuzzy candidate
+func (s *OddsSyncService) recordUnmatched(ctx context.Context, name string, best teamCandidate) {
+	if s.aliasRepo == nil {
+		return
 	}
 
-	for full, abbr := range abbreviations {
-		dbNorm = strings.ReplaceAll(dbNorm, full, abbr)
-		apiNorm = strings.ReplaceAll(apiNorm, full, abbr)
+	var candidateID *int
+	var candidateScore *float64
+	if best.TeamID != 0 {
+		candidateID = &best.TeamID
+		candidateScore = &best.Score
 	}
 
-	return dbNorm == apiNorm
+	if err := s.aliasRepo.RecordUnmatched(ctx, name, "odds_api", candidateID, candidateScore); err != nil {
+		log.Printf("Warning: %v", err)
+	}
 }
 
 // extractOddsFromEvent extracts all odds from an event
 func (s *OddsSyncService) extractOddsFromEvent(fixtureID int, event oddsapi.Event) []models.Odds {
 	var oddsList []models.Odds
-	timestamp := time.Now()
+	timestamp := time.Now().UTC()
 
 	for _, bookmaker := range event.Bookmakers {
 		for _, market := range bookmaker.Markets {
@@ -218,9 +293,11 @@ func (s *OddsSyncService) extractOddsFromEvent(fixtureID int, event oddsapi.Even
 				odds := models.Odds{
 					FixtureID:  fixtureID,
 					Bookmaker:  bookmaker.Key,
+					Source:     models.OddsSourceOddsAPI,
 					MarketType: market.Key,
-					Outcome:    s.normalizeOutcome(outcome.Name, market.Key),
+					Outcome:    s.normalizeOutcome(outcome.Name, market.Key, event),
 					OddsValue:  outcome.Price,
+					Line:       outcome.Point,
 					Timestamp:  timestamp,
 				}
 				oddsList = append(oddsList, odds)
@@ -231,39 +308,60 @@ func (s *OddsSyncService) extractOddsFromEvent(fixtureID int, event oddsapi.Even
 	return oddsList
 }
 
-// normalizeOutcome normalizes outcome names for consistency
-func (s *OddsSyncService) normalizeOutcome(name, marketType string) string {
-	// For h2h market, normalize to Home/Draw/Away
-	if marketType == oddsapi.MarketH2H {
-		// Names from API are team names or "Draw"
-		if strings.ToLower(name) == "draw" {
-			return "Draw"
-		}
-		// We'll keep team names as-is and normalize later when needed
+// normalizeOutcome maps a provider outcome name to the market's canonical outcome
+// (e.g. "over" -> "Over"). h2h and handicap outcomes are team names, mapped to Home/Away.
+func (s *OddsSyncService) normalizeOutcome(name, marketType string, event oddsapi.Event) string {
+	market, ok := markets.Get(marketType)
+	if !ok {
 		return name
 	}
 
-	// For totals market, normalize to Over/Under
-	if marketType == oddsapi.MarketTotals {
-		return name // Already "Over" or "Under"
+	// h2h and handicap outcomes name the team; map them to Home/Away so stored odds
+	// line up with model outcomes (handicap lines are stored separately)
+	if market.ModelKey == markets.Model1X2 || market.ModelKey == markets.ModelHandicap {
+		switch name {
+		case event.HomeTeam:
+			return "Home"
+		case event.AwayTeam:
+			return "Away"
+		}
 	}
 
-	// For BTTS market, normalize to Yes/No
-	if marketType == oddsapi.MarketBTTS {
-		if strings.ToLower(name) == "yes" {
-			return "Yes"
+	if outcome, ok := market.NormalizeOutcome(name); ok {
+		return outcome
+	}
+	return name
+}
+
+// MarkClosingLines flags closing-line odds for fixtures that kicked off within the lookback window
+func (s *OddsSyncService) MarkClosingLines(ctx context.Context, lookback time.Duration) error {
+	log.Println("Marking closing lines for recently started fixtures...")
+
+	now := time.Now()
+	fixtures, err := s.fixturesRepo.GetByDateRange(ctx, now.Add(-lookback), now)
+	if err != nil {
+		return fmt.Errorf("failed to get recent fixtures: %w", err)
+	}
+
+	for _, fixture := range fixtures {
+		updated, err := s.oddsRepo.MarkClosingLines(ctx, fixture.ID)
+		if err != nil {
+			log.Printf("Failed to mark closing lines for fixture %d: %v", fixture.ID, err)
+			continue
+		}
+		if updated > 0 {
+			log.Printf("Updated %d closing line flags for fixture %d", updated, fixture.ID)
 		}
-		return "No"
 	}
 
-	return name
+	return nil
 }
 
 // CleanupOldOdds removes odds older than specified days
 func (s *OddsSyncService) CleanupOldOdds(ctx context.Context, daysToKeep int) error {
 	log.Printf("Cleaning up odds older than %d days...", daysToKeep)
 
-	cutoffDate := time.Now().AddDate(0, 0, -daysToKeep)
+	cutoffDate := time.Now().UTC().AddDate(0, 0, -daysToKeep)
 	deleted, err := s.oddsRepo.DeleteOldOdds(ctx, cutoffDate)
 	if err != nil {
 		return fmt.Errorf("failed to cleanup old odds: %w", err)
@@ -273,7 +371,7 @@ func (s *OddsSyncService) CleanupOldOdds(ctx context.Context, daysToKeep int) er
 	return nil
 }
 
-// GetOddsSummary returns a summary of stored odds
+// GetOddsSummary returns a summary of stored odds and the remaining Odds API credits
 func (s *OddsSyncService) GetOddsSummary(ctx context.Context) (map[string]interface{}, error) {
 	marketTypes, err := s.oddsRepo.GetMarketTypes(ctx)
 	if err != nil {
@@ -286,10 +384,11 @@ func (s *OddsSyncService) GetOddsSummary(ctx context.Context) (map[string]interf
 	}
 
 	summary := map[string]interface{}{
-		"market_types": marketTypes,
-		"bookmakers":   bookmakers,
-		"total_markets": len(marketTypes),
+		"market_types":     marketTypes,
+		"bookmakers":       bookmakers,
+		"total_markets":    len(marketTypes),
 		"total_bookmakers": len(bookmakers),
+		"api_credits":      s.apiClient.Credits(),
 	}
 
 	return summary, nil
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dEnchanter/OddsIQ/backend/pkg/oddsapi"
+)
+
+// syncedEventJSON is an event as returned by The Odds API, with h2h outcomes named
+// after the teams and totals outcomes in the provider's casing
+const syncedEventJSON = `{
+	"id": "e1",
+	"commence_time": "2026-01-10T15:00:00Z",
+	"home_team": "Arsenal",
+	"away_team": "Chelsea",
+	"bookmakers": [{
+		"key": "bet365",
+		"markets": [
+			{"key": "h2h", "outcomes": [
+				{"name": "Arsenal", "price": 2.10},
+				{"name": "Draw", "price": 3.40},
+				{"name": "Chelsea", "price": 3.60}
+			]},
+			{"key": "spreads", "outcomes": [
+				{"name": "Arsenal", "price": 1.95, "point": -0.5},
+				{"name": "Chelsea", "price": 1.95, "point": 0.5}
+			]},
+			{"key": "totals", "outcomes": [
+				{"name": "over", "price": 1.90, "point": 2.5},
+				{"name": "under", "price": 1.90, "point": 2.5}
+			]}
+		]
+	}]
+}`
+
+func TestNormalizeOutcome_SyncedEvent_StoresCanonicalOutcomes(t *testing.T) {
+	var event oddsapi.Event
+	if err := json.Unmarshal([]byte(syncedEventJSON), &event); err != nil {
+		t.Fatalf("failed to decode event: %v", err)
+	}
+	s := &OddsSyncService{}
+
+	odds := s.extractOddsFromEvent(42, event)
+
+	expected := map[string][]string{
+		"h2h":     {"Home", "Draw", "Away"},
+		"spreads": {"Home", "Away"},
+		"totals":  {"Over", "Under"},
+	}
+	got := make(map[string][]string)
+	for _, o := range odds {
+		got[o.MarketType] = append(got[o.MarketType], o.Outcome)
+	}
+	for market, outcomes := range expected {
+		if len(got[market]) != len(outcomes) {
+			t.Errorf("%s: expected %v, got %v", market, outcomes, got[market])
+			continue
+		}
+		for i := range outcomes {
+			if got[market][i] != outcomes[i] {
+				t.Errorf("%s: expected %v, got %v", market, outcomes, got[market])
+				break
+			}
+		}
+	}
+}
+
+func TestNormalizeOutcome_SyncedH2H_LinesUpWithModelOutcomes(t *testing.T) {
+	var event oddsapi.Event
+	if err := json.Unmarshal([]byte(syncedEventJSON), &event); err != nil {
+		t.Fatalf("failed to decode event: %v", err)
+	}
+	odds := (&OddsSyncService{}).extractOddsFromEvent(42, event)
+
+	oddsMap := (&BettingService{}).buildOddsMap(odds, nil)
+
+	for key, price := range map[string]float64{"1x2_home_win": 2.10, "1x2_draw": 3.40, "1x2_away_win": 3.60} {
+		if got := oddsMap[key]; got.Odds != price {
+			t.Errorf("%s: expected %v, got %+v", key, price, got)
+		}
+	}
+}
+
+func TestNormalizeOutcome_UnknownMarket_KeepsName(t *testing.T) {
+	event := oddsapi.Event{HomeTeam: "Arsenal", AwayTeam: "Chelsea"}
+
+	if got := (&OddsSyncService{}).normalizeOutcome("Arsenal", "corners", event); got != "Arsenal" {
+		t.Errorf("Expected Arsenal, got %s", got)
+	}
+}
+
+func TestExtractOddsFromEvent_NonUTCHost_StoresUTCTimestamps(t *testing.T) {
+	local := time.Local
+	time.Local = time.FixedZone("UTC+3", 3*60*60)
+	defer func() { time.Local = local }()
+
+	var event oddsapi.Event
+	if err := json.Unmarshal([]byte(syncedEventJSON), &event); err != nil {
+		t.Fatalf("failed to decode event: %v", err)
+	}
+	s := &OddsSyncService{}
+
+	for _, o := range s.extractOddsFromEvent(42, event) {
+		if o.Timestamp.Location() != time.UTC {
+			t.Fatalf("Expected UTC timestamp, got %v", o.Timestamp)
+		}
+	}
+}
+
+func TestParseAPIFootballUpdate_OffsetTime_ReturnsUTC(t *testing.T) {
+	got := parseAPIFootballUpdate("2026-01-10T15:00:00+03:00")
+
+	expected := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	if got.Location() != time.UTC || !got.Equal(expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
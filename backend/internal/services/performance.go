@@ -0,0 +1,179 @@
+package services
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+)
+
+// CalculatePerformanceMetrics computes a performance summary from settled bets.
+// Bets are expected in settlement order so drawdown follows the real P/L curve.
+func CalculatePerformanceMetrics(bets []models.Bet) *models.PerformanceMetrics {
+	metrics := &models.PerformanceMetrics{}
+
+	var totalOdds, peak, cumulative float64
+	var clvSum float64
+	var clvCount int
+	var returns []float64
+
+	for _, bet := range bets {
+		if bet.Status == BetStatusPending {
+			continue
+		}
+
+		metrics.TotalBets++
+		metrics.TotalStaked += bet.Stake
+		totalOdds += bet.Odds
+
+		if bet.Payout != nil {
+			metrics.TotalReturned += *bet.Payout
+		}
+
+		profit := 0.0
+		if bet.ProfitLoss != nil {
+			profit = *bet.ProfitLoss
+		}
+		metrics.TotalProfit += profit
+
+		switch bet.Status {
+		case BetStatusWon:
+			metrics.NumWins++
+		case BetStatusLost:
+			metrics.NumLosses++
+		}
+
+		if profit > metrics.BiggestWin {
+			metrics.BiggestWin = profit
+		}
+		if profit < metrics.BiggestLoss {
+			metrics.BiggestLoss = profit
+		}
+
+		// Drawdown from the running peak of cumulative profit
+		cumulative += profit
+		if cumulative > peak {
+			peak = cumulative
+		}
+		if drawdown := peak - cumulative; drawdown > metrics.MaxDrawdown {
+			metrics.MaxDrawdown = drawdown
+		}
+
+		if bet.Stake > 0 {
+			returns = append(returns, profit/bet.Stake)
+		}
+
+		if bet.ClosingLineValue != nil {
+			clvSum += *bet.ClosingLineValue
+			clvCount++
+		}
+
+		if bet.SettledAt != nil {
+			if metrics.FromDate.IsZero() || bet.SettledAt.Before(metrics.FromDate) {
+				metrics.FromDate = *bet.SettledAt
+			}
+			if bet.SettledAt.After(metrics.ToDate) {
+				metrics.ToDate = *bet.SettledAt
+			}
+		}
+	}
+
+	if metrics.TotalBets == 0 {
+		return metrics
+	}
+
+	metrics.AvgOdds = totalOdds / float64(metrics.TotalBets)
+	metrics.AvgStake = metrics.TotalStaked / float64(metrics.TotalBets)
+
+	if metrics.TotalStaked > 0 {
+		metrics.ROIPercentage = metrics.TotalProfit / metrics.TotalStaked * 100
+	}
+
+	if decided := metrics.NumWins + metrics.NumLosses; decided > 0 {
+		metrics.WinRate = float64(metrics.NumWins) / float64(decided)
+	}
+
+	if clvCount > 0 {
+		metrics.CLVAverage = clvSum / float64(clvCount)
+	}
+
+	metrics.SharpeRatio = sharpeRatio(returns)
+
+	return metrics
+}
+
+// sharpeRatio returns mean / standard deviation of per-bet returns
+func sharpeRatio(returns []float64) float64 {
+	if len(returns) < 2 {
+		return 0
+	}
+
+	var mean float64
+	for _, r := range returns {
+		mean += r
+	}
+	mean /= float64(len(returns))
+
+	var variance float64
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+	}
+	variance /= float64(len(returns) - 1)
+
+	if variance == 0 {
+		return 0
+	}
+
+	return mean / math.Sqrt(variance)
+}
+
+// CalculateDailyPerformance buckets settled bets by the calendar day they settled on in
+// loc, oldest day first. Bets without a settlement time are skipped.
+func CalculateDailyPerformance(bets []models.Bet, loc *time.Location) []models.DailyPerformance {
+	var days []models.DailyPerformance
+	index := make(map[string]int)
+
+	for _, bet := range bets {
+		if bet.Status == BetStatusPending || bet.SettledAt == nil {
+			continue
+		}
+
+		date := bet.SettledAt.In(loc).Format("2006-01-02")
+		i, ok := index[date]
+		if !ok {
+			i = len(days)
+			index[date] = i
+			days = append(days, models.DailyPerformance{Date: date})
+		}
+		day := &days[i]
+
+		day.TotalBets++
+		day.TotalStaked += bet.Stake
+		if bet.ProfitLoss != nil {
+			day.TotalProfit += *bet.ProfitLoss
+		}
+
+		switch bet.Status {
+		case BetStatusWon:
+			day.NumWins++
+		case BetStatusLost:
+			day.NumLosses++
+		case BetStatusVoid:
+			day.NumVoids++
+		}
+	}
+
+	sort.Slice(days, func(i, j int) bool { return days[i].Date < days[j].Date })
+
+	for i := range days {
+		day := &days[i]
+		if day.TotalStaked > 0 {
+			day.ROIPercentage = math.Round(day.TotalProfit/day.TotalStaked*100*100) / 100
+		}
+		day.TotalStaked = math.Round(day.TotalStaked*100) / 100
+		day.TotalProfit = math.Round(day.TotalProfit*100) / 100
+	}
+
+	return days
+}
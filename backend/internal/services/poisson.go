@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/dEnchanter/OddsIQ/backend/internal/markets"
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+	"github.com/dEnchanter/OddsIQ/backend/internal/repository"
+)
+
+// PoissonModelVersion tags predictions made by the in-process Poisson model
+const PoissonModelVersion = "poisson-fallback"
+
+const (
+	// poissonMaxGoals bounds the score matrix; the tail beyond it is negligible
+	poissonMaxGoals = 10
+	// poissonHomeAdvantage scales home expected goals up and away goals down
+	poissonHomeAdvantage = 1.12
+	// defaultLeagueGoalsPerTeam is used when the league average can't be computed
+	defaultLeagueGoalsPerTeam = 1.35
+)
+
+// PoissonModel holds the score probability matrix for a match given each side's
+// expected goals, assuming independent Poisson-distributed goals
+type PoissonModel struct {
+	HomeExpectedGoals float64
+	AwayExpectedGoals float64
+	scores            [][]float64 // scores[h][a] = P(home scores h, away scores a)
+}
+
+// NewPoissonModel builds the score matrix for the given expected goals
+func NewPoissonModel(homeExpectedGoals, awayExpectedGoals float64) *PoissonModel {
+	home := poissonDistribution(homeExpectedGoals)
+	away := poissonDistribution(awayExpectedGoals)
+
+	scores := make([][]float64, len(home))
+	for h := range home {
+		scores[h] = make([]float64, len(away))
+		for a := range away {
+			scores[h][a] = home[h] * away[a]
+		}
+	}
+
+	return &PoissonModel{
+		HomeExpectedGoals: homeExpectedGoals,
+		AwayExpectedGoals: awayExpectedGoals,
+		scores:            scores,
+	}
+}
+
+// poissonDistribution returns P(k goals) for k = 0..poissonMaxGoals
+func poissonDistribution(lambda float64) []float64 {
+	probs := make([]float64, poissonMaxGoals+1)
+	probs[0] = math.Exp(-lambda)
+	for k := 1; k <= poissonMaxGoals; k++ {
+		probs[k] = probs[k-1] * lambda / float64(k)
+	}
+	return probs
+}
+
+// sum adds up the probability of every score matching the predicate
+func (m *PoissonModel) sum(match func(home, away int) bool) float64 {
+	var total float64
+	for h, row := range m.scores {
+		for a, p := range row {
+			if match(h, a) {
+				total += p
+			}
+		}
+	}
+	return total
+}
+
+// Result returns home win, draw and away win probabilities, normalized to sum to one
+func (m *PoissonModel) Result() (home, draw, away float64) {
+	home = m.sum(func(h, a int) bool { return h > a })
+	draw = m.sum(func(h, a int) bool { return h == a })
+	away = m.sum(func(h, a int) bool { return h < a })
+
+	total := home + draw + away
+	return home / total, draw / total, away / total
+}
+
+// Over returns the probability of more than line total goals
+func (m *PoissonModel) Over(line float64) float64 {
+	return m.sum(func(h, a int) bool { return float64(h+a) > line })
+}
+
+// BTTS returns the probability that both teams score
+func (m *PoissonModel) BTTS() float64 {
+	return m.sum(func(h, a int) bool { return h > 0 && a > 0 })
+}
+
+// PoissonExpectedGoals estimates each side's expected goals from season averages:
+// attack strength times opponent defensive weakness, relative to the league average,
+// adjusted for home advantage
+func PoissonExpectedGoals(home, away *models.TeamStats, leagueAvg float64) (float64, float64) {
+	if leagueAvg <= 0 {
+		leagueAvg = defaultLeagueGoalsPerTeam
+	}
+
+	homeAttack := home.AvgGoalsScored / leagueAvg
+	homeDefence := home.AvgGoalsConceded / leagueAvg
+	awayAttack := away.AvgGoalsScored / leagueAvg
+	awayDefence := away.AvgGoalsConceded / leagueAvg
+
+	homeGoals := leagueAvg * homeAttack * awayDefence * poissonHomeAdvantage
+	awayGoals := leagueAvg * awayAttack * homeDefence / poissonHomeAdvantage
+
+	return homeGoals, awayGoals
+}
+
+// leagueGoalsPerTeam returns the average goals scored per team per match in a season
+func leagueGoalsPerTeam(stats []models.TeamStats) float64 {
+	var goals, matches int
+	for _, s := range stats {
+		goals += s.GoalsFor
+		matches += s.MatchesPlayed
+	}
+	if matches == 0 {
+		return defaultLeagueGoalsPerTeam
+	}
+	return float64(goals) / float64(matches)
+}
+
+// PoissonPredictor predicts fixtures from stored team stats without the ML service
+type PoissonPredictor struct {
+	statsRepo *repository.TeamStatsRepository
+}
+
+// NewPoissonPredictor creates a new Poisson predictor
+func NewPoissonPredictor(statsRepo *repository.TeamStatsRepository) *PoissonPredictor {
+	return &PoissonPredictor{statsRepo: statsRepo}
+}
+
+// Predict builds a prediction for a fixture from the teams' stats for its season,
+// falling back to the previous season early in a campaign. Stats come from the
+// fixture's league where it has one; for the previous season each team's stats come
+// from the league it played most in, since promoted and relegated teams change
+// league. The league goals average is always the fixture's league.
+func (p *PoissonPredictor) Predict(ctx context.Context, fixture *models.Fixture) (*models.Prediction, error) {
+	leagueID := 0
+	if fixture.LeagueID != nil {
+		leagueID = *fixture.LeagueID
+	}
+
+	var lastErr error
+	for _, season := range []int{fixture.Season, fixture.Season - 1} {
+		statsLeagueID := leagueID
+		if season != fixture.Season {
+			statsLeagueID = 0
+		}
+
+		home, err := p.statsRepo.GetByTeamAndSeason(ctx, fixture.HomeTeamID, statsLeagueID, season)
+		if err != nil || home.MatchesPlayed == 0 {
+			lastErr = err
+			continue
+		}
+		away, err := p.statsRepo.GetByTeamAndSeason(ctx, fixture.AwayTeamID, statsLeagueID, season)
+		if err != nil || away.MatchesPlayed == 0 {
+			lastErr = err
+			continue
+		}
+
+		leagueAvg := defaultLeagueGoalsPerTeam
+		if seasonStats, err := p.statsRepo.GetBySeason(ctx, leagueID, season); err == nil {
+			leagueAvg = leagueGoalsPerTeam(seasonStats)
+		}
+
+		return PoissonPrediction(fixture.ID, NewPoissonModel(PoissonExpectedGoals(home, away, leagueAvg))), nil
+	}
+
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no matches played")
+	}
+	return nil, fmt.Errorf("no team stats for fixture %d: %w", fixture.ID, lastErr)
+}
+
+// PoissonPrediction converts a Poisson model into a prediction; totals and BTTS
+// probabilities are carried in the prediction features
+func PoissonPrediction(fixtureID int, model *PoissonModel) *models.Prediction {
+	home, draw, away := model.Result()
+
+	outcome, confidence := "home_win", home
+	if draw > confidence {
+		outcome, confidence = "draw", draw
+	}
+	if away > confidence {
+		outcome, confidence = "away_win", away
+	}
+
+	return &models.Prediction{
+		FixtureID:        fixtureID,
+		ModelVersion:     PoissonModelVersion,
+		HomeWinProb:      home,
+		DrawProb:         draw,
+		AwayWinProb:      away,
+		PredictedOutcome: outcome,
+		ConfidenceScore:  confidence,
+		Features: map[string]interface{}{
+			"home_expected_goals": math.Round(model.HomeExpectedGoals*100) / 100,
+			"away_expected_goals": math.Round(model.AwayExpectedGoals*100) / 100,
+			"over_2_5":            math.Round(model.Over(markets.DefaultTotalsLine)*10000) / 10000,
+			"btts_yes":            math.Round(model.BTTS()*10000) / 10000,
+		},
+		PredictedAt: time.Now(),
+	}
+}
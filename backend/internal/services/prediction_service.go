@@ -3,28 +3,43 @@ package services
 import (
 	"context"
 	"fmt"
-	"log"
 	"math"
 	"sync"
 	"time"
 
 	"github.com/dEnchanter/OddsIQ/backend/config"
+	"github.com/dEnchanter/OddsIQ/backend/internal/logging"
 	"github.com/dEnchanter/OddsIQ/backend/internal/models"
 	"github.com/dEnchanter/OddsIQ/backend/internal/repository"
 )
 
 // PredictionService handles predictions and betting recommendations
 type PredictionService struct {
-	mlClient     *MLClient
-	fixturesRepo *repository.FixturesRepository
-	oddsRepo     *repository.OddsRepository
-	config       *config.Config
+	mlClient        *MLClient
+	fixturesRepo    *repository.FixturesRepository
+	oddsRepo        *repository.OddsRepository
+	predictionsRepo *repository.PredictionsRepository
+	poisson         *PoissonPredictor // Fallback when the ML service is unavailable
+	config          *config.Config
 
 	// Cache for predictions (fixture_id -> prediction)
 	cache      map[int]*models.Prediction
 	cacheMutex sync.RWMutex
 	cacheTime  map[int]time.Time
 	cacheTTL   time.Duration
+
+	// Cache for model metrics responses
+	metricsCache        *ModelMetricsResponse
+	metricsCacheTime    time.Time
+	allMetricsCache     *AllMarketsMetricsResponse
+	allMetricsCacheTime time.Time
+	metricsCacheMutex   sync.RWMutex
+	metricsCacheTTL     time.Duration
+
+	// Calibration fitted from past predictions, refitted after cacheTTL
+	calibrator      *IsotonicCalibrator
+	calibratorTime  time.Time
+	calibratorMutex sync.Mutex
 }
 
 // NewPredictionService creates a new prediction service
@@ -32,15 +47,20 @@ func NewPredictionService(
 	cfg *config.Config,
 	fixturesRepo *repository.FixturesRepository,
 	oddsRepo *repository.OddsRepository,
+	predictionsRepo *repository.PredictionsRepository,
+	statsRepo *repository.TeamStatsRepository,
 ) *PredictionService {
 	return &PredictionService{
-		mlClient:     NewMLClient(cfg.MLServiceURL),
-		fixturesRepo: fixturesRepo,
-		oddsRepo:     oddsRepo,
-		config:       cfg,
+		mlClient:        NewMLClient(cfg.MLServiceURL),
+		fixturesRepo:    fixturesRepo,
+		oddsRepo:        oddsRepo,
+		predictionsRepo: predictionsRepo,
+		poisson:         NewPoissonPredictor(statsRepo),
+		config:          cfg,
 		cache:        make(map[int]*models.Prediction),
 		cacheTime:    make(map[int]time.Time),
 		cacheTTL:     1 * time.Hour, // Cache predictions for 1 hour
+		metricsCacheTTL: cfg.MetricsCacheTTL,
 	}
 }
 
@@ -56,21 +76,80 @@ func (s *PredictionService) GetPrediction(ctx context.Context, fixture *models.F
 	}
 	s.cacheMutex.RUnlock()
 
+	// Check stored predictions before hitting the ML service
+	if pred := s.getStoredPrediction(ctx, fixture.ID, s.currentModelVersion(ctx)); pred != nil {
+		s.cachePrediction(pred)
+		return pred, nil
+	}
+
 	// Call ML service
 	pred, err := s.mlClient.Predict(ctx, fixture)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get prediction: %w", err)
+		// Fallback predictions are neither stored nor cached so the ML model is retried next time
+		fallback, fallbackErr := s.poisson.Predict(ctx, fixture)
+		if fallbackErr != nil {
+			return nil, fmt.Errorf("failed to get prediction: %w", err)
+		}
+		logging.Printf(ctx, "Warning: ML prediction failed for fixture %d, using Poisson fallback: %v", fixture.ID, err)
+		return fallback, nil
 	}
 
-	// Update cache
-	s.cacheMutex.Lock()
-	s.cache[fixture.ID] = pred
-	s.cacheTime[fixture.ID] = time.Now()
-	s.cacheMutex.Unlock()
+	// Write through to the database and update cache
+	s.storePrediction(ctx, pred)
+	s.cachePrediction(pred)
 
 	return pred, nil
 }
 
+// getStoredPrediction returns a persisted prediction made by the given model version
+// that is still within the cache TTL, or nil. Without a model version nothing is
+// returned, so predictions from a replaced model are never served.
+func (s *PredictionService) getStoredPrediction(ctx context.Context, fixtureID int, modelVersion string) *models.Prediction {
+	if s.predictionsRepo == nil || modelVersion == "" {
+		return nil
+	}
+
+	pred, err := s.predictionsRepo.GetLatestByFixtureAndModelVersion(ctx, fixtureID, modelVersion)
+	if err != nil {
+		return nil
+	}
+
+	if time.Since(pred.PredictedAt) >= s.cacheTTL {
+		return nil
+	}
+
+	return pred
+}
+
+// currentModelVersion returns the version of the model the ML service is serving,
+// from the cached model metrics (refreshed on reload), or "" if it can't be reached
+func (s *PredictionService) currentModelVersion(ctx context.Context) string {
+	metrics, err := s.GetModelMetrics(ctx)
+	if err != nil {
+		return ""
+	}
+	return metrics.ModelVersion
+}
+
+// storePrediction persists a fresh prediction, logging rather than failing on error
+func (s *PredictionService) storePrediction(ctx context.Context, pred *models.Prediction) {
+	if s.predictionsRepo == nil {
+		return
+	}
+
+	if err := s.predictionsRepo.Create(ctx, pred); err != nil {
+		logging.Printf(ctx, "Warning: Failed to store prediction for fixture %d: %v", pred.FixtureID, err)
+	}
+}
+
+// cachePrediction stores a prediction in the in-memory cache
+func (s *PredictionService) cachePrediction(pred *models.Prediction) {
+	s.cacheMutex.Lock()
+	s.cache[pred.FixtureID] = pred
+	s.cacheTime[pred.FixtureID] = time.Now()
+	s.cacheMutex.Unlock()
+}
+
 // GetPredictions gets predictions for multiple fixtures
 func (s *PredictionService) GetPredictions(ctx context.Context, fixtures []*models.Fixture) ([]*models.Prediction, error) {
 	// Check which fixtures need predictions
@@ -89,11 +168,37 @@ func (s *PredictionService) GetPredictions(ctx context.Context, fixtures []*mode
 	}
 	s.cacheMutex.RUnlock()
 
-	// Get missing predictions from ML service
+	// Fill from stored predictions before calling the ML service
+	var needML []*models.Fixture
+	modelVersion := ""
 	if len(needPrediction) > 0 {
-		newPreds, err := s.mlClient.PredictBatch(ctx, needPrediction)
+		modelVersion = s.currentModelVersion(ctx)
+	}
+	for _, f := range needPrediction {
+		pred := s.getStoredPrediction(ctx, f.ID, modelVersion)
+		if pred == nil {
+			needML = append(needML, f)
+			continue
+		}
+
+		s.cachePrediction(pred)
+		for i, fx := range fixtures {
+			if fx.ID == pred.FixtureID {
+				predictions[i] = pred
+				break
+			}
+		}
+	}
+
+	// Get missing predictions from ML service
+	if len(needML) > 0 {
+		newPreds, err := s.mlClient.PredictBatch(ctx, needML)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get batch predictions: %w", err)
+			return s.fillWithFallback(ctx, fixtures, predictions, needML, err)
+		}
+
+		for _, pred := range newPreds {
+			s.storePrediction(ctx, pred)
 		}
 
 		// Update cache and fill in predictions array
@@ -116,6 +221,41 @@ func (s *PredictionService) GetPredictions(ctx context.Context, fixtures []*mode
 	return predictions, nil
 }
 
+// fillWithFallback fills missing predictions from the Poisson model after the ML batch
+// call failed. The ML error is returned only if no fixture could be predicted.
+func (s *PredictionService) fillWithFallback(
+	ctx context.Context,
+	fixtures []*models.Fixture,
+	predictions []*models.Prediction,
+	missing []*models.Fixture,
+	mlErr error,
+) ([]*models.Prediction, error) {
+	logging.Printf(ctx, "Warning: ML batch prediction failed, using Poisson fallback: %v", mlErr)
+
+	filled := 0
+	for _, f := range missing {
+		pred, err := s.poisson.Predict(ctx, f)
+		if err != nil {
+			logging.Printf(ctx, "Warning: No fallback prediction for fixture %d: %v", f.ID, err)
+			continue
+		}
+
+		for i, fx := range fixtures {
+			if fx.ID == f.ID {
+				predictions[i] = pred
+				break
+			}
+		}
+		filled++
+	}
+
+	if filled == 0 {
+		return nil, fmt.Errorf("failed to get batch predictions: %w", mlErr)
+	}
+
+	return predictions, nil
+}
+
 // CalculateExpectedValue calculates EV for a bet
 func (s *PredictionService) CalculateExpectedValue(modelProb, odds float64) float64 {
 	// EV = (probability * odds) - 1
@@ -148,16 +288,25 @@ func (s *PredictionService) CalculateKellyStake(modelProb, odds, bankroll float6
 	return adjustedKelly * bankroll
 }
 
-// GetWeeklyPicks generates betting recommendations for upcoming fixtures
-func (s *PredictionService) GetWeeklyPicks(ctx context.Context, bankroll float64) ([]*models.WeeklyPick, error) {
-	// Get upcoming fixtures (limit 20)
-	fixtureSlice, err := s.fixturesRepo.GetUpcoming(ctx, 20)
+// WeeklyPicksFilter selects which 1X2 weekly picks are returned
+type WeeklyPicksFilter struct {
+	MinEV         float64 // Minimum expected value per unit staked
+	MinConfidence float64 // Minimum model confidence score (0-1)
+	Limit         int     // Maximum picks returned, highest EV first; 0 means no cap
+	Window        FixtureWindow
+}
+
+// GetWeeklyPicks generates betting recommendations for upcoming fixtures in the
+// filter's window, keeping picks that meet its EV and confidence minimums
+func (s *PredictionService) GetWeeklyPicks(ctx context.Context, bankroll float64, filter WeeklyPicksFilter) ([]*models.WeeklyPick, error) {
+	window := filter.Window
+	fixtureSlice, err := s.fixturesRepo.GetUpcomingInWindow(ctx, window.From, window.To)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get upcoming fixtures: %w", err)
 	}
 
 	if len(fixtureSlice) == 0 {
-		log.Println("No upcoming fixtures found")
+		logging.Printf(ctx, "No upcoming fixtures found")
 		return []*models.WeeklyPick{}, nil
 	}
 
@@ -175,37 +324,44 @@ func (s *PredictionService) GetWeeklyPicks(ctx context.Context, bankroll float64
 
 	var picks []*models.WeeklyPick
 
+	// EV is computed on calibrated probabilities once enough results are in
+	calibrator := s.getCalibrator(ctx)
+
 	for i, fixture := range fixtures {
-		pred := predictions[i]
-		if pred == nil {
+		if predictions[i] == nil {
+			continue
+		}
+		pred := calibrator.CalibratePrediction(predictions[i])
+		if pred.ConfidenceScore < filter.MinConfidence {
 			continue
 		}
-
-		// Get best odds for each outcome
-		// For now, use synthetic odds based on predictions
-		// TODO: Get real odds from odds repository
-		homeOdds := 1.0 / pred.HomeWinProb * 0.95  // Add 5% margin
-		drawOdds := 1.0 / pred.DrawProb * 0.95
-		awayOdds := 1.0 / pred.AwayWinProb * 0.95
 
 		// Check each outcome for value
 		outcomes := []struct {
 			betType  string
 			prob     float64
-			odds     float64
 			outcome  string
 		}{
-			{"home_win", pred.HomeWinProb, homeOdds, "home_win"},
-			{"draw", pred.DrawProb, drawOdds, "draw"},
-			{"away_win", pred.AwayWinProb, awayOdds, "away_win"},
+			{"home_win", pred.HomeWinProb, "Home"},
+			{"draw", pred.DrawProb, "Draw"},
+			{"away_win", pred.AwayWinProb, "Away"},
 		}
 
 		for _, o := range outcomes {
-			ev := s.CalculateExpectedValue(o.prob, o.odds)
+			odds, bookmaker, synthetic := s.bestH2HOdds(ctx, fixture.ID, o.outcome, o.prob)
+			if odds <= 1 {
+				continue
+			}
+
+			ev := s.CalculateExpectedValue(o.prob, odds)
 
-			// Only include picks with positive EV above threshold
-			if ev >= s.config.MinEVThreshold {
-				stake := s.CalculateKellyStake(o.prob, o.odds, bankroll)
+			// Only include picks with EV above the filter's threshold
+			if ev >= filter.MinEV {
+				stake := s.CalculateKellyStake(o.prob, odds, bankroll)
+				stake, stakeAdjusted, keep := normalizeStake(s.config, stake)
+				if !keep {
+					continue
+				}
 
 				confidence := "low"
 				if pred.ConfidenceScore > 0.5 {
@@ -218,14 +374,16 @@ func (s *PredictionService) GetWeeklyPicks(ctx context.Context, bankroll float64
 				pick := &models.WeeklyPick{
 					Fixture:        *fixture,
 					Prediction:     *pred,
-					BestOdds:       o.odds,
-					Bookmaker:      "synthetic", // TODO: Get from odds repo
+					BestOdds:       odds,
+					Bookmaker:      bookmaker,
 					ExpectedValue:  ev,
 					EVPercentage:   ev * 100,
-					SuggestedStake: math.Round(stake*100) / 100,
+					SuggestedStake: stake,
 					KellyFraction:  s.config.KellyFraction,
 					BetType:        o.betType,
 					Confidence:     confidence,
+					StakeAdjusted:  stakeAdjusted,
+					SyntheticOdds:  synthetic,
 				}
 
 				picks = append(picks, pick)
@@ -242,12 +400,117 @@ func (s *PredictionService) GetWeeklyPicks(ctx context.Context, bankroll float64
 		}
 	}
 
+	if filter.Limit > 0 && len(picks) > filter.Limit {
+		picks = picks[:filter.Limit]
+	}
+
 	return picks, nil
 }
 
+// getCalibrator returns the calibration fitted from settled predictions, or nil
+// if there aren't enough results yet (or they couldn't be loaded)
+func (s *PredictionService) getCalibrator(ctx context.Context) *IsotonicCalibrator {
+	if s.predictionsRepo == nil {
+		return nil
+	}
+
+	s.calibratorMutex.Lock()
+	defer s.calibratorMutex.Unlock()
+
+	if !s.calibratorTime.IsZero() && time.Since(s.calibratorTime) < s.cacheTTL {
+		return s.calibrator
+	}
+
+	results, err := s.predictionsRepo.GetResults(ctx, 0)
+	if err != nil {
+		logging.Printf(ctx, "Warning: Failed to load prediction results for calibration: %v", err)
+		return nil
+	}
+
+	s.calibrator = nil
+	if samples := CalibrationSamples(results); len(samples) >= MinCalibrationSamples {
+		s.calibrator = FitIsotonic(samples)
+	}
+	s.calibratorTime = time.Now()
+
+	return s.calibrator
+}
+
+// GetCalibration returns reliability diagram data for past 1X2 predictions
+func (s *PredictionService) GetCalibration(ctx context.Context, numBuckets int) (*CalibrationReport, error) {
+	if s.predictionsRepo == nil {
+		return nil, fmt.Errorf("predictions are not stored")
+	}
+
+	results, err := s.predictionsRepo.GetResults(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	samples := CalibrationSamples(results)
+
+	return &CalibrationReport{
+		Buckets:    ReliabilityBuckets(samples, numBuckets),
+		Fixtures:   len(results),
+		Samples:    len(samples),
+		BrierScore: math.Round(BrierScore(samples)*10000) / 10000,
+		Applied:    len(samples) >= MinCalibrationSamples,
+	}, nil
+}
+
+// GetAccuracy scores past 1X2 predictions against results for a season (0 for all),
+// bucketed by confidence
+func (s *PredictionService) GetAccuracy(ctx context.Context, season, numBuckets int) (*AccuracyReport, error) {
+	if s.predictionsRepo == nil {
+		return nil, fmt.Errorf("predictions are not stored")
+	}
+
+	results, err := s.predictionsRepo.GetResults(ctx, season)
+	if err != nil {
+		return nil, err
+	}
+
+	report := CalculateAccuracy(results, numBuckets)
+	report.Season = season
+	return report, nil
+}
+
+// bestH2HOdds returns the best stored 1X2 odds for an outcome (Home, Draw, Away).
+// Falls back to synthetic odds (fair price minus a 5% margin) when none are stored.
+func (s *PredictionService) bestH2HOdds(ctx context.Context, fixtureID int, outcome string, prob float64) (float64, string, bool) {
+	best, err := s.oddsRepo.GetBestOdds(ctx, fixtureID, "h2h", outcome, 0, "")
+	if err == nil {
+		return best.OddsValue, best.Bookmaker, false
+	}
+
+	if prob <= 0 {
+		return 0, "", true
+	}
+
+	return 1.0 / prob * 0.95, "synthetic", true
+}
+
 // GetModelMetrics returns current model performance metrics
 func (s *PredictionService) GetModelMetrics(ctx context.Context) (*ModelMetricsResponse, error) {
-	return s.mlClient.GetModelMetrics(ctx)
+	s.metricsCacheMutex.RLock()
+	if s.metricsCache != nil && time.Since(s.metricsCacheTime) < s.metricsCacheTTL {
+		metrics := s.metricsCache
+		s.metricsCacheMutex.RUnlock()
+		return metrics, nil
+	}
+	s.metricsCacheMutex.RUnlock()
+
+	metrics, err := s.mlClient.GetModelMetrics(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.metricsCacheMutex.Lock()
+	s.metricsCache = metrics
+	s.metricsCacheTime = time.Now()
+	s.metricsCacheMutex.Unlock()
+
+	return metrics, nil
 }
 
 // CheckMLServiceHealth checks if ML service is available
@@ -269,5 +532,53 @@ func (s *PredictionService) ClearCache() {
 
 // GetAllMarketsMetrics returns metrics for all market models
 func (s *PredictionService) GetAllMarketsMetrics(ctx context.Context) (*AllMarketsMetricsResponse, error) {
-	return s.mlClient.GetAllMarketsMetrics(ctx)
+	s.metricsCacheMutex.RLock()
+	if s.allMetricsCache != nil && time.Since(s.allMetricsCacheTime) < s.metricsCacheTTL {
+		metrics := s.allMetricsCache
+		s.metricsCacheMutex.RUnlock()
+		return metrics, nil
+	}
+	s.metricsCacheMutex.RUnlock()
+
+	metrics, err := s.mlClient.GetAllMarketsMetrics(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.metricsCacheMutex.Lock()
+	s.allMetricsCache = metrics
+	s.allMetricsCacheTime = time.Now()
+	s.metricsCacheMutex.Unlock()
+
+	return metrics, nil
+}
+
+// ReloadModel reloads the models on the ML service, clears cached predictions
+// and metrics, and returns the freshly loaded model's metrics
+func (s *PredictionService) ReloadModel(ctx context.Context) (*ModelMetricsResponse, error) {
+	if err := s.mlClient.ReloadModel(ctx); err != nil {
+		return nil, fmt.Errorf("failed to reload model: %w", err)
+	}
+
+	s.ClearCache()
+	s.ClearMetricsCache()
+
+	s.calibratorMutex.Lock()
+	s.calibratorTime = time.Time{} // Refit on next use
+	s.calibratorMutex.Unlock()
+
+	metrics, err := s.GetModelMetrics(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get model metrics after reload: %w", err)
+	}
+
+	return metrics, nil
+}
+
+// ClearMetricsCache clears the cached model metrics
+func (s *PredictionService) ClearMetricsCache() {
+	s.metricsCacheMutex.Lock()
+	defer s.metricsCacheMutex.Unlock()
+	s.metricsCache = nil
+	s.allMetricsCache = nil
 }
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/dEnchanter/OddsIQ/backend/internal/markets"
+)
+
+// probOverrideTolerance is how far a group of overridden probabilities may sum from 1
+const probOverrideTolerance = 0.02
+
+// ValidateProbOverrides checks custom probabilities keyed by ML market and outcome key.
+// Every outcome must be known to its market with a probability in [0, 1], and each
+// overridden set of mutually exclusive outcomes must sum to ~1. Whole and quarter goal
+// lines can push, so their outcomes only need to sum to at most ~1.
+func ValidateProbOverrides(overrides map[string]map[string]float64) error {
+	for marketKey, outcomes := range overrides {
+		market, ok := markets.ForModel(marketKey)
+		if !ok {
+			return fmt.Errorf("unknown market %q", marketKey)
+		}
+		if len(outcomes) == 0 {
+			return fmt.Errorf("no probabilities given for market %q", marketKey)
+		}
+
+		sums := make(map[string]float64)
+		exhaustive := make(map[string]bool)
+		for outcome, prob := range outcomes {
+			if !market.IsOutcomeKey(outcome) {
+				return fmt.Errorf("unknown outcome %q for market %q", outcome, marketKey)
+			}
+			if math.IsNaN(prob) || prob < 0 || prob > 1 {
+				return fmt.Errorf("probability for %s %s must be between 0 and 1", marketKey, outcome)
+			}
+
+			group, complete := overrideGroup(MarketType(marketKey), outcome)
+			sums[group] += prob
+			exhaustive[group] = complete
+		}
+
+		for group, sum := range sums {
+			if sum > 1+probOverrideTolerance || (exhaustive[group] && sum < 1-probOverrideTolerance) {
+				return fmt.Errorf("probabilities for %s sum to %.3f, expected 1", group, sum)
+			}
+		}
+	}
+
+	return nil
+}
+
+// overrideGroup returns the group of outcomes an overridden probability is validated
+// with, and whether the group's outcomes cover every result so must sum to 1
+func overrideGroup(market MarketType, outcome string) (string, bool) {
+	if group, ok := exclusiveGroup(BetOutcome{Market: market, Outcome: outcome}); ok {
+		return group, true
+	}
+
+	switch market {
+	case MarketTypeOverUnder:
+		_, lineKey, _ := strings.Cut(outcome, "_")
+		return string(market) + "_" + lineKey, false
+
+	case MarketTypeHandicap:
+		if side, line, ok := markets.ParseHandicapOutcomeKey(outcome); ok {
+			if side == "Away" {
+				line = -line // Group on the home line
+			}
+			return string(market) + "_" + markets.HandicapOutcomeKey("home", line), false
+		}
+	}
+
+	return string(market), false
+}
+
+// applyProbOverrides replaces the predicted probabilities of overridden outcomes, adding
+// markets the model didn't predict. The predicted outcome and confidence follow the new
+// probabilities.
+func applyProbOverrides(predictions *MultiMarketPredictionResponse, overrides map[string]map[string]float64) {
+	if len(overrides) == 0 {
+		return
+	}
+	if predictions.Predictions == nil {
+		predictions.Predictions = make(map[string]MarketPrediction)
+	}
+
+	for marketKey, outcomes := range overrides {
+		pred, ok := predictions.Predictions[marketKey]
+		if !ok {
+			pred = MarketPrediction{Market: marketKey}
+			if market, found := markets.ForModel(marketKey); found {
+				pred.Description = market.Name
+			}
+		}
+
+		probs := make(map[string]float64, len(pred.Probabilities)+len(outcomes))
+		for outcome, prob := range pred.Probabilities {
+			probs[outcome] = prob
+		}
+		for outcome, prob := range outcomes {
+			probs[outcome] = prob
+		}
+
+		pred.Probabilities = probs
+		pred.PredictedOutcome, pred.Confidence = "", 0
+		for outcome, prob := range probs {
+			if prob > pred.Confidence {
+				pred.PredictedOutcome, pred.Confidence = outcome, prob
+			}
+		}
+		predictions.Predictions[marketKey] = pred
+	}
+}
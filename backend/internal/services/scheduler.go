@@ -2,9 +2,11 @@ package services
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"time"
 
+	"github.com/dEnchanter/OddsIQ/backend/config"
 	"github.com/robfig/cron/v3"
 )
 
@@ -13,31 +15,99 @@ type Scheduler struct {
 	cron              *cron.Cron
 	fixtureSyncService *FixtureSyncService
 	oddsSyncService    *OddsSyncService
+	liveOddsService    *LiveOddsSyncService  // optional, polls in-play odds
+	notifications      *NotificationService  // optional, sends the weekly digest
+	bookmakerSync      *BookmakerSyncService // optional, refreshes the bookmakers reference list
+	config             config.SchedulerConfig
+	matchDays          map[time.Weekday]bool
+
+	// ctx is passed to every job and cancelled by Stop so in-flight syncs abort
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
-// NewScheduler creates a new scheduler
+// NewScheduler creates a new scheduler, returning an error if any cron expression is invalid
 func NewScheduler(
+	cfg config.SchedulerConfig,
 	fixtureSyncService *FixtureSyncService,
 	oddsSyncService *OddsSyncService,
-) *Scheduler {
-	// Create cron with second precision
-	c := cron.New(cron.WithSeconds())
+	liveOddsService *LiveOddsSyncService,
+	notifications *NotificationService,
+	bookmakerSync *BookmakerSyncService,
+) (*Scheduler, error) {
+	if err := validateSchedules(cfg); err != nil {
+		return nil, err
+	}
+
+	// Create cron with second precision, skipping runs that overlap a still-running job
+	c := cron.New(
+		cron.WithSeconds(),
+		cron.WithChain(cron.SkipIfStillRunning(cron.DefaultLogger)),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	days := make(map[time.Weekday]bool, len(cfg.MatchDays))
+	for _, day := range cfg.MatchDays {
+		days[day] = true
+	}
 
 	return &Scheduler{
 		cron:              c,
 		fixtureSyncService: fixtureSyncService,
 		oddsSyncService:    oddsSyncService,
+		liveOddsService:    liveOddsService,
+		notifications:      notifications,
+		bookmakerSync:      bookmakerSync,
+		config:             cfg,
+		matchDays:          days,
+		ctx:                ctx,
+		cancel:             cancel,
+	}, nil
+}
+
+// validateSchedules parses every configured cron expression
+func validateSchedules(cfg config.SchedulerConfig) error {
+	parser := cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+	schedules := []struct {
+		name string
+		spec string
+	}{
+		{"CRON_FIXTURES", cfg.FixturesCron},
+		{"CRON_RESULTS", cfg.ResultsCron},
+		{"CRON_ODDS_ALL", cfg.OddsAllCron},
+		{"CRON_ODDS_H2H", cfg.OddsH2HCron},
+		{"CRON_CLOSING_LINES", cfg.ClosingLinesCron},
+		{"CRON_CLEANUP", cfg.CleanupCron},
+		{"CRON_LIVE_ODDS", cfg.LiveOddsCron},
+		{"CRON_WEEKLY_DIGEST", cfg.WeeklyDigestCron},
+		{"CRON_BOOKMAKERS", cfg.BookmakersCron},
+		{"CRON_DEV_FIXTURES", cfg.DevFixturesCron},
+		{"CRON_DEV_ODDS", cfg.DevOddsCron},
+	}
+
+	for _, sch := range schedules {
+		if _, err := parser.Parse(sch.spec); err != nil {
+			return fmt.Errorf("invalid cron expression for %s (%q): %w", sch.name, sch.spec, err)
+		}
+	}
+
+	if cfg.OddsRetentionDays <= 0 {
+		return fmt.Errorf("invalid odds retention days: %d", cfg.OddsRetentionDays)
 	}
+
+	return nil
 }
 
 // Start starts the scheduler and all jobs
 func (s *Scheduler) Start() error {
 	log.Println("Starting scheduler...")
 
-	ctx := context.Background()
+	ctx := s.ctx
 
-	// Job 1: Sync upcoming fixtures daily at 6:00 AM
-	_, err := s.cron.AddFunc("0 0 6 * * *", func() {
+	// Job 1: Sync upcoming fixtures (default daily at 6:00 AM)
+	_, err := s.cron.AddFunc(s.config.FixturesCron, func() {
 		log.Println("Running scheduled job: Sync upcoming fixtures")
 		if err := s.fixtureSyncService.SyncUpcomingFixtures(ctx); err != nil {
 			log.Printf("Error syncing upcoming fixtures: %v", err)
@@ -47,12 +117,10 @@ func (s *Scheduler) Start() error {
 		return err
 	}
 
-	// Job 2: Update fixture results every 30 minutes during match days
-	_, err = s.cron.AddFunc("0 */30 * * * *", func() {
-		// Only run on match days (Friday-Monday)
-		now := time.Now()
-		weekday := now.Weekday()
-		if weekday >= time.Friday || weekday <= time.Monday {
+	// Job 2: Update fixture results during match days (default every 30 minutes)
+	_, err = s.cron.AddFunc(s.config.ResultsCron, func() {
+		// Only run on match days (Friday-Monday by default)
+		if s.IsMatchDay(time.Now().Weekday()) {
 			log.Println("Running scheduled job: Update fixture results")
 			if err := s.fixtureSyncService.UpdateFixtureResults(ctx); err != nil {
 				log.Printf("Error updating fixture results: %v", err)
@@ -63,8 +131,8 @@ func (s *Scheduler) Start() error {
 		return err
 	}
 
-	// Job 3: Sync odds every 2 hours
-	_, err = s.cron.AddFunc("0 0 */2 * * *", func() {
+	// Job 3: Sync odds (default every 2 hours)
+	_, err = s.cron.AddFunc(s.config.OddsAllCron, func() {
 		log.Println("Running scheduled job: Sync odds for all markets")
 		if err := s.oddsSyncService.SyncAllMarkets(ctx); err != nil {
 			log.Printf("Error syncing odds: %v", err)
@@ -74,8 +142,8 @@ func (s *Scheduler) Start() error {
 		return err
 	}
 
-	// Job 4: Sync H2H odds every hour (more frequent for main market)
-	_, err = s.cron.AddFunc("0 0 * * * *", func() {
+	// Job 4: Sync H2H odds (default every hour, more frequent for main market)
+	_, err = s.cron.AddFunc(s.config.OddsH2HCron, func() {
 		log.Println("Running scheduled job: Sync H2H odds")
 		if err := s.oddsSyncService.SyncH2HOdds(ctx); err != nil {
 			log.Printf("Error syncing H2H odds: %v", err)
@@ -85,10 +153,21 @@ func (s *Scheduler) Start() error {
 		return err
 	}
 
-	// Job 5: Cleanup old odds weekly (Sunday at 3:00 AM)
-	_, err = s.cron.AddFunc("0 0 3 * * 0", func() {
+	// Job 5: Mark closing lines (default shortly after typical kickoff times, :00 and :30)
+	_, err = s.cron.AddFunc(s.config.ClosingLinesCron, func() {
+		log.Println("Running scheduled job: Mark closing lines")
+		if err := s.oddsSyncService.MarkClosingLines(ctx, 24*time.Hour); err != nil {
+			log.Printf("Error marking closing lines: %v", err)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	// Job 6: Cleanup old odds (default weekly, Sunday at 3:00 AM)
+	_, err = s.cron.AddFunc(s.config.CleanupCron, func() {
 		log.Println("Running scheduled job: Cleanup old odds")
-		if err := s.oddsSyncService.CleanupOldOdds(ctx, 30); err != nil {
+		if err := s.oddsSyncService.CleanupOldOdds(ctx, s.config.OddsRetentionDays); err != nil {
 			log.Printf("Error cleaning up old odds: %v", err)
 		}
 	})
@@ -96,6 +175,28 @@ func (s *Scheduler) Start() error {
 		return err
 	}
 
+	// Job 7: Sync live odds (default every minute, only does work while fixtures are in play)
+	if s.liveOddsService != nil {
+		_, err = s.cron.AddFunc(s.config.LiveOddsCron, func() {
+			if err := s.liveOddsService.SyncAllLiveOdds(ctx); err != nil {
+				log.Printf("Error syncing live odds: %v", err)
+			}
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Job 8: Send the weekly digest (default Friday at 9:00 AM)
+	if err := s.addWeeklyDigestJob(ctx); err != nil {
+		return err
+	}
+
+	// Job 9: Refresh the bookmakers reference list (default weekly, Monday at 4:00 AM)
+	if err := s.addBookmakersJob(ctx); err != nil {
+		return err
+	}
+
 	// Start the cron scheduler
 	s.cron.Start()
 	log.Println("Scheduler started successfully")
@@ -103,16 +204,53 @@ func (s *Scheduler) Start() error {
 	return nil
 }
 
-// Stop stops the scheduler
+// addWeeklyDigestJob schedules the weekly digest when notifications are configured.
+// Cron runs each job on its own goroutine, so slow webhook retries or SMTP servers don't hold up the syncs.
+func (s *Scheduler) addWeeklyDigestJob(ctx context.Context) error {
+	if s.notifications == nil || !s.notifications.Enabled() {
+		return nil
+	}
+
+	_, err := s.cron.AddFunc(s.config.WeeklyDigestCron, func() {
+		log.Println("Running scheduled job: Send weekly digest")
+		if err := s.notifications.SendWeeklyDigest(ctx); err != nil {
+			log.Printf("Error sending weekly digest: %v", err)
+		}
+	})
+	return err
+}
+
+// addBookmakersJob schedules the bookmakers reference list refresh
+func (s *Scheduler) addBookmakersJob(ctx context.Context) error {
+	if s.bookmakerSync == nil {
+		return nil
+	}
+
+	_, err := s.cron.AddFunc(s.config.BookmakersCron, func() {
+		log.Println("Running scheduled job: Sync bookmakers")
+		if err := s.bookmakerSync.SyncBookmakers(ctx); err != nil {
+			log.Printf("Error syncing bookmakers: %v", err)
+		}
+	})
+	return err
+}
+
+// IsMatchDay reports whether result updates should run on the given weekday
+func (s *Scheduler) IsMatchDay(day time.Weekday) bool {
+	return s.matchDays[day]
+}
+
+// Stop stops the scheduler, cancels in-flight jobs and waits for them to return
 func (s *Scheduler) Stop() {
 	log.Println("Stopping scheduler...")
-	s.cron.Stop()
+	s.cancel()
+	<-s.cron.Stop().Done()
 	log.Println("Scheduler stopped")
 }
 
 // RunNow executes all jobs immediately (useful for testing)
 func (s *Scheduler) RunNow() error {
-	ctx := context.Background()
+	ctx := s.ctx
 
 	log.Println("Running all jobs immediately...")
 
@@ -169,10 +307,10 @@ func (s *Scheduler) GetNextRunTimes() []time.Time {
 func (s *Scheduler) StartDevelopmentSchedule() error {
 	log.Println("Starting development scheduler...")
 
-	ctx := context.Background()
+	ctx := s.ctx
 
-	// Sync fixtures once per day at noon
-	_, err := s.cron.AddFunc("0 0 12 * * *", func() {
+	// Sync fixtures (default once per day at noon)
+	_, err := s.cron.AddFunc(s.config.DevFixturesCron, func() {
 		log.Println("[DEV] Syncing upcoming fixtures")
 		if err := s.fixtureSyncService.SyncUpcomingFixtures(ctx); err != nil {
 			log.Printf("Error: %v", err)
@@ -182,8 +320,8 @@ func (s *Scheduler) StartDevelopmentSchedule() error {
 		return err
 	}
 
-	// Sync odds twice per day
-	_, err = s.cron.AddFunc("0 0 10,18 * * *", func() {
+	// Sync odds (default twice per day)
+	_, err = s.cron.AddFunc(s.config.DevOddsCron, func() {
 		log.Println("[DEV] Syncing odds")
 		if err := s.oddsSyncService.SyncAllMarkets(ctx); err != nil {
 			log.Printf("Error: %v", err)
@@ -193,6 +331,16 @@ func (s *Scheduler) StartDevelopmentSchedule() error {
 		return err
 	}
 
+	// Send the weekly digest (same schedule as production)
+	if err := s.addWeeklyDigestJob(ctx); err != nil {
+		return err
+	}
+
+	// Refresh the bookmakers reference list (same schedule as production)
+	if err := s.addBookmakersJob(ctx); err != nil {
+		return err
+	}
+
 	s.cron.Start()
 	log.Println("Development scheduler started")
 
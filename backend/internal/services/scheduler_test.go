@@ -0,0 +1,40 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dEnchanter/OddsIQ/backend/config"
+)
+
+func TestScheduler_IsMatchDay_DefaultMatchDays_ExcludesMidweek(t *testing.T) {
+	t.Setenv("MATCH_DAYS", "")
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	s, err := NewScheduler(cfg.Scheduler, nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("NewScheduler returned error: %v", err)
+	}
+	defer s.Stop()
+
+	tests := []struct {
+		day      time.Weekday
+		expected bool
+	}{
+		{time.Sunday, true},
+		{time.Monday, true},
+		{time.Tuesday, false},
+		{time.Wednesday, false},
+		{time.Thursday, false},
+		{time.Friday, true},
+		{time.Saturday, true},
+	}
+
+	for _, tt := range tests {
+		if got := s.IsMatchDay(tt.day); got != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.day, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"math"
+
+	"github.com/dEnchanter/OddsIQ/backend/config"
+)
+
+// Minimum stake policies
+const (
+	MinStakePolicyRoundUp = "round_up" // Raise stakes below the minimum up to the minimum
+	MinStakePolicyDrop    = "drop"     // Drop picks whose stake is below the minimum
+)
+
+// normalizeStake rounds a suggested stake to the configured unit and applies the
+// configured minimum bet. Returns the stake to use, whether it was raised to the
+// minimum, and whether the pick should be kept at all. Stakes that round to nothing
+// are dropped; a zero minimum disables the minimum check.
+func normalizeStake(cfg *config.Config, stake float64) (float64, bool, bool) {
+	if stake <= 0 {
+		return stake, false, true
+	}
+
+	rounded := roundStake(cfg, stake)
+	if cfg.MinStake > 0 && rounded < cfg.MinStake {
+		if cfg.MinStakePolicy == MinStakePolicyDrop {
+			return rounded, false, false
+		}
+		// Round the minimum up so the raised stake stays on the unit
+		return ceilStake(cfg, cfg.MinStake), true, true
+	}
+
+	if rounded <= 0 {
+		return 0, false, false
+	}
+
+	return rounded, false, true
+}
+
+// roundStake rounds a stake to the nearest multiple of the configured rounding unit
+func roundStake(cfg *config.Config, stake float64) float64 {
+	unit := stakeUnit(cfg)
+	return toCents(math.Round(stake/unit) * unit)
+}
+
+// floorStake rounds a stake down to a multiple of the configured rounding unit, for
+// scaling stakes without exceeding a cap
+func floorStake(cfg *config.Config, stake float64) float64 {
+	unit := stakeUnit(cfg)
+	return toCents(math.Floor(stake/unit+1e-9) * unit)
+}
+
+// ceilStake rounds a stake up to a multiple of the configured rounding unit
+func ceilStake(cfg *config.Config, stake float64) float64 {
+	unit := stakeUnit(cfg)
+	return toCents(math.Ceil(stake/unit-1e-9) * unit)
+}
+
+// stakeUnit returns the configured rounding unit, defaulting to 0.01
+func stakeUnit(cfg *config.Config) float64 {
+	if cfg.StakeRounding <= 0 {
+		return 0.01
+	}
+	return cfg.StakeRounding
+}
+
+// toCents clears floating point noise left by scaling to the rounding unit
+func toCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+	"github.com/dEnchanter/OddsIQ/backend/internal/repository"
+	"github.com/dEnchanter/OddsIQ/backend/pkg/apifootball"
+)
+
+// StandingsSyncService handles syncing league standings from API-Football into team_stats
+type StandingsSyncService struct {
+	apiClient     *apifootball.Client
+	teamsRepo     *repository.TeamsRepository
+	teamStatsRepo *repository.TeamStatsRepository
+	leaguesRepo   *repository.LeaguesRepository
+	leagueIDs     []int // API-Football league IDs to sync standings for
+}
+
+// NewStandingsSyncService creates a new standings sync service
+func NewStandingsSyncService(
+	apiClient *apifootball.Client,
+	teamsRepo *repository.TeamsRepository,
+	teamStatsRepo *repository.TeamStatsRepository,
+	leaguesRepo *repository.LeaguesRepository,
+	leagueIDs []int,
+) *StandingsSyncService {
+	return &StandingsSyncService{
+		apiClient:     apiClient,
+		teamsRepo:     teamsRepo,
+		teamStatsRepo: teamStatsRepo,
+		leaguesRepo:   leaguesRepo,
+		leagueIDs:     leagueIDs,
+	}
+}
+
+// SyncStandings fetches standings for all configured leagues and upserts them as team stats
+func (s *StandingsSyncService) SyncStandings(ctx context.Context, season int) error {
+	for _, leagueID := range s.leagueIDs {
+		if err := s.SyncLeagueStandings(ctx, leagueID, season); err != nil {
+			if apifootball.IsQuotaExceeded(err) {
+				return err
+			}
+			log.Printf("Failed to sync standings for league %d season %d: %v", leagueID, season, err)
+		}
+	}
+
+	return nil
+}
+
+// SyncLeagueStandings fetches standings for a single league and season. The league
+// must already be stored, since team stats are keyed by the internal league ID.
+func (s *StandingsSyncService) SyncLeagueStandings(ctx context.Context, leagueID, season int) error {
+	log.Printf("Syncing standings for league %d season %d...", leagueID, season)
+
+	league, err := s.leaguesRepo.GetByAPIFootballID(ctx, leagueID)
+	if err != nil {
+		return fmt.Errorf("failed to resolve league %d: %w", leagueID, err)
+	}
+
+	standingsResp, err := s.apiClient.GetStandings(ctx, leagueID, season)
+	if err != nil {
+		return fmt.Errorf("failed to fetch standings: %w", err)
+	}
+
+	successCount, total := 0, 0
+	// Leagues with groups return multiple tables; regular leagues return one
+	for _, table := range standingsResp.League.Standings {
+		for _, row := range table {
+			total++
+
+			team, err := s.teamsRepo.GetByAPIFootballID(ctx, row.Team.ID)
+			if err != nil {
+				log.Printf("Skipping standings row for %s: %v", row.Team.Name, err)
+				continue
+			}
+
+			rank := row.Rank
+			stats := &models.TeamStats{
+				TeamID:         team.ID,
+				LeagueID:       league.ID,
+				Season:         season,
+				Rank:           &rank,
+				Description:    row.Description,
+				MatchesPlayed:  row.All.Played,
+				Wins:           row.All.Win,
+				Draws:          row.All.Draw,
+				Losses:         row.All.Lose,
+				GoalsFor:       row.All.Goals.For,
+				GoalsAgainst:   row.All.Goals.Against,
+				GoalDifference: row.GoalsDiff,
+				Points:         row.Points,
+				HomeWins:       row.Home.Win,
+				HomeDraws:      row.Home.Draw,
+				HomeLosses:     row.Home.Lose,
+				AwayWins:       row.Away.Win,
+				AwayDraws:      row.Away.Draw,
+				AwayLosses:     row.Away.Lose,
+				Form:           row.Form,
+			}
+
+			if row.All.Played > 0 {
+				stats.AvgGoalsScored = float64(row.All.Goals.For) / float64(row.All.Played)
+				stats.AvgGoalsConceded = float64(row.All.Goals.Against) / float64(row.All.Played)
+			}
+
+			if err := s.teamStatsRepo.Upsert(ctx, stats); err != nil {
+				log.Printf("Failed to upsert stats for %s: %v", team.Name, err)
+				continue
+			}
+			successCount++
+		}
+	}
+
+	log.Printf("Successfully synced standings for %d/%d teams", successCount, total)
+	return nil
+}
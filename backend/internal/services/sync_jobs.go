@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// Sync job statuses
+const (
+	SyncJobRunning   = "running"
+	SyncJobCompleted = "completed"
+	SyncJobFailed    = "failed"
+)
+
+// finishedSyncJobTTL is how long a finished job stays available for polling
+const finishedSyncJobTTL = time.Hour
+
+// SyncJob tracks a sync triggered on demand
+type SyncJob struct {
+	ID         string     `json:"id"`
+	Type       string     `json:"type"`
+	Status     string     `json:"status"`
+	Error      string     `json:"error,omitempty"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// SyncJobManager runs sync jobs in the background, allowing at most one
+// running job per type
+type SyncJobManager struct {
+	mu      sync.RWMutex
+	jobs    map[string]*SyncJob
+	running map[string]string // job type -> running job ID
+	nextID  int
+	now     func() time.Time
+
+	// ctx is passed to every job and cancelled by Shutdown
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewSyncJobManager creates a new sync job manager
+func NewSyncJobManager() *SyncJobManager {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &SyncJobManager{
+		jobs:    make(map[string]*SyncJob),
+		running: make(map[string]string),
+		now:     time.Now,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// Start runs fn in a background goroutine. If a job of the same type is
+// already running, that job is returned and started is false.
+func (m *SyncJobManager) Start(jobType string, fn func(ctx context.Context) error) (job SyncJob, started bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if id, ok := m.running[jobType]; ok {
+		return *m.jobs[id], false
+	}
+	m.pruneFinished()
+
+	m.nextID++
+	j := &SyncJob{
+		ID:        fmt.Sprintf("%s-%d", jobType, m.nextID),
+		Type:      jobType,
+		Status:    SyncJobRunning,
+		StartedAt: m.now(),
+	}
+	m.jobs[j.ID] = j
+	m.running[jobType] = j.ID
+
+	m.wg.Add(1)
+	go m.run(j, fn)
+
+	return *j, true
+}
+
+// run executes a job and records its outcome
+func (m *SyncJobManager) run(job *SyncJob, fn func(ctx context.Context) error) {
+	defer m.wg.Done()
+
+	log.Printf("Starting sync job %s", job.ID)
+	err := fn(m.ctx)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	job.FinishedAt = &now
+	if err != nil {
+		job.Status = SyncJobFailed
+		job.Error = err.Error()
+		log.Printf("Sync job %s failed: %v", job.ID, err)
+	} else {
+		job.Status = SyncJobCompleted
+		log.Printf("Sync job %s completed", job.ID)
+	}
+	delete(m.running, job.Type)
+}
+
+// pruneFinished drops jobs that finished more than finishedSyncJobTTL ago.
+// Callers must hold m.mu.
+func (m *SyncJobManager) pruneFinished() {
+	cutoff := m.now().Add(-finishedSyncJobTTL)
+	for id, job := range m.jobs {
+		if job.FinishedAt != nil && job.FinishedAt.Before(cutoff) {
+			delete(m.jobs, id)
+		}
+	}
+}
+
+// Get returns a copy of the job with the given ID
+func (m *SyncJobManager) Get(id string) (SyncJob, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	job, ok := m.jobs[id]
+	if !ok {
+		return SyncJob{}, false
+	}
+	return *job, true
+}
+
+// Shutdown cancels running jobs and waits for them to return, or for ctx to expire
+func (m *SyncJobManager) Shutdown(ctx context.Context) error {
+	m.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("sync jobs still running: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSyncJobManager_Start_PrunesJobsFinishedBeforeTTL(t *testing.T) {
+	m := NewSyncJobManager()
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+
+	old, _ := m.Start("fixtures", func(ctx context.Context) error { return nil })
+	if err := m.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown returned error: %v", err)
+	}
+	if _, ok := m.Get(old.ID); !ok {
+		t.Fatalf("Expected finished job %s to be kept within the TTL", old.ID)
+	}
+
+	now = now.Add(finishedSyncJobTTL + time.Minute)
+	recent, _ := m.Start("odds", func(ctx context.Context) error { return nil })
+
+	if _, ok := m.Get(old.ID); ok {
+		t.Errorf("Expected job %s finished over %v ago to be pruned", old.ID, finishedSyncJobTTL)
+	}
+	if _, ok := m.Get(recent.ID); !ok {
+		t.Errorf("Expected new job %s to be kept", recent.ID)
+	}
+}
+
+func TestSyncJobManager_Start_KeepsRunningJobs(t *testing.T) {
+	m := NewSyncJobManager()
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+
+	release := make(chan struct{})
+	running, _ := m.Start("fixtures", func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+	defer func() {
+		close(release)
+		m.Shutdown(context.Background())
+	}()
+
+	now = now.Add(2 * finishedSyncJobTTL)
+	m.Start("odds", func(ctx context.Context) error { return nil })
+
+	if job, ok := m.Get(running.ID); !ok || job.Status != SyncJobRunning {
+		t.Errorf("Expected running job %s to be kept, got %+v (found %v)", running.ID, job, ok)
+	}
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+	"github.com/dEnchanter/OddsIQ/backend/internal/repository"
+)
+
+// syncStaleAfter is how long after its last success each sync type is reported stale.
+// Results only sync on match days, so allow for the midweek gap; bookmakers sync weekly.
+var syncStaleAfter = map[string]time.Duration{
+	models.SyncTypeFixtures:   36 * time.Hour,
+	models.SyncTypeResults:    96 * time.Hour,
+	models.SyncTypeOdds:       6 * time.Hour,
+	models.SyncTypeBookmakers: 8 * 24 * time.Hour,
+}
+
+// SyncStaleAfter returns the staleness threshold for a sync type; single-market
+// odds syncs (odds_h2h, ...) share the odds threshold
+func SyncStaleAfter(syncType string) time.Duration {
+	if d, ok := syncStaleAfter[syncType]; ok {
+		return d
+	}
+	if strings.HasPrefix(syncType, models.SyncTypeOdds+"_") {
+		return syncStaleAfter[models.SyncTypeOdds]
+	}
+	return 24 * time.Hour
+}
+
+// SyncStatusReport is a sync type's recorded status with its staleness
+type SyncStatusReport struct {
+	models.SyncStatus
+	StalenessSeconds  *int64 `json:"staleness_seconds"` // Time since last success, nil if never succeeded
+	StaleAfterSeconds int64  `json:"stale_after_seconds"`
+	Stale             bool   `json:"stale"`
+}
+
+// BuildSyncStatusReports adds staleness to recorded statuses. Core sync types that
+// have never run are included and reported stale.
+func BuildSyncStatusReports(statuses []models.SyncStatus, now time.Time) []SyncStatusReport {
+	recorded := make(map[string]bool, len(statuses))
+	for _, status := range statuses {
+		recorded[status.SyncType] = true
+	}
+	for _, syncType := range []string{models.SyncTypeFixtures, models.SyncTypeResults, models.SyncTypeOdds} {
+		if !recorded[syncType] {
+			statuses = append(statuses, models.SyncStatus{SyncType: syncType})
+		}
+	}
+
+	reports := make([]SyncStatusReport, 0, len(statuses))
+	for _, status := range statuses {
+		staleAfter := SyncStaleAfter(status.SyncType)
+		report := SyncStatusReport{
+			SyncStatus:        status,
+			StaleAfterSeconds: int64(staleAfter.Seconds()),
+			Stale:             true,
+		}
+		if status.LastSuccessAt != nil {
+			age := now.Sub(*status.LastSuccessAt)
+			seconds := int64(age.Seconds())
+			report.StalenessSeconds = &seconds
+			report.Stale = age > staleAfter
+		}
+		reports = append(reports, report)
+	}
+
+	return reports
+}
+
+// recordSyncRun stores the outcome of a sync run. A nil repository skips recording,
+// and recording failures are logged rather than failing the sync.
+func recordSyncRun(ctx context.Context, repo *repository.SyncStatusRepository, syncType string, rows int, syncErr error) {
+	if repo == nil {
+		return
+	}
+
+	var err error
+	if syncErr != nil {
+		err = repo.RecordFailure(ctx, syncType, syncErr)
+	} else {
+		err = repo.RecordSuccess(ctx, syncType, rows)
+	}
+	if err != nil {
+		log.Printf("Failed to record %s sync status: %v", syncType, err)
+	}
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"time"
+
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+)
+
+// Team results from the team's own perspective
+const (
+	TeamResultWin  = "W"
+	TeamResultDraw = "D"
+	TeamResultLoss = "L"
+)
+
+// FormMatch is one completed fixture seen from a team's side
+type FormMatch struct {
+	FixtureID    int       `json:"fixture_id"`
+	MatchDate    time.Time `json:"match_date"`
+	Venue        string    `json:"venue"` // "home" or "away"
+	OpponentID   int       `json:"opponent_id"`
+	OpponentName string    `json:"opponent_name"`
+	GoalsFor     int       `json:"goals_for"`
+	GoalsAgainst int       `json:"goals_against"`
+	Result       string    `json:"result"` // W, D or L
+}
+
+// TeamForm summarises a team's recent results
+type TeamForm struct {
+	TeamID       int         `json:"team_id"`
+	Matches      []FormMatch `json:"matches"` // Newest first
+	Form         string      `json:"form"`    // Results oldest first, e.g. "WWDLL"
+	Wins         int         `json:"wins"`
+	Draws        int         `json:"draws"`
+	Losses       int         `json:"losses"`
+	GoalsFor     int         `json:"goals_for"`
+	GoalsAgainst int         `json:"goals_against"`
+}
+
+// TeamResult returns a fixture's result from a team's perspective, flipping the score
+// when the team played away. ok is false if the fixture has no score or the team
+// didn't play in it.
+func TeamResult(fixture *models.Fixture, teamID int) (result string, scored, conceded int, ok bool) {
+	if fixture.HomeScore == nil || fixture.AwayScore == nil {
+		return "", 0, 0, false
+	}
+
+	switch teamID {
+	case fixture.HomeTeamID:
+		scored, conceded = *fixture.HomeScore, *fixture.AwayScore
+	case fixture.AwayTeamID:
+		scored, conceded = *fixture.AwayScore, *fixture.HomeScore
+	default:
+		return "", 0, 0, false
+	}
+
+	switch {
+	case scored > conceded:
+		result = TeamResultWin
+	case scored == conceded:
+		result = TeamResultDraw
+	default:
+		result = TeamResultLoss
+	}
+
+	return result, scored, conceded, true
+}
+
+// CalculateTeamForm builds a team's form from its recent fixtures (newest first), naming
+// opponents from teams. Fixtures without a score are skipped.
+func CalculateTeamForm(teamID int, fixtures []models.Fixture, teams map[int]models.Team) *TeamForm {
+	form := &TeamForm{TeamID: teamID, Matches: []FormMatch{}}
+
+	var results []byte
+	for i := range fixtures {
+		fixture := &fixtures[i]
+		result, scored, conceded, ok := TeamResult(fixture, teamID)
+		if !ok {
+			continue
+		}
+
+		match := FormMatch{
+			FixtureID:    fixture.ID,
+			MatchDate:    fixture.MatchDate,
+			Venue:        "home",
+			OpponentID:   fixture.AwayTeamID,
+			GoalsFor:     scored,
+			GoalsAgainst: conceded,
+			Result:       result,
+		}
+		if fixture.AwayTeamID == teamID {
+			match.Venue = "away"
+			match.OpponentID = fixture.HomeTeamID
+		}
+		match.OpponentName = teams[match.OpponentID].Name
+		form.Matches = append(form.Matches, match)
+
+		form.GoalsFor += scored
+		form.GoalsAgainst += conceded
+		switch result {
+		case TeamResultWin:
+			form.Wins++
+		case TeamResultDraw:
+			form.Draws++
+		default:
+			form.Losses++
+		}
+		results = append(results, result[0])
+	}
+
+	// Matches are newest first; the form string reads oldest first like TeamStats.Form
+	for i, j := 0, len(results)-1; i < j; i, j = i+1, j-1 {
+		results[i], results[j] = results[j], results[i]
+	}
+	form.Form = string(results)
+
+	return form
+}
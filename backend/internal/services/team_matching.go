@@ -0,0 +1,186 @@
+package services
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DefaultTeamMatchThreshold is the minimum similarity for auto-matching team names
+const DefaultTeamMatchThreshold = 0.85
+
+// teamNameAbbreviations expands common bookmaker abbreviations to full tokens
+var teamNameAbbreviations = map[string]string{
+	"man":    "manchester",
+	"utd":    "united",
+	"spurs":  "tottenham",
+	"wolves": "wolverhampton",
+	"nottm":  "nottingham",
+	"sheff":  "sheffield",
+	"weds":   "wednesday",
+	"qpr":    "queens park rangers",
+}
+
+// teamNameNoise are tokens ignored when comparing team names
+var teamNameNoise = map[string]bool{
+	"fc": true, "afc": true, "cf": true, "sc": true, "ssc": true,
+	"ac": true, "as": true, "the": true, "and": true, "de": true,
+}
+
+// teamNameGeneric are tokens too common to identify a club on their own
+var teamNameGeneric = map[string]bool{
+	"united": true, "city": true, "town": true, "athletic": true, "rovers": true,
+	"wanderers": true, "albion": true, "real": true, "sporting": true, "inter": true,
+	"county": true, "hotspur": true, "forest": true, "rangers": true,
+}
+
+// accentFolder strips common accents and apostrophes so "München"/"Munchen"
+// and "Nott'm"/"Nottm" compare equal
+var accentFolder = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ä", "a", "ã", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "ö", "o", "õ", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ñ", "n", "ç", "c",
+	"'", "", "’", "",
+)
+
+// TeamNameSimilarity scores how likely two team names refer to the same club,
+// from 0 (unrelated) to 1 (same name after normalization). It takes the best of
+// a normalized Levenshtein ratio and a token-set ratio, so word order, club
+// suffixes and common abbreviations ("Man Utd", "Spurs") don't hurt the score.
+func TeamNameSimilarity(a, b string) float64 {
+	tokensA := teamNameTokens(a)
+	tokensB := teamNameTokens(b)
+	if len(tokensA) == 0 || len(tokensB) == 0 {
+		return 0
+	}
+
+	joinedA := strings.Join(tokensA, " ")
+	joinedB := strings.Join(tokensB, " ")
+	if joinedA == joinedB {
+		return 1
+	}
+
+	score := levenshteinRatio(joinedA, joinedB)
+	if tokenScore := tokenSetRatio(tokensA, tokensB); tokenScore > score {
+		score = tokenScore
+	}
+
+	return score
+}
+
+// teamNameTokens lowercases, strips punctuation and accents, expands
+// abbreviations and drops noise tokens
+func teamNameTokens(name string) []string {
+	name = accentFolder.Replace(strings.ToLower(name))
+	fields := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var tokens []string
+	for _, field := range fields {
+		if expanded, ok := teamNameAbbreviations[field]; ok {
+			field = expanded
+		}
+		for _, token := range strings.Fields(field) {
+			if !teamNameNoise[token] {
+				tokens = append(tokens, token)
+			}
+		}
+	}
+
+	return tokens
+}
+
+// tokenSetRatio compares the shared tokens of two names against each name's
+// full token set. A name whose tokens are a subset of the other's scores 1,
+// unless the shared tokens are all generic (e.g. "United").
+func tokenSetRatio(tokensA, tokensB []string) float64 {
+	setA := make(map[string]bool, len(tokensA))
+	for _, t := range tokensA {
+		setA[t] = true
+	}
+	setB := make(map[string]bool, len(tokensB))
+	for _, t := range tokensB {
+		setB[t] = true
+	}
+
+	var common, onlyA, onlyB []string
+	for t := range setA {
+		if setB[t] {
+			common = append(common, t)
+		} else {
+			onlyA = append(onlyA, t)
+		}
+	}
+	for t := range setB {
+		if !setA[t] {
+			onlyB = append(onlyB, t)
+		}
+	}
+
+	if len(common) == 0 {
+		return 0
+	}
+
+	distinctive := false
+	for _, t := range common {
+		if !teamNameGeneric[t] {
+			distinctive = true
+			break
+		}
+	}
+	if !distinctive {
+		return 0
+	}
+
+	sort.Strings(common)
+	sort.Strings(onlyA)
+	sort.Strings(onlyB)
+
+	base := strings.Join(common, " ")
+	if len(onlyA) == 0 || len(onlyB) == 0 {
+		return 1
+	}
+
+	withA := base + " " + strings.Join(onlyA, " ")
+	withB := base + " " + strings.Join(onlyB, " ")
+
+	return max(levenshteinRatio(base, withA), levenshteinRatio(base, withB), levenshteinRatio(withA, withB))
+}
+
+// levenshteinRatio returns 1 - editDistance/maxLength
+func levenshteinRatio(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	maxLen := max(len(ra), len(rb))
+	if maxLen == 0 {
+		return 1
+	}
+
+	return 1 - float64(levenshtein(ra, rb))/float64(maxLen)
+}
+
+// levenshtein returns the edit distance between two rune slices
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
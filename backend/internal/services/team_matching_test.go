@@ -0,0 +1,78 @@
+package services
+
+import "testing"
+
+func TestTeamNameSimilarity_AbbreviatedNames_MatchFullNames(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{"Man Utd", "Manchester United"},
+		{"Spurs", "Tottenham"},
+		{"Spurs", "Tottenham Hotspur"},
+		{"Wolves", "Wolverhampton Wanderers"},
+		{"Nott'm Forest", "Nottingham Forest"},
+		{"Bayern München", "FC Bayern Munchen"},
+	}
+
+	for _, tt := range tests {
+		if got := TeamNameSimilarity(tt.a, tt.b); got < DefaultTeamMatchThreshold {
+			t.Errorf("%q vs %q: expected at least %v, got %v", tt.a, tt.b, DefaultTeamMatchThreshold, got)
+		}
+	}
+}
+
+func TestTeamNameSimilarity_DifferentClubsSharingTokens_BelowThreshold(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{"Manchester United", "Manchester City"},
+		{"Sheffield Utd", "Sheffield Wednesday"},
+		{"United", "Manchester United"},
+		{"Arsenal", "Chelsea"},
+	}
+
+	for _, tt := range tests {
+		if got := TeamNameSimilarity(tt.a, tt.b); got >= DefaultTeamMatchThreshold {
+			t.Errorf("%q vs %q: expected below %v, got %v", tt.a, tt.b, DefaultTeamMatchThreshold, got)
+		}
+	}
+}
+
+func TestTeamNameSimilarity_IsSymmetric(t *testing.T) {
+	pairs := [][2]string{
+		{"Man Utd", "Manchester United"},
+		{"Manchester United", "Manchester City"},
+		{"Sheffield", "Sheffield Wednesday"},
+	}
+
+	for _, p := range pairs {
+		if ab, ba := TeamNameSimilarity(p[0], p[1]), TeamNameSimilarity(p[1], p[0]); ab != ba {
+			t.Errorf("%q vs %q: expected symmetric scores, got %v and %v", p[0], p[1], ab, ba)
+		}
+	}
+}
+
+// A name whose tokens are a subset of another's scores 1, so a bare city name
+// matches every club from that city. Odds sync relies on both teams having to
+// match a fixture kicking off within 12 hours of the event to disambiguate.
+func TestTokenSetRatio_SubsetOfBothClubs_ScoresOneForEach(t *testing.T) {
+	for _, club := range []string{"Sheffield United", "Sheffield Wednesday"} {
+		if got := TeamNameSimilarity("Sheffield", club); got != 1 {
+			t.Errorf("\"Sheffield\" vs %q: expected 1, got %v", club, got)
+		}
+	}
+}
+
+func TestTokenSetRatio_OnlyGenericTokensShared_ScoresZero(t *testing.T) {
+	got := tokenSetRatio(teamNameTokens("United"), teamNameTokens("Manchester United"))
+
+	if got != 0 {
+		t.Errorf("Expected 0, got %v", got)
+	}
+}
+
+func TestTeamNameSimilarity_EmptyName_ScoresZero(t *testing.T) {
+	if got := TeamNameSimilarity("FC", "Arsenal"); got != 0 {
+		t.Errorf("Expected 0 for a name of only noise tokens, got %v", got)
+	}
+}
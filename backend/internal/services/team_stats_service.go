@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+	"github.com/dEnchanter/OddsIQ/backend/internal/repository"
+)
+
+// formLength is the number of recent results included in the form string
+const formLength = 5
+
+// TeamStatsService derives team statistics from stored fixtures
+type TeamStatsService struct {
+	fixturesRepo  *repository.FixturesRepository
+	teamStatsRepo *repository.TeamStatsRepository
+}
+
+// NewTeamStatsService creates a new team stats service
+func NewTeamStatsService(
+	fixturesRepo *repository.FixturesRepository,
+	teamStatsRepo *repository.TeamStatsRepository,
+) *TeamStatsService {
+	return &TeamStatsService{
+		fixturesRepo:  fixturesRepo,
+		teamStatsRepo: teamStatsRepo,
+	}
+}
+
+// ComputeTeamStats calculates a team's season stats in a league (internal ID) from
+// completed fixtures and upserts them. Fixtures without scores are skipped. Rank is
+// left unset; it only comes from synced standings.
+func (s *TeamStatsService) ComputeTeamStats(ctx context.Context, teamID, leagueID, season int) (*models.TeamStats, error) {
+	fixtures, err := s.fixturesRepo.GetCompletedByTeamAndSeason(ctx, teamID, leagueID, season)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &models.TeamStats{
+		TeamID:   teamID,
+		LeagueID: leagueID,
+		Season:   season,
+	}
+
+	var results []byte
+	for _, fixture := range fixtures {
+		result, scored, conceded, ok := TeamResult(&fixture, teamID)
+		if !ok {
+			continue
+		}
+		isHome := fixture.HomeTeamID == teamID
+
+		stats.MatchesPlayed++
+		stats.GoalsFor += scored
+		stats.GoalsAgainst += conceded
+
+		if conceded == 0 {
+			stats.CleanSheets++
+		}
+		if scored == 0 {
+			stats.FailedToScore++
+		}
+
+		switch result {
+		case TeamResultWin:
+			stats.Wins++
+			if isHome {
+				stats.HomeWins++
+			} else {
+				stats.AwayWins++
+			}
+			results = append(results, 'W')
+		case TeamResultDraw:
+			stats.Draws++
+			if isHome {
+				stats.HomeDraws++
+			} else {
+				stats.AwayDraws++
+			}
+			results = append(results, 'D')
+		default:
+			stats.Losses++
+			if isHome {
+				stats.HomeLosses++
+			} else {
+				stats.AwayLosses++
+			}
+			results = append(results, 'L')
+		}
+	}
+
+	stats.GoalDifference = stats.GoalsFor - stats.GoalsAgainst
+	stats.Points = stats.Wins*3 + stats.Draws
+
+	// Form is the last five results, oldest first (e.g. "WWDLW")
+	if len(results) > formLength {
+		results = results[len(results)-formLength:]
+	}
+	stats.Form = string(results)
+
+	if stats.MatchesPlayed > 0 {
+		stats.AvgGoalsScored = float64(stats.GoalsFor) / float64(stats.MatchesPlayed)
+		stats.AvgGoalsConceded = float64(stats.GoalsAgainst) / float64(stats.MatchesPlayed)
+	}
+
+	if err := s.teamStatsRepo.Upsert(ctx, stats); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// ComputeSeasonStats computes stats for every team with fixtures in a league
+// (internal ID) and season
+func (s *TeamStatsService) ComputeSeasonStats(ctx context.Context, leagueID, season int) error {
+	fixtures, err := s.fixturesRepo.GetBySeason(ctx, season, leagueID)
+	if err != nil {
+		return fmt.Errorf("failed to get season fixtures: %w", err)
+	}
+
+	teamIDs := make(map[int]bool)
+	for _, fixture := range fixtures {
+		teamIDs[fixture.HomeTeamID] = true
+		teamIDs[fixture.AwayTeamID] = true
+	}
+
+	successCount := 0
+	for teamID := range teamIDs {
+		if _, err := s.ComputeTeamStats(ctx, teamID, leagueID, season); err != nil {
+			log.Printf("Failed to compute stats for team %d: %v", teamID, err)
+			continue
+		}
+		successCount++
+	}
+
+	log.Printf("Computed stats for %d/%d teams in season %d", successCount, len(teamIDs), season)
+	return nil
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"sort"
+
+	"github.com/dEnchanter/OddsIQ/backend/internal/logging"
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+	"github.com/dEnchanter/OddsIQ/backend/internal/repository"
+)
+
+// ValueAlertService flags outcomes where the model and the market diverge sharply
+type ValueAlertService struct {
+	bettingService *BettingService
+	alertsRepo     *repository.ValueAlertsRepository
+}
+
+// NewValueAlertService creates a new value alert service
+func NewValueAlertService(bettingService *BettingService, alertsRepo *repository.ValueAlertsRepository) *ValueAlertService {
+	return &ValueAlertService{
+		bettingService: bettingService,
+		alertsRepo:     alertsRepo,
+	}
+}
+
+// ValueAlertResult is an alert raised by a scan, with its fixture
+type ValueAlertResult struct {
+	models.ValueAlert
+	Fixture     models.Fixture `json:"fixture"`
+	Description string         `json:"description"`
+	New         bool           `json:"new"` // Not raised by an earlier recorded scan
+}
+
+// ValueAlertScan is the outcome of scanning upcoming fixtures for value alerts
+type ValueAlertScan struct {
+	Alerts    []ValueAlertResult `json:"alerts"`
+	MinEdge   float64            `json:"min_edge"`
+	Evaluated int                `json:"evaluated"` // Fixtures evaluated successfully
+	Skipped   int                `json:"skipped"`   // Fixtures that could not be evaluated
+}
+
+// Scan evaluates upcoming fixtures in the window and raises an alert for every outcome
+// whose model probability exceeds the market no-vig probability by at least minEdge.
+// Outcomes without bookmaker odds have no market probability and are never alerted.
+// Nothing is stored; an alert is new when no recorded scan has raised it before.
+func (s *ValueAlertService) Scan(ctx context.Context, minEdge float64, window FixtureWindow) (*ValueAlertScan, error) {
+	scan, err := s.scan(ctx, minEdge, window)
+	if err != nil {
+		return nil, err
+	}
+
+	fixtureIDs := make([]int, 0, len(scan.Alerts))
+	for _, alert := range scan.Alerts {
+		fixtureIDs = append(fixtureIDs, alert.FixtureID)
+	}
+
+	recorded, err := s.alertsRepo.GetByFixtureIDs(ctx, fixtureIDs)
+	if err != nil {
+		logging.Printf(ctx, "Warning: Failed to load recorded value alerts: %v", err)
+		return scan, nil
+	}
+
+	seen := make(map[valueAlertKey]bool, len(recorded))
+	for _, alert := range recorded {
+		seen[valueAlertKey{alert.FixtureID, alert.Market, alert.Outcome}] = true
+	}
+	for i := range scan.Alerts {
+		alert := &scan.Alerts[i]
+		alert.New = !seen[valueAlertKey{alert.FixtureID, alert.Market, alert.Outcome}]
+	}
+
+	return scan, nil
+}
+
+// Record runs a scan like Scan and records its alerts so repeat sightings of an outcome
+// can be told from new ones; a failure to record is logged and the alert still returned
+func (s *ValueAlertService) Record(ctx context.Context, minEdge float64, window FixtureWindow) (*ValueAlertScan, error) {
+	scan, err := s.scan(ctx, minEdge, window)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range scan.Alerts {
+		alert := &scan.Alerts[i]
+		if err := s.alertsRepo.Record(ctx, &alert.ValueAlert); err != nil {
+			logging.Printf(ctx, "Warning: Failed to record value alert for fixture %d: %v", alert.FixtureID, err)
+		} else {
+			alert.New = alert.FirstSeenAt.Equal(alert.LastSeenAt)
+		}
+	}
+
+	return scan, nil
+}
+
+// valueAlertKey identifies the fixture outcome an alert is raised for
+type valueAlertKey struct {
+	fixtureID       int
+	market, outcome string
+}
+
+// scan evaluates upcoming fixtures and returns their alerts, largest edge first
+func (s *ValueAlertService) scan(ctx context.Context, minEdge float64, window FixtureWindow) (*ValueAlertScan, error) {
+	result, err := s.bettingService.EvaluateUpcomingFixtures(ctx, s.bettingService.config.InitialBankroll, window, OddsRange{})
+	if err != nil {
+		return nil, err
+	}
+
+	scan := &ValueAlertScan{
+		Alerts:    []ValueAlertResult{},
+		MinEdge:   minEdge,
+		Evaluated: result.Evaluated,
+		Skipped:   result.Skipped,
+	}
+
+	for _, pick := range result.All {
+		for _, outcome := range pick.AllOutcomes {
+			if outcome.MarketProbability <= 0 || outcome.Edge < minEdge {
+				continue
+			}
+
+			alert := ValueAlertResult{
+				ValueAlert: models.ValueAlert{
+					FixtureID:         pick.Fixture.ID,
+					Market:            string(outcome.Market),
+					Outcome:           outcome.Outcome,
+					ModelProbability:  outcome.Probability,
+					MarketProbability: outcome.MarketProbability,
+					Edge:              outcome.Edge,
+					BestOdds:          outcome.BestOdds,
+					Bookmaker:         outcome.Bookmaker,
+				},
+				Fixture:     pick.Fixture,
+				Description: outcome.Description,
+			}
+
+			scan.Alerts = append(scan.Alerts, alert)
+		}
+	}
+
+	// Largest divergence first
+	sort.Slice(scan.Alerts, func(i, j int) bool {
+		return scan.Alerts[i].Edge > scan.Alerts[j].Edge
+	})
+
+	return scan, nil
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/dEnchanter/OddsIQ/backend/internal/logging"
+	"github.com/dEnchanter/OddsIQ/backend/internal/models"
+	"github.com/dEnchanter/OddsIQ/backend/internal/repository"
+)
+
+// WatchlistService re-evaluates saved outcomes against current odds and predictions
+type WatchlistService struct {
+	bettingService *BettingService
+	watchlistRepo  *repository.WatchlistRepository
+}
+
+// NewWatchlistService creates a new watchlist service
+func NewWatchlistService(bettingService *BettingService, watchlistRepo *repository.WatchlistRepository) *WatchlistService {
+	return &WatchlistService{
+		bettingService: bettingService,
+		watchlistRepo:  watchlistRepo,
+	}
+}
+
+// WatchlistEntry is a saved outcome with its current evaluation
+type WatchlistEntry struct {
+	models.WatchlistItem
+	Fixture     *models.Fixture `json:"fixture,omitempty"`
+	Description string          `json:"description"`
+	Started     bool            `json:"started"`              // The fixture has kicked off
+	Available   bool            `json:"available"`            // Both odds and a model probability were found
+	Issue       string          `json:"issue,omitempty"`      // Why the outcome couldn't be re-evaluated
+	Evaluation  *BetOutcome     `json:"evaluation,omitempty"` // Current odds, probability, EV and stake
+	ValueBet    bool            `json:"value_bet"`            // Still a value bet under the current EV, stake and odds limits
+}
+
+// Add saves an outcome to the watchlist, returning the existing item if it was already saved
+func (s *WatchlistService) Add(ctx context.Context, fixtureID int, market MarketType, outcome string) (*models.WatchlistItem, error) {
+	item := &models.WatchlistItem{FixtureID: fixtureID, Market: string(market), Outcome: outcome}
+	if err := s.watchlistRepo.Add(ctx, item); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// List returns every saved outcome re-evaluated with the current best odds and model
+// probability. Fixtures that have kicked off are flagged and not re-evaluated.
+func (s *WatchlistService) List(ctx context.Context, bankroll float64) ([]WatchlistEntry, error) {
+	items, err := s.watchlistRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	entries := make([]WatchlistEntry, len(items))
+	evaluations := make(map[int]*fixtureEvaluation)
+	for i, item := range items {
+		market := MarketType(item.Market)
+		entry := WatchlistEntry{
+			WatchlistItem: item,
+			Description:   GetOutcomeDescription(market, item.Outcome),
+		}
+
+		eval, ok := evaluations[item.FixtureID]
+		if !ok {
+			eval = s.evaluateFixture(ctx, item.FixtureID, bankroll, now)
+			evaluations[item.FixtureID] = eval
+		}
+		if eval.fixture != nil {
+			entry.Fixture = eval.fixture
+			entry.Started = HasKickedOff(eval.fixture, now)
+		}
+
+		switch outcome := findOutcome(eval.pick, market, item.Outcome); {
+		case entry.Started:
+			entry.Issue = "fixture has kicked off"
+		case eval.issue != "":
+			entry.Issue = eval.issue
+		case outcome == nil:
+			entry.Issue = "no prediction available for this outcome"
+		case outcome.Bookmaker == "synthetic":
+			entry.Evaluation = outcome
+			entry.Issue = "no odds available"
+		default:
+			entry.Evaluation = outcome
+			entry.Available = true
+			for _, value := range eval.pick.ValueOutcomes {
+				if value.Market == market && value.Outcome == item.Outcome {
+					entry.ValueBet = true
+				}
+			}
+		}
+
+		entries[i] = entry
+	}
+
+	return entries, nil
+}
+
+// Remove deletes a saved outcome. found is false if no item has the ID.
+func (s *WatchlistService) Remove(ctx context.Context, id int) (bool, error) {
+	return s.watchlistRepo.Delete(ctx, id)
+}
+
+// evaluateFixture loads a fixture and, unless it has kicked off, evaluates its markets
+func (s *WatchlistService) evaluateFixture(ctx context.Context, fixtureID int, bankroll float64, now time.Time) *fixtureEvaluation {
+	fixture, err := s.bettingService.fixturesRepo.GetByID(ctx, fixtureID)
+	if err != nil {
+		return &fixtureEvaluation{issue: "fixture not found"}
+	}
+	if HasKickedOff(fixture, now) {
+		return &fixtureEvaluation{fixture: fixture}
+	}
+
+	pick, err := s.bettingService.EvaluateFixture(ctx, fixture, bankroll, false, nil)
+	if err != nil {
+		logging.Printf(ctx, "Failed to evaluate fixture %d for watchlist: %v", fixtureID, err)
+		return &fixtureEvaluation{fixture: fixture, issue: "no prediction available"}
+	}
+
+	return &fixtureEvaluation{fixture: fixture, pick: pick}
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Webhook types
+const (
+	WebhookTypeDiscord = "discord"
+	WebhookTypeSlack   = "slack"
+)
+
+const (
+	webhookAttempts      = 3                // Tries per message, including the first
+	webhookRetryDelay    = 2 * time.Second  // Delay before the first retry, doubled after each
+	webhookMaxRetryAfter = 30 * time.Second // Longest Retry-After honoured on 429
+	discordMessageLimit  = 2000             // Discord rejects longer message content
+)
+
+// webhookSink posts the weekly digest to a Discord or Slack incoming webhook
+type webhookSink struct {
+	url        string
+	kind       string
+	httpClient *http.Client
+}
+
+// newWebhookSink creates a webhook sink for a Discord or Slack webhook URL
+func newWebhookSink(url, kind string) *webhookSink {
+	return &webhookSink{
+		url:        url,
+		kind:       kind,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Name identifies the sink in logs
+func (w *webhookSink) Name() string {
+	return w.kind + " webhook"
+}
+
+// Send formats the digest as a chat message and posts it
+func (w *webhookSink) Send(ctx context.Context, digest *WeeklyDigest) error {
+	message := FormatDigestMessage(digest, w.kind)
+
+	var payload interface{}
+	if w.kind == WebhookTypeSlack {
+		payload = map[string]string{"text": message}
+	} else {
+		if len(message) > discordMessageLimit {
+			cut := discordMessageLimit - 3
+			for cut > 0 && !utf8.RuneStart(message[cut]) {
+				cut-- // Don't split a multi-byte character
+			}
+			message = message[:cut] + "..."
+		}
+		payload = map[string]string{"content": message}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook message: %w", err)
+	}
+
+	return w.post(ctx, body)
+}
+
+// post sends the body, retrying network errors, 429 and 5xx responses with backoff
+func (w *webhookSink) post(ctx context.Context, body []byte) error {
+	delay := webhookRetryDelay
+
+	var lastErr error
+	for attempt := 1; attempt <= webhookAttempts; attempt++ {
+		retryAfter, err := w.postOnce(ctx, body)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if retryAfter < 0 || attempt == webhookAttempts {
+			break // Not retryable, or out of attempts
+		}
+
+		wait := delay
+		if retryAfter > 0 {
+			wait = retryAfter
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+		delay *= 2
+	}
+
+	return lastErr
+}
+
+// postOnce makes one delivery attempt. On failure it returns how long to wait before
+// retrying (0 for the default backoff), or a negative duration if retrying won't help.
+func (w *webhookSink) postOnce(ctx context.Context, body []byte) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", w.url, bytes.NewReader(body))
+	if err != nil {
+		return -1, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 300 {
+		return 0, nil
+	}
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	err = fmt.Errorf("webhook returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		if seconds, convErr := strconv.Atoi(resp.Header.Get("Retry-After")); convErr == nil && seconds > 0 {
+			return min(time.Duration(seconds)*time.Second, webhookMaxRetryAfter), err
+		}
+		return 0, err
+	case resp.StatusCode >= 500:
+		return 0, err
+	default:
+		return -1, err
+	}
+}
+
+// FormatDigestMessage renders the weekly digest as a chat message, with headings in
+// the webhook type's bold markup (** for Discord, * for Slack)
+func FormatDigestMessage(digest *WeeklyDigest, kind string) string {
+	bold := "**"
+	if kind == WebhookTypeSlack {
+		bold = "*"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%sOddsIQ weekly picks%s (%s - %s)\n",
+		bold, bold, digest.Window.From.Format("Mon 2 Jan"), digest.Window.To.Format("Mon 2 Jan"))
+
+	if len(digest.Picks) == 0 {
+		b.WriteString("\nNo value picks this week.\n")
+	}
+	for i, pick := range digest.Picks {
+		fmt.Fprintf(&b, "\n%d. %s (%s)\n", i+1, pick.Match, pick.Kickoff.UTC().Format("Mon 2 Jan 15:04 MST"))
+		fmt.Fprintf(&b, "    %s @ %.2f (%s) | EV %+.1f%% | Stake %.2f %s\n",
+			pick.Selection, pick.Odds, pick.Bookmaker, pick.EV*100, pick.Stake, digest.Currency)
+	}
+
+	if len(digest.Accumulators) > 0 {
+		fmt.Fprintf(&b, "\n%sAccumulators%s\n", bold, bold)
+	}
+	for i, acc := range digest.Accumulators {
+		fmt.Fprintf(&b, "\n%d. %d legs @ %.2f | EV %+.1f%% | Stake %.2f %s\n",
+			i+1, len(acc.Legs), acc.CombinedOdds, acc.EV*100, acc.Stake, digest.Currency)
+		for _, leg := range acc.Legs {
+			fmt.Fprintf(&b, "    - %s: %s @ %.2f\n", leg.Match, leg.Selection, leg.Odds)
+		}
+	}
+
+	return b.String()
+}
@@ -1,41 +1,185 @@
 package apifootball
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
+	"strconv"
+	"sync"
 	"time"
 )
 
 const (
 	BaseURL        = "https://v3.football.api-sports.io"
 	PremierLeagueID = 39 // England Premier League
+	LaLigaID        = 140 // Spain La Liga
+	BundesligaID    = 78  // Germany Bundesliga
+	SerieAID        = 135 // Italy Serie A
+	Ligue1ID        = 61  // France Ligue 1
+
+	maxRateLimitRetries = 3
 )
 
+// Quota holds the remaining request quota reported by API-Football response headers.
+// A value of -1 means the header has not been seen yet.
+type Quota struct {
+	DailyLimit      int       `json:"daily_limit"`
+	DailyRemaining  int       `json:"daily_remaining"`
+	MinuteLimit     int       `json:"minute_limit"`
+	MinuteRemaining int       `json:"minute_remaining"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
 // Client represents API-Football client
 type Client struct {
 	apiKey     string
 	httpClient *http.Client
 	baseURL    string
+
+	// Throttling: minimum interval between requests (0 = unlimited)
+	minInterval time.Duration
+	lastRequest time.Time
+	throttleMu  sync.Mutex
+
+	quota   Quota
+	quotaMu sync.RWMutex
 }
 
-// NewClient creates a new API-Football client
-func NewClient(apiKey string) *Client {
+// NewClient creates a new API-Football client.
+// requestsPerMinute throttles outgoing requests; pass 0 to disable throttling.
+func NewClient(apiKey string, requestsPerMinute int) *Client {
+	var minInterval time.Duration
+	if requestsPerMinute > 0 {
+		minInterval = time.Minute / time.Duration(requestsPerMinute)
+	}
+
 	return &Client{
 		apiKey: apiKey,
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
-		baseURL: BaseURL,
+		baseURL:     BaseURL,
+		minInterval: minInterval,
+		quota: Quota{
+			DailyLimit:      -1,
+			DailyRemaining:  -1,
+			MinuteLimit:     -1,
+			MinuteRemaining: -1,
+		},
+	}
+}
+
+// RemainingQuota returns the latest quota reported by the API
+func (c *Client) RemainingQuota() Quota {
+	c.quotaMu.RLock()
+	defer c.quotaMu.RUnlock()
+	return c.quota
+}
+
+// throttle blocks until the next request is allowed by the per-minute limit,
+// or until ctx is cancelled
+func (c *Client) throttle(ctx context.Context) error {
+	if c.minInterval <= 0 {
+		return nil
+	}
+
+	c.throttleMu.Lock()
+	defer c.throttleMu.Unlock()
+
+	if wait := c.minInterval - time.Since(c.lastRequest); wait > 0 {
+		if err := sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+	c.lastRequest = time.Now()
+	return nil
+}
+
+// sleep waits for d, returning early with the context's error if ctx is cancelled
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// updateQuota parses the rate limit headers from a response
+func (c *Client) updateQuota(header http.Header) {
+	c.quotaMu.Lock()
+	defer c.quotaMu.Unlock()
+
+	parse := func(name string, target *int) {
+		if value := header.Get(name); value != "" {
+			if n, err := strconv.Atoi(value); err == nil {
+				*target = n
+			}
+		}
+	}
+
+	parse("x-ratelimit-requests-limit", &c.quota.DailyLimit)
+	parse("x-ratelimit-requests-remaining", &c.quota.DailyRemaining)
+	parse("X-RateLimit-Limit", &c.quota.MinuteLimit)
+	parse("X-RateLimit-Remaining", &c.quota.MinuteRemaining)
+	c.quota.UpdatedAt = time.Now()
+}
+
+// retryAfter returns how long to wait after a 429 response.
+// Uses the Retry-After header when present, otherwise waits for the next minute window.
+func retryAfter(header http.Header) time.Duration {
+	if value := header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
 	}
+
+	now := time.Now()
+	return now.Truncate(time.Minute).Add(time.Minute).Sub(now)
 }
 
 // doRequest performs HTTP request with API key header
-func (c *Client) doRequest(endpoint string, params map[string]string) ([]byte, error) {
-	req, err := http.NewRequest("GET", c.baseURL+endpoint, nil)
+func (c *Client) doRequest(ctx context.Context, endpoint string, params map[string]string) ([]byte, error) {
+	for attempt := 0; ; attempt++ {
+		body, status, header, err := c.execute(ctx, endpoint, params)
+		if err != nil {
+			return nil, err
+		}
+
+		if status == http.StatusTooManyRequests && attempt < maxRateLimitRetries {
+			wait := retryAfter(header)
+			log.Printf("API-Football rate limit hit, retrying in %s", wait.Round(time.Second))
+			if err := sleep(ctx, wait); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		// Check status code
+		if status != http.StatusOK {
+			return nil, fmt.Errorf("API returned status %d: %s", status, string(body))
+		}
+
+		// API-Football reports errors in the body with a 200 status
+		if err := checkResponseErrors(body); err != nil {
+			return nil, err
+		}
+
+		return body, nil
+	}
+}
+
+// execute performs a single throttled HTTP request and records quota headers
+func (c *Client) execute(ctx context.Context, endpoint string, params map[string]string) ([]byte, int, http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+endpoint, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, 0, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Add API key header
@@ -48,25 +192,26 @@ func (c *Client) doRequest(endpoint string, params map[string]string) ([]byte, e
 	}
 	req.URL.RawQuery = q.Encode()
 
+	if err := c.throttle(ctx); err != nil {
+		return nil, 0, nil, err
+	}
+
 	// Execute request
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, 0, nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
+	c.updateQuota(resp.Header)
+
 	// Read response body
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, 0, nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	// Check status code
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
-	}
-
-	return body, nil
+	return body, resp.StatusCode, resp.Header, nil
 }
 
 // Response structures
@@ -77,10 +222,57 @@ type APIResponse struct {
 	Parameters map[string]interface{} `json:"parameters"`
 	Errors     interface{}            `json:"errors"` // Can be map or array
 	Results    int                    `json:"results"`
-	Paging     map[string]interface{} `json:"paging"`
+	Paging     Paging                 `json:"paging"`
 	Response   json.RawMessage        `json:"response"`
 }
 
+// Paging describes the current and total pages of a paginated response
+type Paging struct {
+	Current int `json:"current"`
+	Total   int `json:"total"`
+}
+
+// pageDelay is the pause between paginated requests to stay under rate limits
+const pageDelay = 250 * time.Millisecond
+
+// getAllPages fetches every page of a paginated endpoint and concatenates the results
+func getAllPages[T any](ctx context.Context, c *Client, endpoint string, params map[string]string) ([]T, error) {
+	var results []T
+
+	for page := 1; ; page++ {
+		pageParams := make(map[string]string, len(params)+1)
+		for key, value := range params {
+			pageParams[key] = value
+		}
+		if page > 1 {
+			pageParams["page"] = strconv.Itoa(page)
+			if err := sleep(ctx, pageDelay); err != nil {
+				return nil, err
+			}
+		}
+
+		body, err := c.doRequest(ctx, endpoint, pageParams)
+		if err != nil {
+			return nil, err
+		}
+
+		var apiResp APIResponse
+		if err := json.Unmarshal(body, &apiResp); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		var items []T
+		if err := json.Unmarshal(apiResp.Response, &items); err != nil {
+			return nil, fmt.Errorf("failed to parse page %d: %w", page, err)
+		}
+		results = append(results, items...)
+
+		if apiResp.Paging.Total <= page {
+			return results, nil
+		}
+	}
+}
+
 // Team represents a team
 type Team struct {
 	ID       int    `json:"id"`
@@ -134,18 +326,20 @@ type FixtureResponse struct {
 		Home Team `json:"home"`
 		Away Team `json:"away"`
 	} `json:"teams"`
+	// Goals and scores are null until a match starts, so they're pointers to tell
+	// "not played" apart from a 0-0
 	Goals struct {
-		Home int `json:"home"`
-		Away int `json:"away"`
+		Home *int `json:"home"`
+		Away *int `json:"away"`
 	} `json:"goals"`
 	Score struct {
 		Halftime struct {
-			Home int `json:"home"`
-			Away int `json:"away"`
+			Home *int `json:"home"`
+			Away *int `json:"away"`
 		} `json:"halftime"`
 		Fulltime struct {
-			Home int `json:"home"`
-			Away int `json:"away"`
+			Home *int `json:"home"`
+			Away *int `json:"away"`
 		} `json:"fulltime"`
 	} `json:"score"`
 }
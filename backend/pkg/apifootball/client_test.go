@@ -0,0 +1,113 @@
+package apifootball
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+// newPagedServer serves totalPages pages of /items, each holding one item whose id
+// is the page number, and counts the requests it receives
+func newPagedServer(t *testing.T, totalPages int, requests *int32) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+
+		page := 1
+		if value := r.URL.Query().Get("page"); value != "" {
+			var err error
+			if page, err = strconv.Atoi(value); err != nil {
+				t.Errorf("invalid page parameter %q", value)
+			}
+		}
+		if got := r.URL.Query().Get("league"); got != "39" {
+			t.Errorf("Expected league=39 on every page, got %q", got)
+		}
+
+		fmt.Fprintf(w, `{"get":"items","errors":[],"results":1,"paging":{"current":%d,"total":%d},"response":[{"id":%d}]}`,
+			page, totalPages, page)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+type pagedItem struct {
+	ID int `json:"id"`
+}
+
+func TestGetAllPages_TwoPages_MergesBothPages(t *testing.T) {
+	var requests int32
+	server := newPagedServer(t, 2, &requests)
+
+	client := NewClient("test-key", 0)
+	client.baseURL = server.URL
+
+	items, err := getAllPages[pagedItem](context.Background(), client, "/items", map[string]string{"league": "39"})
+	if err != nil {
+		t.Fatalf("getAllPages returned error: %v", err)
+	}
+
+	if len(items) != 2 || items[0].ID != 1 || items[1].ID != 2 {
+		t.Errorf("Expected items from pages 1 and 2 in order, got %+v", items)
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 requests, got %d", requests)
+	}
+}
+
+func TestGetAllPages_SinglePage_StopsAfterFirstRequest(t *testing.T) {
+	var requests int32
+	server := newPagedServer(t, 1, &requests)
+
+	client := NewClient("test-key", 0)
+	client.baseURL = server.URL
+
+	items, err := getAllPages[pagedItem](context.Background(), client, "/items", map[string]string{"league": "39"})
+	if err != nil {
+		t.Fatalf("getAllPages returned error: %v", err)
+	}
+
+	if len(items) != 1 {
+		t.Errorf("Expected 1 item, got %d", len(items))
+	}
+	if requests != 1 {
+		t.Errorf("Expected 1 request, got %d", requests)
+	}
+}
+
+func TestGetAllPages_CancelledBetweenPages_ReturnsContextError(t *testing.T) {
+	var requests int32
+	server := newPagedServer(t, 3, &requests)
+
+	client := NewClient("test-key", 0)
+	client.baseURL = server.URL
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client.httpClient.Transport = cancelAfterResponse{cancel: cancel}
+
+	_, err := getAllPages[pagedItem](ctx, client, "/items", map[string]string{"league": "39"})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected no requests after cancellation, got %d", requests)
+	}
+}
+
+// cancelAfterResponse cancels a context once the first response has been received
+type cancelAfterResponse struct {
+	cancel context.CancelFunc
+}
+
+func (c cancelAfterResponse) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	c.cancel()
+	return resp, err
+}
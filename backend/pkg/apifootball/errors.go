@@ -0,0 +1,67 @@
+package apifootball
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// APIError is returned when API-Football responds with a populated errors object.
+// The API reports problems like an invalid season or an exhausted quota with HTTP 200.
+type APIError struct {
+	Errors map[string]string
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	keys := make([]string, 0, len(e.Errors))
+	for key := range e.Errors {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprintf("%s: %s", key, e.Errors[key]))
+	}
+
+	return "API-Football error: " + strings.Join(parts, "; ")
+}
+
+// IsQuotaExceeded reports whether the error is due to request limits
+func (e *APIError) IsQuotaExceeded() bool {
+	_, requests := e.Errors["requests"]
+	_, rateLimit := e.Errors["rateLimit"]
+	return requests || rateLimit
+}
+
+// IsQuotaExceeded reports whether err is an APIError caused by request limits
+func IsQuotaExceeded(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.IsQuotaExceeded()
+}
+
+// checkResponseErrors returns an APIError if the response body has a non-empty errors object.
+// API-Football sends an empty array when there are no errors, and an object otherwise.
+func checkResponseErrors(body []byte) error {
+	var envelope struct {
+		Errors json.RawMessage `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil // Leave malformed bodies to the caller's parsing
+	}
+
+	var errorMap map[string]interface{}
+	if err := json.Unmarshal(envelope.Errors, &errorMap); err != nil || len(errorMap) == 0 {
+		return nil
+	}
+
+	apiErr := &APIError{Errors: make(map[string]string, len(errorMap))}
+	for key, value := range errorMap {
+		apiErr.Errors[key] = fmt.Sprint(value)
+	}
+
+	return apiErr
+}
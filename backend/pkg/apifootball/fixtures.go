@@ -1,44 +1,30 @@
 package apifootball
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
 )
 
 // GetFixtures fetches fixtures for a specific league and season
-func (c *Client) GetFixtures(leagueID, season int) ([]FixtureResponse, error) {
+func (c *Client) GetFixtures(ctx context.Context, leagueID, season int) ([]FixtureResponse, error) {
 	params := map[string]string{
 		"league": strconv.Itoa(leagueID),
 		"season": strconv.Itoa(season),
 	}
 
-	body, err := c.doRequest("/fixtures", params)
-	if err != nil {
-		return nil, err
-	}
-
-	var apiResp APIResponse
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	var fixtures []FixtureResponse
-	if err := json.Unmarshal(apiResp.Response, &fixtures); err != nil {
-		return nil, fmt.Errorf("failed to parse fixtures: %w", err)
-	}
-
-	return fixtures, nil
+	return getAllPages[FixtureResponse](ctx, c, "/fixtures", params)
 }
 
-// GetFixturesByDate fetches fixtures for a specific date
-func (c *Client) GetFixturesByDate(date string) ([]FixtureResponse, error) {
+// GetFixturesByDate fetches fixtures for a league on a specific date
+func (c *Client) GetFixturesByDate(ctx context.Context, leagueID int, date string) ([]FixtureResponse, error) {
 	params := map[string]string{
 		"date":   date, // Format: YYYY-MM-DD
-		"league": strconv.Itoa(PremierLeagueID),
+		"league": strconv.Itoa(leagueID),
 	}
 
-	body, err := c.doRequest("/fixtures", params)
+	body, err := c.doRequest(ctx, "/fixtures", params)
 	if err != nil {
 		return nil, err
 	}
@@ -56,39 +42,24 @@ func (c *Client) GetFixturesByDate(date string) ([]FixtureResponse, error) {
 	return fixtures, nil
 }
 
-// GetFixturesByDateRange fetches fixtures between two dates
-func (c *Client) GetFixturesByDateRange(from, to string) ([]FixtureResponse, error) {
+// GetFixturesByDateRange fetches fixtures for a league between two dates
+func (c *Client) GetFixturesByDateRange(ctx context.Context, leagueID int, from, to string) ([]FixtureResponse, error) {
 	params := map[string]string{
 		"from":   from, // Format: YYYY-MM-DD
 		"to":     to,   // Format: YYYY-MM-DD
-		"league": strconv.Itoa(PremierLeagueID),
-	}
-
-	body, err := c.doRequest("/fixtures", params)
-	if err != nil {
-		return nil, err
-	}
-
-	var apiResp APIResponse
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	var fixtures []FixtureResponse
-	if err := json.Unmarshal(apiResp.Response, &fixtures); err != nil {
-		return nil, fmt.Errorf("failed to parse fixtures: %w", err)
+		"league": strconv.Itoa(leagueID),
 	}
 
-	return fixtures, nil
+	return getAllPages[FixtureResponse](ctx, c, "/fixtures", params)
 }
 
 // GetFixture fetches a single fixture by ID
-func (c *Client) GetFixture(fixtureID int) (*FixtureResponse, error) {
+func (c *Client) GetFixture(ctx context.Context, fixtureID int) (*FixtureResponse, error) {
 	params := map[string]string{
 		"id": strconv.Itoa(fixtureID),
 	}
 
-	body, err := c.doRequest("/fixtures", params)
+	body, err := c.doRequest(ctx, "/fixtures", params)
 	if err != nil {
 		return nil, err
 	}
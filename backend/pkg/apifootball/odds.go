@@ -1,18 +1,19 @@
 package apifootball
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
 )
 
 // GetOddsByFixture fetches odds for a specific fixture
-func (c *Client) GetOddsByFixture(fixtureID int) ([]OddsResponse, error) {
+func (c *Client) GetOddsByFixture(ctx context.Context, fixtureID int) ([]OddsResponse, error) {
 	params := map[string]string{
 		"fixture": strconv.Itoa(fixtureID),
 	}
 
-	body, err := c.doRequest("/odds", params)
+	body, err := c.doRequest(ctx, "/odds", params)
 	if err != nil {
 		return nil, err
 	}
@@ -31,37 +32,22 @@ func (c *Client) GetOddsByFixture(fixtureID int) ([]OddsResponse, error) {
 }
 
 // GetOddsByLeague fetches odds for all fixtures in a league and season
-func (c *Client) GetOddsByLeague(leagueID, season int) ([]OddsResponse, error) {
+func (c *Client) GetOddsByLeague(ctx context.Context, leagueID, season int) ([]OddsResponse, error) {
 	params := map[string]string{
 		"league": strconv.Itoa(leagueID),
 		"season": strconv.Itoa(season),
 	}
 
-	body, err := c.doRequest("/odds", params)
-	if err != nil {
-		return nil, err
-	}
-
-	var apiResp APIResponse
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	var odds []OddsResponse
-	if err := json.Unmarshal(apiResp.Response, &odds); err != nil {
-		return nil, fmt.Errorf("failed to parse odds: %w", err)
-	}
-
-	return odds, nil
+	return getAllPages[OddsResponse](ctx, c, "/odds", params)
 }
 
 // GetLiveOdds fetches live odds for a specific fixture
-func (c *Client) GetLiveOdds(fixtureID int) ([]OddsResponse, error) {
+func (c *Client) GetLiveOdds(ctx context.Context, fixtureID int) ([]OddsResponse, error) {
 	params := map[string]string{
 		"fixture": strconv.Itoa(fixtureID),
 	}
 
-	body, err := c.doRequest("/odds/live", params)
+	body, err := c.doRequest(ctx, "/odds/live", params)
 	if err != nil {
 		return nil, err
 	}
@@ -80,10 +66,10 @@ func (c *Client) GetLiveOdds(fixtureID int) ([]OddsResponse, error) {
 }
 
 // GetBookmakers fetches the list of available bookmakers
-func (c *Client) GetBookmakers() ([]BookmakerInfo, error) {
+func (c *Client) GetBookmakers(ctx context.Context) ([]BookmakerInfo, error) {
 	params := map[string]string{}
 
-	body, err := c.doRequest("/odds/bookmakers", params)
+	body, err := c.doRequest(ctx, "/odds/bookmakers", params)
 	if err != nil {
 		return nil, err
 	}
@@ -102,10 +88,10 @@ func (c *Client) GetBookmakers() ([]BookmakerInfo, error) {
 }
 
 // GetBetTypes fetches the list of available bet types
-func (c *Client) GetBetTypes() ([]BetTypeInfo, error) {
+func (c *Client) GetBetTypes(ctx context.Context) ([]BetTypeInfo, error) {
 	params := map[string]string{}
 
-	body, err := c.doRequest("/odds/bets", params)
+	body, err := c.doRequest(ctx, "/odds/bets", params)
 	if err != nil {
 		return nil, err
 	}
@@ -1,19 +1,20 @@
 package apifootball
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
 )
 
 // GetStandings fetches league standings for a specific season
-func (c *Client) GetStandings(leagueID, season int) (*StandingsResponse, error) {
+func (c *Client) GetStandings(ctx context.Context, leagueID, season int) (*StandingsResponse, error) {
 	params := map[string]string{
 		"league": strconv.Itoa(leagueID),
 		"season": strconv.Itoa(season),
 	}
 
-	body, err := c.doRequest("/standings", params)
+	body, err := c.doRequest(ctx, "/standings", params)
 	if err != nil {
 		return nil, err
 	}
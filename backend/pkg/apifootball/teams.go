@@ -1,6 +1,7 @@
 package apifootball
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
@@ -13,13 +14,13 @@ type TeamResponse struct {
 }
 
 // GetTeams fetches all teams for a specific league and season
-func (c *Client) GetTeams(leagueID, season int) ([]TeamResponse, error) {
+func (c *Client) GetTeams(ctx context.Context, leagueID, season int) ([]TeamResponse, error) {
 	params := map[string]string{
 		"league": strconv.Itoa(leagueID),
 		"season": strconv.Itoa(season),
 	}
 
-	body, err := c.doRequest("/teams", params)
+	body, err := c.doRequest(ctx, "/teams", params)
 	if err != nil {
 		return nil, err
 	}
@@ -38,12 +39,12 @@ func (c *Client) GetTeams(leagueID, season int) ([]TeamResponse, error) {
 }
 
 // GetTeam fetches a single team by ID
-func (c *Client) GetTeam(teamID int) (*TeamResponse, error) {
+func (c *Client) GetTeam(ctx context.Context, teamID int) (*TeamResponse, error) {
 	params := map[string]string{
 		"id": strconv.Itoa(teamID),
 	}
 
-	body, err := c.doRequest("/teams", params)
+	body, err := c.doRequest(ctx, "/teams", params)
 	if err != nil {
 		return nil, err
 	}
@@ -17,6 +17,10 @@ func New(databaseURL string) (*DB, error) {
 		return nil, fmt.Errorf("unable to parse database URL: %w", err)
 	}
 
+	// Timestamps such as fixtures.match_date are stored in UTC without a zone, so
+	// NOW() must be evaluated in UTC for comparisons against them
+	config.ConnConfig.RuntimeParams["timezone"] = "UTC"
+
 	pool, err := pgxpool.NewWithConfig(context.Background(), config)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create connection pool: %w", err)
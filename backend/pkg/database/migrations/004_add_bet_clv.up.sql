@@ -0,0 +1,3 @@
+-- Add closing line value to bets
+-- CLV = (placed_odds / closing_odds) - 1, positive means we beat the closing line
+ALTER TABLE bets ADD COLUMN IF NOT EXISTS closing_line_value DECIMAL(10, 4);
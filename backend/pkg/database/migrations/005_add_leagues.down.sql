@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS team_leagues;
+
+DROP INDEX IF EXISTS idx_fixtures_league_season;
+DROP INDEX IF EXISTS idx_fixtures_league;
+ALTER TABLE fixtures DROP COLUMN IF EXISTS league_id;
+
+DROP TRIGGER IF EXISTS update_leagues_updated_at ON leagues;
+DROP TABLE IF EXISTS leagues;
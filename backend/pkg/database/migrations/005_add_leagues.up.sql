@@ -0,0 +1,45 @@
+-- Create leagues table
+CREATE TABLE IF NOT EXISTS leagues (
+    id SERIAL PRIMARY KEY,
+    api_football_id INTEGER UNIQUE NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    country VARCHAR(100),
+    odds_api_sport_key VARCHAR(100),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_leagues_api_football_id ON leagues(api_football_id);
+
+CREATE TRIGGER update_leagues_updated_at BEFORE UPDATE ON leagues
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Seed supported leagues
+INSERT INTO leagues (api_football_id, name, country, odds_api_sport_key) VALUES
+    (39, 'Premier League', 'England', 'soccer_epl'),
+    (140, 'La Liga', 'Spain', 'soccer_spain_la_liga'),
+    (78, 'Bundesliga', 'Germany', 'soccer_germany_bundesliga'),
+    (135, 'Serie A', 'Italy', 'soccer_italy_serie_a'),
+    (61, 'Ligue 1', 'France', 'soccer_france_ligue_one')
+ON CONFLICT (api_football_id) DO NOTHING;
+
+-- Scope fixtures by league
+ALTER TABLE fixtures ADD COLUMN IF NOT EXISTS league_id INTEGER REFERENCES leagues(id);
+
+CREATE INDEX idx_fixtures_league ON fixtures(league_id);
+CREATE INDEX idx_fixtures_league_season ON fixtures(league_id, season);
+
+-- Existing fixtures were all synced from the Premier League
+UPDATE fixtures SET league_id = (SELECT id FROM leagues WHERE api_football_id = 39)
+WHERE league_id IS NULL;
+
+-- Teams can play in several leagues across seasons (promotion/relegation)
+CREATE TABLE IF NOT EXISTS team_leagues (
+    team_id INTEGER REFERENCES teams(id) ON DELETE CASCADE,
+    league_id INTEGER REFERENCES leagues(id) ON DELETE CASCADE,
+    season INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (team_id, league_id, season)
+);
+
+CREATE INDEX idx_team_leagues_league_season ON team_leagues(league_id, season);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_odds_fixture_market_line;
+ALTER TABLE odds DROP COLUMN IF EXISTS line;
@@ -0,0 +1,7 @@
+-- Store the handicap/totals line (e.g. 2.5 goals) for each odds row; 0 for markets without a line
+ALTER TABLE odds ADD COLUMN IF NOT EXISTS line DECIMAL(5, 2) NOT NULL DEFAULT 0;
+
+-- Existing totals odds were all recorded for the 2.5 line
+UPDATE odds SET line = 2.5 WHERE market_type IN ('totals', 'over_under') AND line = 0;
+
+CREATE INDEX idx_odds_fixture_market_line ON odds(fixture_id, market_type, line);
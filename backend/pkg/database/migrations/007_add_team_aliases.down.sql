@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS unmatched_team_names;
+
+DROP INDEX IF EXISTS idx_team_aliases_team;
+DROP INDEX IF EXISTS idx_team_aliases_alias;
+DROP TABLE IF EXISTS team_aliases;
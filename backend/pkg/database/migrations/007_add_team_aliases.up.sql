@@ -0,0 +1,40 @@
+-- Alternative team names (e.g. from The Odds API) mapped to our teams
+CREATE TABLE IF NOT EXISTS team_aliases (
+    id SERIAL PRIMARY KEY,
+    alias VARCHAR(100) NOT NULL,
+    team_id INTEGER REFERENCES teams(id) ON DELETE CASCADE NOT NULL,
+    source VARCHAR(50) NOT NULL DEFAULT 'odds_api',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_team_aliases_alias ON team_aliases(LOWER(alias));
+CREATE INDEX idx_team_aliases_team ON team_aliases(team_id);
+
+-- Team names we couldn't resolve, so aliases can be added for them
+CREATE TABLE IF NOT EXISTS unmatched_team_names (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    source VARCHAR(50) NOT NULL DEFAULT 'odds_api',
+    occurrences INTEGER NOT NULL DEFAULT 1,
+    first_seen_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(name, source)
+);
+
+-- Seed aliases for Odds API spellings of Premier League teams
+INSERT INTO team_aliases (alias, team_id)
+SELECT a.alias, t.id
+FROM (VALUES
+    ('Tottenham Hotspur', 'Tottenham'),
+    ('Wolverhampton Wanderers', 'Wolves'),
+    ('Brighton and Hove Albion', 'Brighton'),
+    ('West Ham United', 'West Ham'),
+    ('Newcastle United', 'Newcastle'),
+    ('Leicester City', 'Leicester'),
+    ('Ipswich Town', 'Ipswich'),
+    ('Luton Town', 'Luton'),
+    ('Sheffield United', 'Sheffield Utd'),
+    ('AFC Bournemouth', 'Bournemouth')
+) AS a(alias, team_name)
+JOIN teams t ON t.name = a.team_name
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,2 @@
+ALTER TABLE unmatched_team_names DROP COLUMN IF EXISTS candidate_score;
+ALTER TABLE unmatched_team_names DROP COLUMN IF EXISTS candidate_team_id;
@@ -0,0 +1,3 @@
+-- Best fuzzy candidate seen for an unmatched name, to speed up adding aliases
+ALTER TABLE unmatched_team_names ADD COLUMN IF NOT EXISTS candidate_team_id INTEGER REFERENCES teams(id) ON DELETE SET NULL;
+ALTER TABLE unmatched_team_names ADD COLUMN IF NOT EXISTS candidate_score DECIMAL(4,3);
@@ -0,0 +1,9 @@
+-- Outcome of the most recent run of each sync type (fixtures, results, odds)
+CREATE TABLE IF NOT EXISTS sync_status (
+    sync_type VARCHAR(30) PRIMARY KEY,
+    last_success_at TIMESTAMP,
+    last_success_rows INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    last_error_at TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
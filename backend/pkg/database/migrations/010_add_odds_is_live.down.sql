@@ -0,0 +1 @@
+ALTER TABLE odds DROP COLUMN IF EXISTS is_live;
@@ -0,0 +1,2 @@
+-- Flag odds captured while the match was in play
+ALTER TABLE odds ADD COLUMN IF NOT EXISTS is_live BOOLEAN NOT NULL DEFAULT FALSE;
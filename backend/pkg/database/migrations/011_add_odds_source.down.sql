@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_odds_fixture_source;
+ALTER TABLE odds DROP COLUMN IF EXISTS source;
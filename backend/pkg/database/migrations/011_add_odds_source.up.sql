@@ -0,0 +1,4 @@
+-- Provider each odds row came from, so bookmaker keys from different providers don't collide
+ALTER TABLE odds ADD COLUMN IF NOT EXISTS source VARCHAR(20) NOT NULL DEFAULT 'odds_api';
+
+CREATE INDEX IF NOT EXISTS idx_odds_fixture_source ON odds(fixture_id, source);
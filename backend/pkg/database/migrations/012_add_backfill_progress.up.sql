@@ -0,0 +1,11 @@
+-- Per league/season checkpoints so an interrupted backfill can resume where it stopped
+CREATE TABLE IF NOT EXISTS backfill_progress (
+    league_id INTEGER NOT NULL, -- API-Football league ID
+    season INTEGER NOT NULL,
+    teams_done BOOLEAN NOT NULL DEFAULT FALSE,
+    fixtures_done BOOLEAN NOT NULL DEFAULT FALSE,
+    last_round VARCHAR(100), -- Last fully synced round while fixtures are in progress
+    fixtures_synced INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (league_id, season)
+);
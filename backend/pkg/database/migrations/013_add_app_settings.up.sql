@@ -0,0 +1,6 @@
+-- Runtime settings changed through the API, stored as JSON documents by key
+CREATE TABLE IF NOT EXISTS app_settings (
+    key VARCHAR(100) PRIMARY KEY, -- e.g. accumulator_config
+    value JSONB NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,10 @@
+-- Outcomes saved by the user to revisit before kickoff
+CREATE TABLE IF NOT EXISTS watchlist (
+    id SERIAL PRIMARY KEY,
+    fixture_id INTEGER REFERENCES fixtures(id) ON DELETE CASCADE NOT NULL,
+    market VARCHAR(50) NOT NULL,   -- ML market key, e.g. 1x2, over_under
+    outcome VARCHAR(50) NOT NULL,  -- ML outcome key, e.g. home_win, over_2_5
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_watchlist_selection ON watchlist(fixture_id, market, outcome);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_odds_bookmaker_timestamp;
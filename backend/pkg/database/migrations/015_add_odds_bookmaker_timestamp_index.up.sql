@@ -0,0 +1,2 @@
+-- Serves listing a bookmaker's odds newest first (GET /api/odds?bookmaker=)
+CREATE INDEX IF NOT EXISTS idx_odds_bookmaker_timestamp ON odds(bookmaker, timestamp DESC);
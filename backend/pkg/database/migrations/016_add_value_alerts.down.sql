@@ -0,0 +1 @@
+DROP TABLE IF EXISTS value_alerts;
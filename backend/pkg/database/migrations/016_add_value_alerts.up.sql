@@ -0,0 +1,17 @@
+-- Outcomes where the model probability exceeded the market no-vig probability by at
+-- least the alert threshold, one row per outcome updated on every scan that sees it
+CREATE TABLE IF NOT EXISTS value_alerts (
+    id SERIAL PRIMARY KEY,
+    fixture_id INTEGER REFERENCES fixtures(id) ON DELETE CASCADE NOT NULL,
+    market VARCHAR(50) NOT NULL,   -- ML market key, e.g. 1x2, over_under
+    outcome VARCHAR(50) NOT NULL,  -- ML outcome key, e.g. home_win, over_2_5
+    model_probability DECIMAL(5, 4) NOT NULL,
+    market_probability DECIMAL(5, 4) NOT NULL,
+    edge DECIMAL(5, 4) NOT NULL,   -- model_probability - market_probability
+    best_odds DECIMAL(10, 2) NOT NULL,
+    bookmaker VARCHAR(50),
+    first_seen_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_value_alerts_selection ON value_alerts(fixture_id, market, outcome);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_fixtures_deleted_at;
+ALTER TABLE fixtures DROP COLUMN IF EXISTS deleted_at;
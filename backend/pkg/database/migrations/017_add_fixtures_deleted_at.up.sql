@@ -0,0 +1,4 @@
+-- Soft delete: deleted fixtures are hidden from reads but can be restored
+ALTER TABLE fixtures ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_fixtures_deleted_at ON fixtures(deleted_at) WHERE deleted_at IS NOT NULL;
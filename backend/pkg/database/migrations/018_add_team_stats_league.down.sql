@@ -0,0 +1,16 @@
+DROP INDEX IF EXISTS idx_team_stats_league_season;
+CREATE INDEX IF NOT EXISTS idx_team_stats_team_season ON team_stats(team_id, season);
+
+ALTER TABLE team_stats DROP CONSTRAINT IF EXISTS team_stats_team_id_league_id_season_key;
+
+-- Keep one row per team and season (the one with most matches) before restoring the old key
+DELETE FROM team_stats a
+USING team_stats b
+WHERE a.team_id = b.team_id AND a.season = b.season
+    AND (a.matches_played, a.id) < (b.matches_played, b.id);
+
+ALTER TABLE team_stats ADD CONSTRAINT team_stats_team_id_season_key UNIQUE (team_id, season);
+
+ALTER TABLE team_stats DROP COLUMN IF EXISTS description;
+ALTER TABLE team_stats DROP COLUMN IF EXISTS rank;
+ALTER TABLE team_stats DROP COLUMN IF EXISTS league_id;
@@ -0,0 +1,17 @@
+-- Scope team stats by league, so a team playing in two competitions in a season
+-- keeps a row for each, and keep the standings position and its description
+ALTER TABLE team_stats ADD COLUMN IF NOT EXISTS league_id INTEGER REFERENCES leagues(id);
+ALTER TABLE team_stats ADD COLUMN IF NOT EXISTS rank INTEGER;
+ALTER TABLE team_stats ADD COLUMN IF NOT EXISTS description VARCHAR(255);
+
+-- Existing stats were all synced from the Premier League
+UPDATE team_stats SET league_id = (SELECT id FROM leagues WHERE api_football_id = 39)
+WHERE league_id IS NULL;
+
+ALTER TABLE team_stats ALTER COLUMN league_id SET NOT NULL;
+
+ALTER TABLE team_stats DROP CONSTRAINT IF EXISTS team_stats_team_id_season_key;
+ALTER TABLE team_stats ADD CONSTRAINT team_stats_team_id_league_id_season_key UNIQUE (team_id, league_id, season);
+
+DROP INDEX IF EXISTS idx_team_stats_team_season;
+CREATE INDEX IF NOT EXISTS idx_team_stats_league_season ON team_stats(league_id, season);
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS update_bookmakers_updated_at ON bookmakers;
+DROP TABLE IF EXISTS bookmakers;
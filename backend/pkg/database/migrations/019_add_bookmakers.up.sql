@@ -0,0 +1,18 @@
+-- Canonical bookmaker list per odds source, synced from the providers, with the
+-- display name and sharp/soft classification used across the API
+CREATE TABLE IF NOT EXISTS bookmakers (
+    id SERIAL PRIMARY KEY,
+    key VARCHAR(100) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    source VARCHAR(50) NOT NULL,
+    is_sharp BOOLEAN NOT NULL DEFAULT FALSE,
+    region VARCHAR(20),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(key, source)
+);
+
+CREATE INDEX idx_bookmakers_key ON bookmakers(key);
+
+CREATE TRIGGER update_bookmakers_updated_at BEFORE UPDATE ON bookmakers
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,50 @@
+package oddsapi
+
+// BookmakerInfo describes a bookmaker offered by The Odds API
+type BookmakerInfo struct {
+	Key    string `json:"key"`
+	Title  string `json:"title"`
+	Region string `json:"region"`
+}
+
+// Bookmakers lists the bookmakers The Odds API offers in the UK and EU regions.
+// The API has no bookmakers endpoint, so this follows its published bookmaker list;
+// bookmakers offered in both regions are listed under the UK.
+var Bookmakers = []BookmakerInfo{
+	{Key: "sport888", Title: "888sport", Region: RegionUK},
+	{Key: "betfair_ex_uk", Title: "Betfair Exchange", Region: RegionUK},
+	{Key: "betfair_sb_uk", Title: "Betfair Sportsbook", Region: RegionUK},
+	{Key: "betvictor", Title: "Bet Victor", Region: RegionUK},
+	{Key: "betway", Title: "Betway", Region: RegionUK},
+	{Key: "boylesports", Title: "BoyleSports", Region: RegionUK},
+	{Key: "casumo", Title: "Casumo", Region: RegionUK},
+	{Key: "coral", Title: "Coral", Region: RegionUK},
+	{Key: "grosvenor", Title: "Grosvenor", Region: RegionUK},
+	{Key: "ladbrokes_uk", Title: "Ladbrokes", Region: RegionUK},
+	{Key: "leovegas", Title: "LeoVegas", Region: RegionUK},
+	{Key: "livescorebet", Title: "LiveScore Bet", Region: RegionUK},
+	{Key: "matchbook", Title: "Matchbook", Region: RegionUK},
+	{Key: "paddypower", Title: "Paddy Power", Region: RegionUK},
+	{Key: "skybet", Title: "Sky Bet", Region: RegionUK},
+	{Key: "smarkets", Title: "Smarkets", Region: RegionUK},
+	{Key: "unibet_uk", Title: "Unibet", Region: RegionUK},
+	{Key: "virginbet", Title: "Virgin Bet", Region: RegionUK},
+	{Key: "williamhill", Title: "William Hill", Region: RegionUK},
+	{Key: "onexbet", Title: "1xBet", Region: RegionEU},
+	{Key: "betclic", Title: "Betclic", Region: RegionEU},
+	{Key: "betfair_ex_eu", Title: "Betfair Exchange", Region: RegionEU},
+	{Key: "betonlineag", Title: "BetOnline.ag", Region: RegionEU},
+	{Key: "betsson", Title: "Betsson", Region: RegionEU},
+	{Key: "coolbet", Title: "Coolbet", Region: RegionEU},
+	{Key: "everygame", Title: "Everygame", Region: RegionEU},
+	{Key: "gtbets", Title: "GTbets", Region: RegionEU},
+	{Key: "marathonbet", Title: "Marathon Bet", Region: RegionEU},
+	{Key: "mybookieag", Title: "MyBookie.ag", Region: RegionEU},
+	{Key: "nordicbet", Title: "Nordic Bet", Region: RegionEU},
+	{Key: "pinnacle", Title: "Pinnacle", Region: RegionEU},
+	{Key: "suprabets", Title: "Suprabets", Region: RegionEU},
+	{Key: "tipico_de", Title: "Tipico", Region: RegionEU},
+	{Key: "unibet_eu", Title: "Unibet", Region: RegionEU},
+	{Key: "winamax_de", Title: "Winamax (DE)", Region: RegionEU},
+	{Key: "winamax_fr", Title: "Winamax (FR)", Region: RegionEU},
+}
@@ -1,16 +1,24 @@
 package oddsapi
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"net/url"
+	"strconv"
+	"sync"
 	"time"
 )
 
 const (
 	BaseURL      = "https://api.the-odds-api.com/v4"
 	SportEPL     = "soccer_epl" // English Premier League
+	SportLaLiga     = "soccer_spain_la_liga"
+	SportBundesliga = "soccer_germany_bundesliga"
+	SportSerieA     = "soccer_italy_serie_a"
+	SportLigue1     = "soccer_france_ligue_one"
 	RegionUK     = "uk"
 	RegionEU     = "eu"
 	RegionUS     = "us"
@@ -24,26 +32,89 @@ const (
 	MarketSpread = "spreads"     // Handicap
 )
 
+// Credits holds the request credits reported by The Odds API response headers.
+// A value of -1 means the header has not been seen yet.
+type Credits struct {
+	Remaining int       `json:"remaining"`
+	Used      int       `json:"used"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
 // Client represents The Odds API client
 type Client struct {
 	apiKey     string
 	httpClient *http.Client
 	baseURL    string
+
+	// lowCreditsThreshold logs a warning when remaining credits drop below it (0 = never)
+	lowCreditsThreshold int
+
+	credits   Credits
+	creditsMu sync.RWMutex
 }
 
-// NewClient creates a new Odds API client
-func NewClient(apiKey string) *Client {
+// NewClient creates a new Odds API client.
+// lowCreditsThreshold logs a warning whenever fewer credits remain; pass 0 to disable it.
+func NewClient(apiKey string, lowCreditsThreshold int) *Client {
 	return &Client{
 		apiKey: apiKey,
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
-		baseURL: BaseURL,
+		baseURL:             BaseURL,
+		lowCreditsThreshold: lowCreditsThreshold,
+		credits: Credits{
+			Remaining: -1,
+			Used:      -1,
+		},
+	}
+}
+
+// RemainingRequests returns the latest remaining credit count, or -1 if unknown
+func (c *Client) RemainingRequests() int {
+	return c.Credits().Remaining
+}
+
+// Credits returns the latest credit usage reported by the API
+func (c *Client) Credits() Credits {
+	c.creditsMu.RLock()
+	defer c.creditsMu.RUnlock()
+	return c.credits
+}
+
+// updateCredits parses the credit usage headers from a response
+func (c *Client) updateCredits(header http.Header) {
+	c.creditsMu.Lock()
+	defer c.creditsMu.Unlock()
+
+	parse := func(name string, target *int) bool {
+		value := header.Get(name)
+		if value == "" {
+			return false
+		}
+		// Counts can be reported as decimals (e.g. "480.0")
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return false
+		}
+		*target = int(n)
+		return true
+	}
+
+	remainingSeen := parse("x-requests-remaining", &c.credits.Remaining)
+	usedSeen := parse("x-requests-used", &c.credits.Used)
+	if !remainingSeen && !usedSeen {
+		return
+	}
+	c.credits.UpdatedAt = time.Now()
+
+	if remainingSeen && c.credits.Remaining < c.lowCreditsThreshold {
+		log.Printf("WARNING: The Odds API credits low: %d remaining (%d used)", c.credits.Remaining, c.credits.Used)
 	}
 }
 
 // doRequest performs HTTP request with API key parameter
-func (c *Client) doRequest(endpoint string, params map[string]string) ([]byte, error) {
+func (c *Client) doRequest(ctx context.Context, endpoint string, params map[string]string) ([]byte, error) {
 	// Build URL
 	reqURL, err := url.Parse(c.baseURL + endpoint)
 	if err != nil {
@@ -59,7 +130,7 @@ func (c *Client) doRequest(endpoint string, params map[string]string) ([]byte, e
 	reqURL.RawQuery = q.Encode()
 
 	// Create request
-	req, err := http.NewRequest("GET", reqURL.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL.String(), nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -71,6 +142,8 @@ func (c *Client) doRequest(endpoint string, params map[string]string) ([]byte, e
 	}
 	defer resp.Body.Close()
 
+	c.updateCredits(resp.Header)
+
 	// Read response body
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -1,22 +1,46 @@
 package oddsapi
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 )
 
-// GetOdds fetches odds for a specific sport and markets
+// commenceTimeFormat is the ISO 8601 format the API accepts for commence time filters
+const commenceTimeFormat = "2006-01-02T15:04:05Z"
+
+// TimeWindow limits odds to events commencing between From and To.
+// A zero From or To leaves that end of the window open.
+type TimeWindow struct {
+	From time.Time
+	To   time.Time
+}
+
+// addParams sets the commenceTimeFrom/commenceTimeTo query parameters for the window
+func (w TimeWindow) addParams(params map[string]string) {
+	if !w.From.IsZero() {
+		params["commenceTimeFrom"] = w.From.UTC().Format(commenceTimeFormat)
+	}
+	if !w.To.IsZero() {
+		params["commenceTimeTo"] = w.To.UTC().Format(commenceTimeFormat)
+	}
+}
+
+// GetOdds fetches odds for a specific sport and markets, limited to events commencing
+// within window
 // markets: h2h, totals, btts, spreads (comma-separated)
 // regions: uk, eu, us (comma-separated)
-func (c *Client) GetOdds(sport string, markets []string, regions []string) ([]Event, error) {
+func (c *Client) GetOdds(ctx context.Context, sport string, markets []string, regions []string, window TimeWindow) ([]Event, error) {
 	params := map[string]string{
 		"markets": strings.Join(markets, ","),
 		"regions": strings.Join(regions, ","),
 	}
+	window.addParams(params)
 
 	endpoint := fmt.Sprintf("/sports/%s/odds", sport)
-	body, err := c.doRequest(endpoint, params)
+	body, err := c.doRequest(ctx, endpoint, params)
 	if err != nil {
 		return nil, err
 	}
@@ -30,14 +54,14 @@ func (c *Client) GetOdds(sport string, markets []string, regions []string) ([]Ev
 }
 
 // GetEventOdds fetches odds for a specific event by ID
-func (c *Client) GetEventOdds(sport, eventID string, markets []string, regions []string) (*Event, error) {
+func (c *Client) GetEventOdds(ctx context.Context, sport, eventID string, markets []string, regions []string) (*Event, error) {
 	params := map[string]string{
 		"markets": strings.Join(markets, ","),
 		"regions": strings.Join(regions, ","),
 	}
 
 	endpoint := fmt.Sprintf("/sports/%s/events/%s/odds", sport, eventID)
-	body, err := c.doRequest(endpoint, params)
+	body, err := c.doRequest(ctx, endpoint, params)
 	if err != nil {
 		return nil, err
 	}
@@ -50,37 +74,55 @@ func (c *Client) GetEventOdds(sport, eventID string, markets []string, regions [
 	return &event, nil
 }
 
-// GetEPLOdds fetches odds for English Premier League matches
+// GetLeagueOdds fetches odds for a league's matches commencing within window using
+// UK and EU regions
+func (c *Client) GetLeagueOdds(ctx context.Context, sport string, markets []string, window TimeWindow) ([]Event, error) {
+	regions := []string{RegionUK, RegionEU}
+	return c.GetOdds(ctx, sport, markets, regions, window)
+}
+
+// GetAllMarkets fetches all supported markets for a league
+func (c *Client) GetAllMarkets(ctx context.Context, sport string) ([]Event, error) {
+	markets := []string{MarketH2H, MarketTotals, MarketBTTS}
+	return c.GetLeagueOdds(ctx, sport, markets, TimeWindow{})
+}
+
+// GetEPLOdds fetches odds for English Premier League matches commencing within window
 // This is a convenience method for the most common use case
-func (c *Client) GetEPLOdds(markets []string) ([]Event, error) {
+func (c *Client) GetEPLOdds(ctx context.Context, markets []string, window TimeWindow) ([]Event, error) {
 	regions := []string{RegionUK, RegionEU}
-	return c.GetOdds(SportEPL, markets, regions)
+	return c.GetOdds(ctx, SportEPL, markets, regions, window)
+}
+
+// GetUpcomingOdds fetches odds for EPL matches commencing between from and to
+func (c *Client) GetUpcomingOdds(ctx context.Context, markets []string, from, to time.Time) ([]Event, error) {
+	return c.GetEPLOdds(ctx, markets, TimeWindow{From: from, To: to})
 }
 
 // GetAllMarketsEPL fetches all available markets for EPL
-func (c *Client) GetAllMarketsEPL() ([]Event, error) {
+func (c *Client) GetAllMarketsEPL(ctx context.Context) ([]Event, error) {
 	markets := []string{MarketH2H, MarketTotals, MarketBTTS}
-	return c.GetEPLOdds(markets)
+	return c.GetEPLOdds(ctx, markets, TimeWindow{})
 }
 
 // GetH2HOdds fetches 1X2 (Home/Draw/Away) odds for EPL
-func (c *Client) GetH2HOdds() ([]Event, error) {
-	return c.GetEPLOdds([]string{MarketH2H})
+func (c *Client) GetH2HOdds(ctx context.Context) ([]Event, error) {
+	return c.GetEPLOdds(ctx, []string{MarketH2H}, TimeWindow{})
 }
 
 // GetTotalsOdds fetches Over/Under odds for EPL
-func (c *Client) GetTotalsOdds() ([]Event, error) {
-	return c.GetEPLOdds([]string{MarketTotals})
+func (c *Client) GetTotalsOdds(ctx context.Context) ([]Event, error) {
+	return c.GetEPLOdds(ctx, []string{MarketTotals}, TimeWindow{})
 }
 
 // GetBTTSOdds fetches Both Teams to Score odds for EPL
-func (c *Client) GetBTTSOdds() ([]Event, error) {
-	return c.GetEPLOdds([]string{MarketBTTS})
+func (c *Client) GetBTTSOdds(ctx context.Context) ([]Event, error) {
+	return c.GetEPLOdds(ctx, []string{MarketBTTS}, TimeWindow{})
 }
 
 // GetSports fetches list of available sports
-func (c *Client) GetSports() ([]Sport, error) {
-	body, err := c.doRequest("/sports", nil)
+func (c *Client) GetSports(ctx context.Context) ([]Sport, error) {
+	body, err := c.doRequest(ctx, "/sports", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,34 @@
+import { NextRequest, NextResponse } from 'next/server';
+
+// Backend base URL as seen from the Next.js server, which may differ from the browser's
+const BACKEND_URL =
+  process.env.BACKEND_API_URL || process.env.NEXT_PUBLIC_API_URL || 'http://localhost:8000/api';
+// Server-only key sent as X-API-Key; never exposed to the browser bundle
+const API_KEY = process.env.API_KEY;
+
+// Forwards write requests to the backend, adding the API key on the server side
+async function proxy(request: NextRequest, { params }: { params: { path: string[] } }) {
+  const url = `${BACKEND_URL}/${params.path.map(encodeURIComponent).join('/')}${request.nextUrl.search}`;
+
+  const response = await fetch(url, {
+    method: request.method,
+    headers: {
+      'Content-Type': request.headers.get('Content-Type') || 'application/json',
+      ...(API_KEY ? { 'X-API-Key': API_KEY } : {}),
+    },
+    body: await request.text(),
+    cache: 'no-store',
+  });
+
+  const headers = new Headers();
+  for (const name of ['Content-Type', 'Retry-After']) {
+    const value = response.headers.get(name);
+    if (value) {
+      headers.set(name, value);
+    }
+  }
+
+  return new NextResponse(await response.text(), { status: response.status, headers });
+}
+
+export { proxy as POST, proxy as PUT, proxy as PATCH, proxy as DELETE };
@@ -30,10 +30,14 @@ import {
 } from '@/types';
 
 const API_URL = process.env.NEXT_PUBLIC_API_URL || 'http://localhost:8000/api';
+// Write requests go through the Next.js proxy route, which adds the server-only API key
+const WRITE_PROXY_URL = '/api/backend';
 
 // Helper for API calls with error handling
 async function fetchApi<T>(endpoint: string, options?: RequestInit): Promise<T> {
-  const response = await fetch(`${API_URL}${endpoint}`, {
+  const method = options?.method?.toUpperCase() || 'GET';
+  const baseUrl = method === 'GET' ? API_URL : WRITE_PROXY_URL;
+  const response = await fetch(`${baseUrl}${endpoint}`, {
     headers: {
       'Content-Type': 'application/json',
       ...options?.headers,
@@ -107,6 +107,7 @@ export interface AccumulatorSummary {
   total_accumulators: number;
   total_doubles: number;
   total_trebles: number;
+  by_legs: Record<number, number>;
   total_suggested_stake: number;
   total_potential_return: number;
   average_ev: number;
@@ -127,6 +127,20 @@ class MultiMarketPredictionResponse(BaseModel):
     predicted_at: str
 
 
+class BatchPredictionError(BaseModel):
+    """Error for a single fixture within a batch"""
+    fixture_id: Optional[int]
+    error: str
+
+
+class BatchMultiMarketPredictionResponse(BaseModel):
+    """Batch multi-market prediction response"""
+    predictions: List[MultiMarketPredictionResponse]
+    errors: List[BatchPredictionError]
+    count: int
+    predicted_at: str
+
+
 class PredictionResponse(BaseModel):
     """Legacy single prediction response (1X2 only)"""
     fixture_id: Optional[int]
@@ -413,6 +427,37 @@ async def predict_multi_market(
         raise HTTPException(status_code=500, detail=str(e))
 
 
+@router.post("/predict/multi/batch", response_model=BatchMultiMarketPredictionResponse)
+async def predict_multi_market_batch(request: BatchPredictionRequest):
+    """
+    Generate multi-market predictions for multiple fixtures in one request
+
+    Fixtures that fail are reported in **errors** rather than failing the batch.
+    """
+    predictions = []
+    errors = []
+
+    for fixture in request.fixtures:
+        try:
+            match_date = datetime.fromisoformat(fixture.match_date.replace('Z', '+00:00'))
+            result = make_multi_market_prediction(
+                home_team_id=fixture.home_team_id,
+                away_team_id=fixture.away_team_id,
+                match_date=match_date,
+                fixture_id=fixture.fixture_id
+            )
+            predictions.append(result)
+        except Exception as e:
+            errors.append({'fixture_id': fixture.fixture_id, 'error': str(e)})
+
+    return BatchMultiMarketPredictionResponse(
+        predictions=predictions,
+        errors=errors,
+        count=len(predictions),
+        predicted_at=datetime.now().isoformat()
+    )
+
+
 @router.post("/predict/batch", response_model=BatchPredictionResponse)
 async def predict_batch(request: BatchPredictionRequest):
     """